@@ -1,6 +1,7 @@
 package console
 
 import (
+	"github.com/MichaelDiBernardo/srl/lib/config"
 	"github.com/MichaelDiBernardo/srl/lib/game"
 	"github.com/MichaelDiBernardo/srl/lib/math"
 	"github.com/nsf/termbox-go"
@@ -15,11 +16,13 @@ type Console struct {
 	curscr  *screen
 }
 
-// Create a new console client.
-func New() *Console {
-	display := &tbdisplay{}
+// Create a new console client that uses the player's config 'cfg'. Changes
+// made on the options screen are saved to 'cfgpath'.
+func New(cfg *config.Config, cfgpath string) *Console {
+	settings := newSettings(cfg, cfgpath)
+	display := &schemedisplay{display: &tbdisplay{}, settings: settings}
 	screens := map[game.Mode]*screen{
-		game.ModeHud:       newHudScreen(display),
+		game.ModeHud:       newHudScreen(display, settings),
 		game.ModeInventory: newInventoryScreen(display),
 		game.ModePickup:    newPickupScreen(display),
 		game.ModeEquip:     newEquipScreen(display),
@@ -27,6 +30,11 @@ func New() *Console {
 		game.ModeDrop:      newDropScreen(display),
 		game.ModeUse:       newUseScreen(display),
 		game.ModeSheet:     newSheetScreen(display),
+		game.ModeCast:      newCastScreen(display),
+		game.ModeSing:      newSingScreen(display),
+		game.ModeHistory:   newHistoryScreen(display),
+		game.ModeOptions:   newOptionsScreen(display, settings),
+		game.ModeCreate:    newCreateScreen(display),
 		game.ModeGameOver:  newGameOverScreen(display),
 	}
 	console := &Console{
@@ -0,0 +1,186 @@
+package console
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/nsf/termbox-go"
+)
+
+func newCreateScreen(display display) *screen {
+	return &screen{
+		display: display,
+		panels:  []panel{newCreatePanel(display)},
+	}
+}
+
+// Steps the player goes through when creating a character.
+type createStep int
+
+const (
+	createName createStep = iota
+	createRace
+	createStats
+)
+
+// Walks the player through picking a name, a race, and their stats.
+type createPanel struct {
+	display display
+	step    createStep
+	name    string
+	cur     game.StatName
+	// The last thing the game told us, e.g. that a stat can't go any higher.
+	// It's cleared on the next key press.
+	msg string
+}
+
+func newCreatePanel(display display) *createPanel {
+	return &createPanel{display: display}
+}
+
+func (c *createPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type != termbox.EventKey {
+		return nocommand()
+	}
+	c.msg = ""
+
+	switch c.step {
+	case createName:
+		return c.handleName(tboxev)
+	case createRace:
+		return c.handleRace(tboxev)
+	case createStats:
+		return c.handleStats(tboxev)
+	}
+	return nocommand()
+}
+
+func (c *createPanel) handleName(tboxev termbox.Event) (game.Command, error) {
+	switch tboxev.Key {
+	case termbox.KeyEnter:
+		if c.name == "" {
+			return nocommand()
+		}
+		c.step = createRace
+		return game.SetNameCommand{Name: c.name}, nil
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if l := len(c.name); l > 0 {
+			c.name = c.name[:l-1]
+		}
+		return nocommand()
+	case termbox.KeySpace:
+		if c.name != "" && len(c.name) < game.MaxNameLen {
+			c.name += " "
+		}
+		return nocommand()
+	}
+
+	if ch := tboxev.Ch; ch > ' ' && ch < 128 && len(c.name) < game.MaxNameLen {
+		c.name += string(ch)
+	}
+	return nocommand()
+}
+
+func (c *createPanel) handleRace(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Key == termbox.KeyEsc {
+		c.step = createName
+		return nocommand()
+	}
+	if opt := selectOption(tboxev.Ch); opt != -1 && opt < len(game.Races) {
+		c.step = createStats
+		return game.ChooseRaceCommand{Race: opt}, nil
+	}
+	return nocommand()
+}
+
+func (c *createPanel) handleStats(tboxev termbox.Event) (game.Command, error) {
+	switch tboxev.Key {
+	case termbox.KeyEsc:
+		c.step = createRace
+		return nocommand()
+	case termbox.KeyEnter:
+		return game.FinishCreationCommand{}, nil
+	}
+
+	switch tboxev.Ch {
+	case 'j':
+		c.cur = (c.cur + 1) % game.NumStats
+	case 'k':
+		if c.cur == game.Str {
+			c.cur = game.Mnd
+		} else {
+			c.cur--
+		}
+	case 'h':
+		return game.SellStatCommand{Stat: c.cur}, nil
+	case 'l':
+		return game.BuyStatCommand{Stat: c.cur}, nil
+	}
+	return nocommand()
+}
+
+// Listens for messages about anything the player tried that didn't work.
+func (c *createPanel) HandleEvent(e game.Event) {
+	if ev, ok := e.(game.MessageEvent); ok {
+		c.msg = ev.Plain()
+	}
+}
+
+func (c *createPanel) Render(g *game.Game) {
+	chargen := g.Chargen
+	if chargen == nil {
+		return
+	}
+
+	c.display.Write(0, 0, "A new hero sets out for the TOWER.", termbox.ColorWhite, termbox.ColorBlack)
+
+	namefg := termbox.ColorWhite
+	if c.step == createName {
+		namefg = termbox.ColorBlue
+	}
+	c.display.Write(1, 2, fmt.Sprintf("NAME   %s", c.name), namefg, termbox.ColorBlack)
+
+	switch c.step {
+	case createName:
+		c.display.Write(1, 4, "What is your name? [Enter] to accept.", termbox.ColorWhite, termbox.ColorBlack)
+	case createRace:
+		c.renderRaces()
+	case createStats:
+		c.renderStats(chargen)
+	}
+	c.display.Write(1, 15, c.msg, termbox.ColorRed, termbox.ColorBlack)
+}
+
+func (c *createPanel) renderRaces() {
+	c.display.Write(1, 4, "Choose your people. [Esc] to go back.", termbox.ColorWhite, termbox.ColorBlack)
+	for i, race := range game.Races {
+		row := fmt.Sprintf("%c - %-8s %s", alphabet[i], race.Name, race.Desc)
+		c.display.Write(1, 6+i, row, termbox.ColorWhite, termbox.ColorBlack)
+	}
+}
+
+func (c *createPanel) renderStats(chargen *game.Chargen) {
+	race := chargen.Race
+	c.display.Write(1, 3, fmt.Sprintf("RACE   %s", race.Name), termbox.ColorWhite, termbox.ColorBlack)
+	c.display.Write(1, 5, "j/k to pick a stat, h/l to lower/raise it.", termbox.ColorWhite, termbox.ColorBlack)
+	c.display.Write(1, 6, "[Enter] to begin your quest, [Esc] to go back.", termbox.ColorWhite, termbox.ColorBlack)
+
+	for stat := game.Str; stat < game.NumStats; stat++ {
+		fg := termbox.ColorWhite
+		if stat == c.cur {
+			fg = termbox.ColorBlue
+		}
+		row := fmt.Sprintf("%s %3d = %2d %3s   next: %d", statname(stat), chargen.Stat(stat), chargen.Bought(stat), extrasign(race.Stats[stat]), chargen.NextCost(stat))
+		c.display.Write(1, 8+int(stat), row, fg, termbox.ColorBlack)
+	}
+	c.display.Write(1, 13, fmt.Sprintf("POINTS LEFT %d", chargen.PointsLeft()), termbox.ColorWhite, termbox.ColorBlack)
+}
+
+func statname(s game.StatName) string {
+	statnames := []string{
+		"STR",
+		"AGI",
+		"VIT",
+		"MND",
+	}
+	return statnames[s]
+}
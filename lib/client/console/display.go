@@ -1,6 +1,7 @@
 package console
 
 import (
+	"github.com/MichaelDiBernardo/srl/lib/config"
 	"github.com/nsf/termbox-go"
 )
 
@@ -89,3 +90,36 @@ func (d *fakedisplay) Flush() error {
 func (d *fakedisplay) PollEvent() termbox.Event {
 	return termbox.Event{}
 }
+
+// Wraps another display, and changes the colours of everything drawn on it to
+// suit the player's colour scheme.
+type schemedisplay struct {
+	display
+	settings *settings
+}
+
+func (d *schemedisplay) SetCell(x, y int, ch rune, fg, bg termbox.Attribute) {
+	fg, bg = d.scheme(fg, bg)
+	d.display.SetCell(x, y, ch, fg, bg)
+}
+
+func (d *schemedisplay) Write(x, y int, text string, fg, bg termbox.Attribute) {
+	fg, bg = d.scheme(fg, bg)
+	d.display.Write(x, y, text, fg, bg)
+}
+
+// Attributes that aren't colours, and so survive every scheme.
+const textAttrs = termbox.AttrBold | termbox.AttrUnderline | termbox.AttrReverse
+
+func (d *schemedisplay) scheme(fg, bg termbox.Attribute) (termbox.Attribute, termbox.Attribute) {
+	if d.settings.config.Colors != config.ColorsMono {
+		return fg, bg
+	}
+	// Anything drawn on a coloured background was meant to stand out, so we
+	// reverse it instead.
+	mono := termbox.ColorWhite | fg&textAttrs
+	if c := bg &^ textAttrs; c != termbox.ColorBlack && c != termbox.ColorDefault {
+		mono |= termbox.AttrReverse
+	}
+	return mono, termbox.ColorBlack
+}
@@ -33,10 +33,50 @@ func (g *gameOverPanel) HandleInput(tboxev termbox.Event) (game.Command, error)
 func (p *gameOverPanel) HandleEvent(e game.Event) {
 }
 
+// How many of the best games to list.
+const gameOverScores = 10
+
 // Render the panel.
 func (p *gameOverPanel) Render(g *game.Game) {
-	msg := center(fmt.Sprintf("✝✝✝ Ur dead %s ✝✝✝", g.Player.Spec.Name), consoleBounds.Width(), " ")
-	p.display.Write(0, 10, msg, termbox.ColorRed, termbox.ColorBlack)
+	width := consoleBounds.Width()
+	if g.Ending != nil && g.Ending.Won {
+		msg := center(fmt.Sprintf("☼☼☼ %s conquered the TOWER ☼☼☼", g.Player.Spec.Name), width, " ")
+		p.display.Write(0, 1, msg, termbox.ColorYellow|termbox.AttrBold, termbox.ColorBlack)
+	} else {
+		msg := center(fmt.Sprintf("✝✝✝ Ur dead %s ✝✝✝", g.Player.Spec.Name), width, " ")
+		p.display.Write(0, 1, msg, termbox.ColorRed, termbox.ColorBlack)
+		if g.Ending != nil {
+			p.display.Write(0, 3, center(g.Ending.Describe(), width, " "), termbox.ColorWhite, termbox.ColorBlack)
+		}
+	}
+
+	if g.Scores != nil {
+		p.renderScores(g.Scores, g.Placing)
+	}
+	p.display.Write(0, consoleBounds.Height()-1, center("[Enter] quit", width, " "), termbox.ColorWhite, termbox.ColorBlack)
+}
+
+// Lists the best games, highlighting this one if it made the list.
+func (p *gameOverPanel) renderScores(scores *game.Scores, placing int) {
+	ranked := scores.Ranked()
+	p.display.Write(2, 5, "HIGH SCORES", termbox.ColorWhite|termbox.AttrBold, termbox.ColorBlack)
+
+	for i := 0; i < len(ranked) && i < gameOverScores; i++ {
+		fg := termbox.ColorWhite
+		if i == placing {
+			fg = termbox.ColorYellow | termbox.AttrBold
+		}
+		p.display.Write(2, 6+i, fmt.Sprintf("%3d. %s", i+1, ranked[i].Describe()), fg, termbox.ColorBlack)
+	}
+
+	y := 7 + gameOverScores
+	if placing >= gameOverScores {
+		row := fmt.Sprintf("%3d. %s", placing+1, ranked[placing].Describe())
+		p.display.Write(2, y, row, termbox.ColorYellow|termbox.AttrBold, termbox.ColorBlack)
+		y++
+	}
+	msg := fmt.Sprintf("This run placed #%d of %d.", placing+1, len(ranked))
+	p.display.Write(2, y+1, msg, termbox.ColorWhite, termbox.ColorBlack)
 }
 
 func center(s string, width int, fill string) string {
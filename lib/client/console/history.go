@@ -0,0 +1,158 @@
+package console
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/nsf/termbox-go"
+)
+
+// How many messages fit on the history screen at once.
+const historyPageSize = 21
+
+// Create a new message history screen.
+func newHistoryScreen(display display) *screen {
+	return &screen{
+		display: display,
+		panels:  []panel{newHistoryPanel(display)},
+	}
+}
+
+// Lets the player page back through every message in the game, and search
+// for old ones.
+type historyPanel struct {
+	display display
+	// How many entries we've scrolled back from the newest.
+	back int
+	// The entry that the last search found, or -1.
+	found int
+	// Set while the player is typing a search term.
+	searching bool
+	query     string
+	notfound  bool
+	// The history we last rendered.
+	hist *game.History
+}
+
+func newHistoryPanel(display display) *historyPanel {
+	return &historyPanel{display: display, found: -1}
+}
+
+func (h *historyPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type != termbox.EventKey || h.hist == nil {
+		return nocommand()
+	}
+	if h.searching {
+		h.handleSearchInput(tboxev)
+		return nocommand()
+	}
+
+	switch tboxev.Key {
+	case termbox.KeyEsc:
+		h.back, h.found, h.notfound = 0, -1, false
+		return game.ModeCommand{Mode: game.ModeHud}, nil
+	case termbox.KeyPgup:
+		h.scroll(historyPageSize)
+	case termbox.KeyPgdn, termbox.KeySpace:
+		h.scroll(-historyPageSize)
+	}
+
+	switch tboxev.Ch {
+	case 'k':
+		h.scroll(1)
+	case 'j':
+		h.scroll(-1)
+	case 'b':
+		h.scroll(historyPageSize)
+	case '/':
+		h.searching, h.query = true, ""
+	case 'n':
+		h.search(h.found - 1)
+	}
+	return nocommand()
+}
+
+func (h *historyPanel) handleSearchInput(tboxev termbox.Event) {
+	switch tboxev.Key {
+	case termbox.KeyEsc:
+		h.searching = false
+	case termbox.KeyEnter:
+		h.searching = false
+		h.search(h.newest())
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if n := len(h.query); n > 0 {
+			h.query = h.query[:n-1]
+		}
+	case termbox.KeySpace:
+		h.query += " "
+	default:
+		if tboxev.Ch != 0 {
+			h.query += string(tboxev.Ch)
+		}
+	}
+}
+
+// Search backwards from entry 'from' for the current query, and scroll so that
+// any match is at the bottom of the page.
+func (h *historyPanel) search(from int) {
+	if h.query == "" {
+		return
+	}
+	found := h.hist.Search(h.query, from)
+	h.notfound = found < 0
+	if found < 0 {
+		return
+	}
+	h.found = found
+	h.back = h.hist.Len() - 1 - found
+}
+
+// Scroll back 'n' entries, or forward if 'n' is negative.
+func (h *historyPanel) scroll(n int) {
+	h.back += n
+	if max := h.hist.Len() - 1; h.back > max {
+		h.back = max
+	}
+	if h.back < 0 {
+		h.back = 0
+	}
+}
+
+// The index of the newest entry shown.
+func (h *historyPanel) newest() int {
+	return h.hist.Len() - 1 - h.back
+}
+
+// Listens to nothing.
+func (h *historyPanel) HandleEvent(e game.Event) {
+}
+
+func (h *historyPanel) Render(g *game.Game) {
+	h.hist = g.History
+	h.display.Write(0, 0, "Messages: [j/k] scroll [b/space] page [/] search [n] next", termbox.ColorWhite, termbox.ColorBlack)
+
+	newest := h.newest()
+	oldest := newest - historyPageSize + 1
+	if oldest < 0 {
+		oldest = 0
+	}
+
+	y := 1
+	for i := oldest; i <= newest; i++ {
+		entry := h.hist.At(i)
+		bg := termbox.ColorBlack
+		if i == h.found {
+			bg = termbox.ColorBlue
+		}
+		turn := fmt.Sprintf("%7d ", entry.Turn)
+		h.display.Write(0, y, turn, termbox.ColorWhite|termbox.AttrBold, bg)
+		writeMarkup(h.display, len(turn), y, entry.Describe(), termbox.ColorWhite, bg)
+		y++
+	}
+
+	switch {
+	case h.searching:
+		h.display.Write(0, historyPageSize+2, "Search: "+h.query, termbox.ColorWhite, termbox.ColorBlack)
+	case h.notfound:
+		h.display.Write(0, historyPageSize+2, fmt.Sprintf("No match for '%s'.", h.query), termbox.ColorRed, termbox.ColorBlack)
+	}
+}
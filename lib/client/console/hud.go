@@ -3,10 +3,12 @@ package console
 import (
 	"container/list"
 	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/config"
 	"github.com/MichaelDiBernardo/srl/lib/game"
 	"github.com/MichaelDiBernardo/srl/lib/math"
 	"github.com/nsf/termbox-go"
 	"log"
+	"unicode/utf8"
 )
 
 var hudBounds = consoleBounds
@@ -24,47 +26,38 @@ var statusPanelBounds = math.Rect(math.Pt(38, 0), math.Pt(hudBounds.Max.X, messa
 var mapPanelBounds = math.Rect(math.Origin, math.Pt(statusPanelBounds.Min.X, messagePanelBounds.Min.Y))
 
 // Create a new HUD.
-func newHudScreen(display display) *screen {
+func newHudScreen(display display, settings *settings) *screen {
 	// Since the messagePanel may capture --more-- prompts and require a redraw
 	// of itself in mid-screen render, we want to make sure the other panels
 	// have already been drawn first.
 	return &screen{
 		display: display,
 		panels: []panel{
-			newMapPanel(display),
+			newMapPanel(display, settings),
 			newStatusPanel(display),
-			newMessagePanel(messagePanelNumLines, display),
+			newMessagePanel(messagePanelNumLines, display, settings),
 		},
 	}
 }
 
-var hudKeymap = map[rune]game.Command{
-	'h': game.MoveCommand{Dir: math.Pt(-1, 0)},
-	'j': game.MoveCommand{Dir: math.Pt(0, 1)},
-	'k': game.MoveCommand{Dir: math.Pt(0, -1)},
-	'l': game.MoveCommand{Dir: math.Pt(1, 0)},
-	'y': game.MoveCommand{Dir: math.Pt(-1, -1)},
-	'u': game.MoveCommand{Dir: math.Pt(1, -1)},
-	'b': game.MoveCommand{Dir: math.Pt(-1, 1)},
-	'n': game.MoveCommand{Dir: math.Pt(1, 1)},
-	'z': game.RestCommand{},
-	'q': game.QuitCommand{},
-	',': game.TryPickupCommand{},
-	'd': game.TryDropCommand{},
-	'w': game.TryEquipCommand{},
-	'r': game.TryRemoveCommand{},
-	'a': game.TryUseCommand{},
-	'i': game.ModeCommand{Mode: game.ModeInventory},
-	'>': game.AscendCommand{},
-	'<': game.DescendCommand{},
-	'@': game.ModeCommand{Mode: game.ModeSheet},
-}
-
 // Panel that renders the gameplay map.
 type mapPanel struct {
-	display display
+	display  display
+	settings *settings
+	// Set while the player is picking a tile to travel to with the cursor.
+	targeting bool
+	cursor    math.Point
+	// Set while the player is picking which way to disarm a trap.
+	disarming bool
+	// Where the player was, and how big the level was, the last time the map
+	// was drawn. The cursor starts on the player and can't leave the level.
+	player math.Point
+	bounds math.Rectangle
 }
 
+// How far the cursor jumps when moved with a run key.
+const cursorJump = 8
+
 // A glyph used to render a tile.
 type glyph struct {
 	Ch rune
@@ -74,9 +67,17 @@ type glyph struct {
 
 // Glyphs used to render actors.
 var actorGlyphs = map[game.Species]glyph{
-	game.SpecHuman: glyph{Ch: '@', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
-	game.SpecOrc:   glyph{Ch: 'o', Fg: termbox.ColorGreen, Bg: termbox.ColorBlack},
-	game.SpecAnt:   glyph{Ch: 'd', Fg: termbox.ColorRed, Bg: termbox.ColorBlack},
+	game.SpecHuman:     glyph{Ch: '@', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+	game.SpecNoldor:    glyph{Ch: '@', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+	game.SpecSindar:    glyph{Ch: '@', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+	game.SpecNaugrim:   glyph{Ch: '@', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+	game.SpecEdain:     glyph{Ch: '@', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+	game.SpecOrc:       glyph{Ch: 'o', Fg: termbox.ColorGreen, Bg: termbox.ColorBlack},
+	game.SpecOrcShaman: glyph{Ch: 'o', Fg: termbox.ColorBlue, Bg: termbox.ColorBlack},
+	game.SpecAnt:       glyph{Ch: 'd', Fg: termbox.ColorRed, Bg: termbox.ColorBlack},
+
+	// Uniques.
+	game.SpecGorbag: glyph{Ch: 'o', Fg: termbox.ColorYellow | termbox.AttrBold, Bg: termbox.ColorBlack},
 }
 
 // Glyphs used to render items.
@@ -87,6 +88,11 @@ var itemGlyphs = map[game.Species]glyph{
 	game.SpecStim:         glyph{Ch: '!', Fg: termbox.ColorRed, Bg: termbox.ColorBlack},
 	game.SpecHyper:        glyph{Ch: '!', Fg: termbox.ColorYellow, Bg: termbox.ColorBlack},
 	game.SpecRestore:      glyph{Ch: '!', Fg: termbox.ColorBlue, Bg: termbox.ColorBlack},
+
+	// Artifacts.
+	game.SpecAngrist:  glyph{Ch: '|', Fg: termbox.ColorYellow | termbox.AttrBold, Bg: termbox.ColorBlack},
+	game.SpecArvedui:  glyph{Ch: '[', Fg: termbox.ColorWhite | termbox.AttrBold, Bg: termbox.ColorBlack},
+	game.SpecEarendil: glyph{Ch: '~', Fg: termbox.ColorYellow | termbox.AttrBold, Bg: termbox.ColorBlack},
 }
 
 // Glyphs used to render tiles.
@@ -97,26 +103,114 @@ var featureGlyphs = map[game.FeatureType]glyph{
 	"FeatOpenDoor":   glyph{Ch: '\'', Fg: termbox.ColorYellow, Bg: termbox.ColorBlack},
 	"FeatStairsUp":   glyph{Ch: '>', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
 	"FeatStairsDown": glyph{Ch: '<', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+
+	"FeatShallowWater": glyph{Ch: '~', Fg: termbox.ColorCyan, Bg: termbox.ColorBlack},
+	"FeatDeepWater":    glyph{Ch: '~', Fg: termbox.ColorBlue, Bg: termbox.ColorBlack},
+	"FeatRubble":       glyph{Ch: '%', Fg: termbox.ColorYellow, Bg: termbox.ColorBlack},
+	"FeatChasm":        glyph{Ch: ':', Fg: termbox.ColorMagenta, Bg: termbox.ColorBlack},
+	"FeatLava":         glyph{Ch: '~', Fg: termbox.ColorRed, Bg: termbox.ColorBlack},
+
+	"FeatPit":          glyph{Ch: '^', Fg: termbox.ColorYellow, Bg: termbox.ColorBlack},
+	"FeatTrapDoor":     glyph{Ch: '^', Fg: termbox.ColorWhite, Bg: termbox.ColorBlack},
+	"FeatSiren":        glyph{Ch: '^', Fg: termbox.ColorRed, Bg: termbox.ColorBlack},
+	"FeatTeleportRune": glyph{Ch: '^', Fg: termbox.ColorMagenta, Bg: termbox.ColorBlack},
+	"FeatDartTrap":     glyph{Ch: '^', Fg: termbox.ColorGreen, Bg: termbox.ColorBlack},
+	"FeatWeb":          glyph{Ch: '^', Fg: termbox.ColorCyan, Bg: termbox.ColorBlack},
 }
 
 // Create a new mapPanel.
-func newMapPanel(display display) *mapPanel {
-	return &mapPanel{display: display}
+func newMapPanel(display display, settings *settings) *mapPanel {
+	return &mapPanel{display: display, settings: settings}
 }
 
 func (m *mapPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
-	if tboxev.Type != termbox.EventKey || tboxev.Key != 0 {
+	if m.targeting {
+		return m.target(tboxev)
+	}
+	if m.disarming {
+		return m.disarm(tboxev)
+	}
+
+	action, ok := m.settings.action(tboxev)
+	if !ok {
+		return nocommand()
+	}
+	switch action {
+	case config.ActionTravel:
+		m.targeting, m.cursor = true, m.player
+		return nocommand()
+	case config.ActionDisarm:
+		m.disarming = true
+		return nocommand()
+	case config.ActionExplore:
+		return game.ExploreCommand{Pickup: m.settings.config.Pickup == config.PickupOn}, nil
+	}
+	if command, ok := hudCommands[action]; ok {
+		return command, nil
+	}
+	return nocommand()
+}
+
+// Moves the travel cursor around with the movement keys. Enter (or the travel
+// key again) travels to the cursor, and Esc gives up.
+func (m *mapPanel) target(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type != termbox.EventKey {
+		return nocommand()
+	}
+	if tboxev.Key == termbox.KeyEsc {
+		m.targeting = false
 		return nocommand()
 	}
 
-	srlev := hudKeymap[tboxev.Ch]
-	if srlev != 0 {
-		return srlev, nil
+	action, _ := m.settings.action(tboxev)
+	if tboxev.Key == termbox.KeyEnter || action == config.ActionTravel {
+		m.targeting = false
+		return game.TravelCommand{Dest: m.cursor}, nil
 	}
 
+	switch c := hudCommands[action].(type) {
+	case game.MoveCommand:
+		m.moveCursor(c.Dir, 1)
+	case game.RunCommand:
+		m.moveCursor(c.Dir, cursorJump)
+	}
+	return nocommand()
+}
+
+// Disarms the trap in whichever direction is picked with the movement keys. Any
+// other key gives up.
+func (m *mapPanel) disarm(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type != termbox.EventKey {
+		return nocommand()
+	}
+	m.disarming = false
+
+	action, _ := m.settings.action(tboxev)
+	if c, ok := hudCommands[action].(game.MoveCommand); ok {
+		return game.DisarmCommand{Dir: c.Dir}, nil
+	}
 	return nocommand()
 }
 
+// The background to draw at 'pt': 'bg', unless the travel cursor is there.
+func (m *mapPanel) cursorBg(pt math.Point, bg termbox.Attribute) termbox.Attribute {
+	if m.targeting && pt == m.cursor {
+		return termbox.ColorBlue
+	}
+	return bg
+}
+
+// Moves the cursor 'n' tiles in 'dir', stopping at the edge of the level.
+func (m *mapPanel) moveCursor(dir math.Point, n int) {
+	for i := 0; i < n; i++ {
+		next := m.cursor.Add(dir)
+		if !m.bounds.HasPoint(next) {
+			return
+		}
+		m.cursor = next
+	}
+}
+
 // Listens to nothing.
 func (m *mapPanel) HandleEvent(e game.Event) {
 }
@@ -124,6 +218,7 @@ func (m *mapPanel) HandleEvent(e game.Event) {
 // Render the gameplay map to the hud.
 func (m *mapPanel) Render(g *game.Game) {
 	center := g.Player.Pos()
+	m.player, m.bounds = center, g.Level.Bounds
 	boundsdist := math.Pt(mapPanelBounds.Width()/2, mapPanelBounds.Height()/2)
 	viewport := math.Rect(center.Sub(boundsdist), center.Add(boundsdist))
 	maptrans := mapPanelBounds.Min.Sub(viewport.Min)
@@ -144,7 +239,7 @@ func (m *mapPanel) Render(g *game.Game) {
 			// When you're blind, you may be walking on unseen tiles. So, we
 			// always want to show the player, even if the tile is unseen.
 			if !tile.Seen && !isplayer {
-				m.display.SetCell(drawpos.X, drawpos.Y, ' ', termbox.ColorBlack, termbox.ColorBlack)
+				m.display.SetCell(drawpos.X, drawpos.Y, ' ', termbox.ColorBlack, m.cursorBg(cur, termbox.ColorBlack))
 				continue
 			}
 
@@ -164,14 +259,21 @@ func (m *mapPanel) Render(g *game.Game) {
 					gl.Bg = termbox.ColorCyan
 				}
 			} else {
-				gl = featureGlyphs[tile.Feature.Type]
+				gl = featureGlyphs[tile.Feature.Appearance().Type]
 				if !tile.Visible {
 					gl.Fg = termbox.ColorBlack | termbox.AttrBold
 				}
 			}
-			m.display.SetCell(drawpos.X, drawpos.Y, gl.Ch, gl.Fg, gl.Bg)
+			m.display.SetCell(drawpos.X, drawpos.Y, gl.Ch, gl.Fg, m.cursorBg(cur, gl.Bg))
 		}
 	}
+
+	if m.targeting {
+		m.display.Write(0, 0, "Travel where? [Enter] go [Esc] cancel", termbox.ColorWhite, termbox.ColorBlack)
+	}
+	if m.disarming {
+		m.display.Write(0, 0, "Disarm which way? [Esc] cancel", termbox.ColorWhite, termbox.ColorBlack)
+	}
 }
 
 type messageLine struct {
@@ -184,21 +286,47 @@ type morePrompt struct {
 
 // The message panel, where messages are rendered at the bottom of the hud.
 type messagePanel struct {
-	display display
-	lines   *list.List
-	size    int
+	display  display
+	settings *settings
+	lines    *list.List
+	size     int
+	// The last blow struck by anyone, so it can be explained on demand.
+	lastblow *game.CombatEvent
+	// If set, every blow is explained as it happens.
+	verbose bool
 }
 
 // Create a new messagePanel.
-func newMessagePanel(size int, display display) *messagePanel {
+func newMessagePanel(size int, display display, settings *settings) *messagePanel {
 	return &messagePanel{
-		display: display,
-		lines:   list.New(),
-		size:    size,
+		display:  display,
+		settings: settings,
+		lines:    list.New(),
+		size:     size,
 	}
 }
 
+// Explains the last blow, or toggles explaining every blow.
 func (m *messagePanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	action, ok := m.settings.action(tboxev)
+	if !ok {
+		return nocommand()
+	}
+	switch action {
+	case config.ActionExplain:
+		if m.lastblow == nil {
+			m.push("No blows struck yet.")
+		} else {
+			m.push(m.lastblow.Describe())
+		}
+	case config.ActionVerbose:
+		m.verbose = !m.verbose
+		if m.verbose {
+			m.push("Explaining every blow.")
+		} else {
+			m.push("No longer explaining every blow.")
+		}
+	}
 	return nocommand()
 }
 
@@ -206,11 +334,26 @@ func (m *messagePanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
 func (m *messagePanel) HandleEvent(e game.Event) {
 	switch ev := e.(type) {
 	case game.MessageEvent:
-		m.lines.PushBack(&messageLine{text: ev.Text})
+		m.push(ev.Text)
 	case game.MoreEvent:
 		m.lines.PushBack(&morePrompt{acked: false})
+		m.trim()
+	case game.CombatEvent:
+		m.lastblow = &ev
+		if m.verbose {
+			m.push(ev.Describe())
+		}
 	}
+}
+
+// Add a line of text to the panel.
+func (m *messagePanel) push(text string) {
+	m.lines.PushBack(&messageLine{text: text})
+	m.trim()
+}
 
+// Drop the oldest lines that no longer fit.
+func (m *messagePanel) trim() {
 	if m.lines.Len() > m.size {
 		m.lines.Remove(m.lines.Front())
 	}
@@ -225,7 +368,7 @@ func (m *messagePanel) Render(g *game.Game) {
 	for e = m.lines.Front(); e != nil; e = e.Next() {
 		switch line := e.Value.(type) {
 		case *messageLine:
-			m.display.Write(messagePanelBounds.Min.X, messagePanelBounds.Min.Y+i, line.text, termbox.ColorWhite, termbox.ColorBlack)
+			writeMarkup(m.display, messagePanelBounds.Min.X, messagePanelBounds.Min.Y+i, line.text, termbox.ColorWhite, termbox.ColorBlack)
 			i++
 		case *morePrompt:
 			if line.acked {
@@ -237,14 +380,13 @@ func (m *messagePanel) Render(g *game.Game) {
 		}
 	}
 
-	if more {
+	if more && m.settings.config.More != config.MoreOff {
 		m.display.Write(messagePanelBounds.Min.X, messagePanelBounds.Min.Y+i, "--more--", termbox.ColorWhite, termbox.ColorBlack)
 		m.display.Flush()
 
 		// Wait for player to clear prompt.
 		for {
-			tboxev := m.display.PollEvent()
-			if tboxev.Type == termbox.EventKey && (tboxev.Key == termbox.KeyEsc || tboxev.Key == termbox.KeyEnter) {
+			if m.dismissesMore(m.display.PollEvent()) {
 				break
 			}
 		}
@@ -258,6 +400,40 @@ func (m *messagePanel) Render(g *game.Game) {
 	}
 }
 
+// Does 'tboxev' get rid of a --more-- prompt?
+func (m *messagePanel) dismissesMore(tboxev termbox.Event) bool {
+	if tboxev.Type != termbox.EventKey {
+		return false
+	}
+	if m.settings.config.More == config.MoreAnyKey {
+		return true
+	}
+	return tboxev.Key == termbox.KeyEsc || tboxev.Key == termbox.KeyEnter
+}
+
+// The colours used for each style of marked-up message text.
+var styleColors = map[game.Style]termbox.Attribute{
+	game.StyleMonster: termbox.ColorYellow,
+	game.StyleDamage:  termbox.ColorWhite | termbox.AttrBold,
+	game.StyleGood:    termbox.ColorGreen,
+	game.StyleBad:     termbox.ColorRed,
+	game.StyleItem:    termbox.ColorCyan,
+	game.StyleCrit:    termbox.ColorMagenta | termbox.AttrBold,
+}
+
+// Writes marked-up text to the display, colouring each span by its style.
+// Plain text is drawn in 'fg'.
+func writeMarkup(d display, x, y int, text string, fg, bg termbox.Attribute) {
+	for _, span := range game.ParseMarkup(text) {
+		color, ok := styleColors[span.Style]
+		if !ok {
+			color = fg
+		}
+		d.Write(x, y, span.Text, color, bg)
+		x += utf8.RuneCountInString(span.Text)
+	}
+}
+
 // Panel that renders player status on hud.
 type statusPanel struct {
 	display display
@@ -286,7 +462,7 @@ func (s *statusPanel) Render(g *game.Game) {
 
 	// left
 	s.display.Write(lcol, statusPanelBounds.Min.Y+0, player.Spec.Name, fg, bg)
-	s.display.Write(lcol, statusPanelBounds.Min.Y+1, "Human", fg, bg)
+	s.display.Write(lcol, statusPanelBounds.Min.Y+1, player.Spec.Species.Describe(), fg, bg)
 
 	s.display.Write(lcol, statusPanelBounds.Min.Y+3, fmt.Sprintf("%-7s%3d/%-3d", "HP", sheet.HP(), sheet.MaxHP()), fg, bg)
 	s.display.Write(lcol, statusPanelBounds.Min.Y+4, fmt.Sprintf("%-7s%3d/%-3d", "MP", sheet.MP(), sheet.MaxMP()), fg, bg)
@@ -447,6 +623,30 @@ var effectLights = []effectLight{
 		},
 		makelight: makeLabelLight,
 	},
+	{
+		label:        "Staying",
+		defaultcolor: termbox.ColorCyan,
+		cond: func(p *game.Obj) int {
+			return singing(p, game.EffectSongStaying)
+		},
+		makelight: makeLabelLight,
+	},
+	{
+		label:        "Sharp",
+		defaultcolor: termbox.ColorCyan,
+		cond: func(p *game.Obj) int {
+			return singing(p, game.EffectSongSharpness)
+		},
+		makelight: makeLabelLight,
+	},
+	{
+		label:        "Hush",
+		defaultcolor: termbox.ColorCyan,
+		cond: func(p *game.Obj) int {
+			return singing(p, game.EffectSongSilence)
+		},
+		makelight: makeLabelLight,
+	},
 }
 
 func makeCountingLight(el effectLight, ticks int) (light string, fg termbox.Attribute) {
@@ -463,3 +663,12 @@ func bool2int(b bool) int {
 	}
 	return 0
 }
+
+// Returns 1 if 'p' is singing the song sustained by 'effect', 0 otherwise.
+func singing(p *game.Obj, effect game.Effect) int {
+	if p.Singer == nil {
+		return 0
+	}
+	song := p.Singer.Singing()
+	return bool2int(song != nil && song.Effect == effect)
+}
@@ -1,12 +1,14 @@
 package console
 
 import (
+	"github.com/MichaelDiBernardo/srl/lib/config"
 	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/nsf/termbox-go"
 	"testing"
 )
 
 func TestMessagePanelHandleEventMessageEvent(t *testing.T) {
-	sut := newMessagePanel(1, &fakedisplay{})
+	sut := newMessagePanel(1, &fakedisplay{}, newSettings(config.Default(), ""))
 	ev := game.MessageEvent{Text: "hi"}
 	sut.HandleEvent(ev)
 
@@ -21,7 +23,7 @@ func TestMessagePanelHandleEventMessageEvent(t *testing.T) {
 
 func TestMessagePanelHasLimit(t *testing.T) {
 	size := 2
-	sut := newMessagePanel(size, &fakedisplay{})
+	sut := newMessagePanel(size, &fakedisplay{}, newSettings(config.Default(), ""))
 
 	sut.HandleEvent(game.MessageEvent{Text: "hi"})
 	sut.HandleEvent(game.MessageEvent{Text: "bye"})
@@ -39,3 +41,19 @@ func TestMessagePanelHasLimit(t *testing.T) {
 		t.Errorf(`Bottom line was %v, want 'foo'`, s)
 	}
 }
+
+func TestMessagePanelVerboseCombat(t *testing.T) {
+	sut := newMessagePanel(5, &fakedisplay{}, newSettings(config.Default(), ""))
+	blow := game.CombatEvent{Attacker: "ORC", AtkRoll: 3, DefRoll: 5, Residual: -2}
+
+	sut.HandleEvent(blow)
+	if l := sut.lines.Len(); l != 0 {
+		t.Errorf(`Quiet CombatEvent added %d lines, want 0`, l)
+	}
+
+	sut.HandleInput(termbox.Event{Type: termbox.EventKey, Ch: 'V'})
+	sut.HandleEvent(blow)
+	if s, w := sut.lines.Back().Value.(*messageLine).text, blow.Describe(); s != w {
+		t.Errorf(`Verbose CombatEvent line was %q, want %q`, s, w)
+	}
+}
@@ -0,0 +1,144 @@
+package console
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/config"
+	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"github.com/nsf/termbox-go"
+)
+
+// A single key, as termbox reports it: either a character, or one of
+// termbox's special keys.
+type keypress struct {
+	ch  rune
+	key termbox.Key
+}
+
+// The termbox keys for each of config.NamedKeys.
+var namedKeys = map[string]termbox.Key{
+	"up":     termbox.KeyArrowUp,
+	"down":   termbox.KeyArrowDown,
+	"left":   termbox.KeyArrowLeft,
+	"right":  termbox.KeyArrowRight,
+	"home":   termbox.KeyHome,
+	"end":    termbox.KeyEnd,
+	"pgup":   termbox.KeyPgup,
+	"pgdn":   termbox.KeyPgdn,
+	"insert": termbox.KeyInsert,
+	"delete": termbox.KeyDelete,
+	"space":  termbox.KeySpace,
+	"tab":    termbox.KeyTab,
+}
+
+// The key that was pressed in 'ev'.
+func keyof(ev termbox.Event) keypress {
+	if ev.Ch != 0 {
+		return keypress{ch: ev.Ch}
+	}
+	return keypress{key: ev.Key}
+}
+
+// Turns a key as it's written in the config into a keypress.
+func parsekey(name string) keypress {
+	if key, ok := namedKeys[name]; ok {
+		return keypress{key: key}
+	}
+	return keypress{ch: []rune(name)[0]}
+}
+
+// The name the config uses for a key, or false if it can't be bound.
+func keyname(k keypress) (string, bool) {
+	if k.ch != 0 {
+		name := string(k.ch)
+		return name, config.ValidKey(name)
+	}
+	for name, key := range namedKeys {
+		if key == k.key {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// What each key does on the HUD.
+type keymap map[keypress]config.Action
+
+func newKeymap(c *config.Config) keymap {
+	keys := keymap{}
+	for action, name := range c.Keys() {
+		keys[parsekey(name)] = action
+	}
+	return keys
+}
+
+// The commands sent to the game for each action. Actions that only change
+// how the client shows things, or that need more input first (like picking
+// where to travel), aren't here.
+var hudCommands = map[config.Action]game.Command{
+	config.ActionWest:      game.MoveCommand{Dir: math.Pt(-1, 0)},
+	config.ActionSouth:     game.MoveCommand{Dir: math.Pt(0, 1)},
+	config.ActionNorth:     game.MoveCommand{Dir: math.Pt(0, -1)},
+	config.ActionEast:      game.MoveCommand{Dir: math.Pt(1, 0)},
+	config.ActionNorthwest: game.MoveCommand{Dir: math.Pt(-1, -1)},
+	config.ActionNortheast: game.MoveCommand{Dir: math.Pt(1, -1)},
+	config.ActionSouthwest: game.MoveCommand{Dir: math.Pt(-1, 1)},
+	config.ActionSoutheast: game.MoveCommand{Dir: math.Pt(1, 1)},
+	config.ActionRest:      game.RestCommand{},
+	config.ActionQuit:      game.QuitCommand{},
+	config.ActionPickup:    game.TryPickupCommand{},
+	config.ActionDrop:      game.TryDropCommand{},
+	config.ActionEquip:     game.TryEquipCommand{},
+	config.ActionRemove:    game.TryRemoveCommand{},
+	config.ActionUse:       game.TryUseCommand{},
+	config.ActionCast:      game.TryCastCommand{},
+	config.ActionSing:      game.TrySingCommand{},
+	config.ActionStopSing:  game.StopSingingCommand{},
+	config.ActionInventory: game.ModeCommand{Mode: game.ModeInventory},
+	config.ActionAscend:    game.AscendCommand{},
+	config.ActionDescend:   game.DescendCommand{},
+	config.ActionSheet:     game.ModeCommand{Mode: game.ModeSheet},
+	config.ActionHistory:   game.ModeCommand{Mode: game.ModeHistory},
+	config.ActionOptions:   game.ModeCommand{Mode: game.ModeOptions},
+
+	config.ActionRunWest:      game.RunCommand{Dir: math.Pt(-1, 0)},
+	config.ActionRunSouth:     game.RunCommand{Dir: math.Pt(0, 1)},
+	config.ActionRunNorth:     game.RunCommand{Dir: math.Pt(0, -1)},
+	config.ActionRunEast:      game.RunCommand{Dir: math.Pt(1, 0)},
+	config.ActionRunNorthwest: game.RunCommand{Dir: math.Pt(-1, -1)},
+	config.ActionRunNortheast: game.RunCommand{Dir: math.Pt(1, -1)},
+	config.ActionRunSouthwest: game.RunCommand{Dir: math.Pt(-1, 1)},
+	config.ActionRunSoutheast: game.RunCommand{Dir: math.Pt(1, 1)},
+	config.ActionTravelStairs: game.TravelStairsCommand{},
+	config.ActionSearch:       game.SearchCommand{},
+}
+
+// The player's config, shared by every screen that needs it so that changes
+// made on the options screen take effect right away.
+type settings struct {
+	config *config.Config
+	// Where the config is saved. If empty, changes aren't saved.
+	path string
+	keys keymap
+}
+
+func newSettings(c *config.Config, path string) *settings {
+	return &settings{config: c, path: path, keys: newKeymap(c)}
+}
+
+// The action bound to the key pressed in 'ev', if any.
+func (s *settings) action(ev termbox.Event) (config.Action, bool) {
+	if ev.Type != termbox.EventKey {
+		return "", false
+	}
+	action, ok := s.keys[keyof(ev)]
+	return action, ok
+}
+
+// Picks up changes to the config, and saves it.
+func (s *settings) changed() error {
+	s.keys = newKeymap(s.config)
+	if s.path == "" {
+		return nil
+	}
+	return s.config.Save(s.path)
+}
@@ -0,0 +1,116 @@
+package console
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/config"
+	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"github.com/nsf/termbox-go"
+	"testing"
+)
+
+func TestMapPanelUsesLayout(t *testing.T) {
+	cfg := config.Default()
+	cfg.Set("layout", string(config.LayoutArrows))
+	sut := newMapPanel(&fakedisplay{}, newSettings(cfg, ""))
+
+	com, err := sut.HandleInput(termbox.Event{Type: termbox.EventKey, Key: termbox.KeyArrowLeft})
+	if err != nil {
+		t.Fatalf(`Left arrow gave error %v`, err)
+	}
+	if want := (game.MoveCommand{Dir: math.Pt(-1, 0)}); com != want {
+		t.Errorf(`Left arrow gave %v; want %v`, com, want)
+	}
+
+	if _, err := sut.HandleInput(termbox.Event{Type: termbox.EventKey, Ch: 'h'}); err == nil {
+		t.Error(`'h' still moved with the arrows layout`)
+	}
+}
+
+func TestMapPanelUsesBindings(t *testing.T) {
+	cfg := config.Default()
+	cfg.Bind(config.ActionPickup, "g")
+	sut := newMapPanel(&fakedisplay{}, newSettings(cfg, ""))
+
+	com, err := sut.HandleInput(termbox.Event{Type: termbox.EventKey, Ch: 'g'})
+	if err != nil || com != (game.TryPickupCommand{}) {
+		t.Errorf(`'g' gave %v, %v; want pickup`, com, err)
+	}
+}
+
+func TestOptionsPanelCyclesOption(t *testing.T) {
+	cfg := config.Default()
+	settings := newSettings(cfg, "")
+	sut := newOptionsPanel(&fakedisplay{}, settings)
+
+	sut.HandleInput(termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter})
+	if cfg.Layout != config.LayoutNumpad {
+		t.Errorf(`Changing layout gave %s; want %s`, cfg.Layout, config.LayoutNumpad)
+	}
+	if action := settings.keys[keypress{ch: '4'}]; action != config.ActionWest {
+		t.Errorf(`'4' was bound to %q after changing layout; want %q`, action, config.ActionWest)
+	}
+}
+
+func TestOptionsPanelRebinds(t *testing.T) {
+	cfg := config.Default()
+	settings := newSettings(cfg, "")
+	sut := newOptionsPanel(&fakedisplay{}, settings)
+
+	// Move down to the first action, and bind it to the up arrow.
+	for i := 0; i < len(config.Options); i++ {
+		sut.HandleInput(termbox.Event{Type: termbox.EventKey, Ch: 'j'})
+	}
+	sut.HandleInput(termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter})
+	sut.HandleInput(termbox.Event{Type: termbox.EventKey, Key: termbox.KeyArrowUp})
+
+	action := config.Actions[0]
+	if key := cfg.Keys()[action]; key != "up" {
+		t.Errorf(`%s was bound to %q; want "up"`, action, key)
+	}
+	if got := settings.keys[keypress{key: termbox.KeyArrowUp}]; got != action {
+		t.Errorf(`Up arrow did %q; want %q`, got, action)
+	}
+}
+
+func TestMapPanelRuns(t *testing.T) {
+	sut := newMapPanel(&fakedisplay{}, newSettings(config.Default(), ""))
+
+	com, err := sut.HandleInput(termbox.Event{Type: termbox.EventKey, Ch: 'L'})
+	if want := (game.RunCommand{Dir: math.Pt(1, 0)}); err != nil || com != want {
+		t.Errorf(`'L' gave %v, %v; want %v`, com, err, want)
+	}
+}
+
+func TestMapPanelPicksTravelTarget(t *testing.T) {
+	sut := newMapPanel(&fakedisplay{}, newSettings(config.Default(), ""))
+	sut.player, sut.bounds = math.Pt(5, 5), math.Rect(math.Origin, math.Pt(20, 20))
+
+	keys := []rune{'t', 'l', 'l', 'j', 'K'}
+	for _, ch := range keys {
+		if _, err := sut.HandleInput(termbox.Event{Type: termbox.EventKey, Ch: ch}); err == nil {
+			t.Fatalf(`'%c' sent a command while picking a target`, ch)
+		}
+	}
+
+	com, err := sut.HandleInput(termbox.Event{Type: termbox.EventKey, Key: termbox.KeyEnter})
+	if want := (game.TravelCommand{Dest: math.Pt(7, 0)}); err != nil || com != want {
+		t.Errorf(`Picking a target gave %v, %v; want %v`, com, err, want)
+	}
+	if sut.targeting {
+		t.Error(`Still targeting after picking a target`)
+	}
+}
+
+func TestMapPanelExploresAsConfigured(t *testing.T) {
+	cfg := config.Default()
+	sut := newMapPanel(&fakedisplay{}, newSettings(cfg, ""))
+	explore := termbox.Event{Type: termbox.EventKey, Ch: 'o'}
+
+	if com, _ := sut.HandleInput(explore); com != (game.ExploreCommand{Pickup: true}) {
+		t.Errorf(`Exploring with default config gave %v; want pickup`, com)
+	}
+	cfg.Set("pickup", string(config.PickupOff))
+	if com, _ := sut.HandleInput(explore); com != (game.ExploreCommand{Pickup: false}) {
+		t.Errorf(`Exploring with pickup off gave %v; want no pickup`, com)
+	}
+}
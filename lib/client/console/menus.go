@@ -3,7 +3,9 @@ package console
 import (
 	"fmt"
 	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/MichaelDiBernardo/srl/lib/math"
 	"github.com/nsf/termbox-go"
+	"strconv"
 )
 
 // Create a new inventory screen.
@@ -58,23 +60,29 @@ func newPickupScreen(display display) *screen {
 // Panel that renders the pickup screen.
 type pickupPanel struct {
 	display display
+	qty     *quantityPrompt
+	// The inventory we last rendered.
+	inv *game.Inventory
 }
 
 // Create a new pickupPanel.
 func newPickupPanel(display display) *pickupPanel {
-	return &pickupPanel{display: display}
+	return &pickupPanel{display: display, qty: newQuantityPrompt(display)}
 }
 
 func (p *pickupPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
 	if tboxev.Type != termbox.EventKey {
 		return nocommand()
 	}
+	if p.qty.active {
+		return p.qty.HandleInput(tboxev)
+	}
 	if tboxev.Key == termbox.KeyEsc {
 		return game.ModeCommand{Mode: game.ModeHud}, nil
 	} else if ch := tboxev.Ch; ch != 0 {
 		opt := selectOption(ch)
 		if opt != -1 {
-			return game.MenuCommand{Option: opt}, nil
+			return p.qty.Begin(opt, p.inv)
 		}
 	}
 	return nocommand()
@@ -86,8 +94,9 @@ func (p *pickupPanel) HandleEvent(e game.Event) {
 
 // Render the menu.
 func (p *pickupPanel) Render(g *game.Game) {
-	inv := g.Player.Tile.Items
-	renderInventory(p.display, "Take what?", inv)
+	p.inv = g.Player.Tile.Items
+	renderInventory(p.display, "Take what?", p.inv)
+	p.qty.Render()
 }
 
 // Create a new drop screen.
@@ -101,23 +110,29 @@ func newDropScreen(display display) *screen {
 // Panel that renders the drop screen.
 type dropPanel struct {
 	display display
+	qty     *quantityPrompt
+	// The inventory we last rendered.
+	inv *game.Inventory
 }
 
 // Create a new dropPanel.
 func newDropPanel(display display) *dropPanel {
-	return &dropPanel{display: display}
+	return &dropPanel{display: display, qty: newQuantityPrompt(display)}
 }
 
 func (d *dropPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
 	if tboxev.Type != termbox.EventKey {
 		return nocommand()
 	}
+	if d.qty.active {
+		return d.qty.HandleInput(tboxev)
+	}
 	if tboxev.Key == termbox.KeyEsc {
 		return game.ModeCommand{Mode: game.ModeHud}, nil
 	} else if ch := tboxev.Ch; ch != 0 {
 		opt := selectOption(ch)
 		if opt != -1 {
-			return game.MenuCommand{Option: opt}, nil
+			return d.qty.Begin(opt, d.inv)
 		}
 	}
 	return nocommand()
@@ -129,8 +144,9 @@ func (d *dropPanel) HandleEvent(e game.Event) {
 
 // Render the menu.
 func (d *dropPanel) Render(g *game.Game) {
-	inv := g.Player.Packer.Inventory()
-	renderInventory(d.display, "Drop what?", inv)
+	d.inv = g.Player.Packer.Inventory()
+	renderInventory(d.display, "Drop what?", d.inv)
+	d.qty.Render()
 }
 
 // Create a new equip screen.
@@ -217,7 +233,7 @@ func (r *removePanel) Render(g *game.Game) {
 	for i, equip := range body.Slots {
 		name := "(nothing)"
 		if equip != nil {
-			name = equip.Spec.Name
+			name = equip.Describe()
 		}
 		display.Write(1, 1+i, fmt.Sprintf("%c - %v", alphabet[i], name), termbox.ColorWhite, termbox.ColorBlack)
 	}
@@ -263,6 +279,100 @@ func (m *usePanel) Render(g *game.Game) {
 	renderInventory(m.display, "Use what?", inv)
 }
 
+// Create a new cast screen.
+func newCastScreen(display display) *screen {
+	return &screen{
+		display: display,
+		panels:  []panel{newCastPanel(display)},
+	}
+}
+
+// Panel that renders the list of spells the player can cast.
+type castPanel struct {
+	display display
+}
+
+// Create a new castPanel.
+func newCastPanel(display display) *castPanel {
+	return &castPanel{display: display}
+}
+
+func (c *castPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type == termbox.EventKey && tboxev.Key == termbox.KeyEsc {
+		return game.ModeCommand{Mode: game.ModeHud}, nil
+	} else if ch := tboxev.Ch; ch != 0 {
+		opt := selectOption(ch)
+		if opt != -1 {
+			return game.MenuCommand{Option: opt}, nil
+		}
+	}
+	return nocommand()
+}
+
+// Listens to nothing.
+func (c *castPanel) HandleEvent(e game.Event) {
+}
+
+// Render the menu. Spells the player can't afford are shown in red.
+func (c *castPanel) Render(g *game.Game) {
+	caster, mp := g.Player.Caster, g.Player.Sheet.MP()
+	c.display.Write(0, 0, "Cast what?", termbox.ColorWhite, termbox.ColorBlack)
+	for i, spell := range caster.Spells() {
+		fg, cost := termbox.ColorWhite, caster.Cost(spell)
+		if cost > mp {
+			fg = termbox.ColorRed
+		}
+		c.display.Write(1, 1+i, fmt.Sprintf("%c - %-12s %2d MP", alphabet[i], spell.Name, cost), fg, termbox.ColorBlack)
+	}
+}
+
+// Create a new sing screen.
+func newSingScreen(display display) *screen {
+	return &screen{
+		display: display,
+		panels:  []panel{newSingPanel(display)},
+	}
+}
+
+// Panel that renders the list of songs the player can sing.
+type singPanel struct {
+	display display
+}
+
+// Create a new singPanel.
+func newSingPanel(display display) *singPanel {
+	return &singPanel{display: display}
+}
+
+func (s *singPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type == termbox.EventKey && tboxev.Key == termbox.KeyEsc {
+		return game.ModeCommand{Mode: game.ModeHud}, nil
+	} else if ch := tboxev.Ch; ch != 0 {
+		opt := selectOption(ch)
+		if opt != -1 {
+			return game.MenuCommand{Option: opt}, nil
+		}
+	}
+	return nocommand()
+}
+
+// Listens to nothing.
+func (s *singPanel) HandleEvent(e game.Event) {
+}
+
+// Render the menu. The song being sung is shown in cyan.
+func (s *singPanel) Render(g *game.Game) {
+	singer := g.Player.Singer
+	s.display.Write(0, 0, "Sing what?", termbox.ColorWhite, termbox.ColorBlack)
+	for i, song := range singer.Songs() {
+		fg := termbox.ColorWhite
+		if song == singer.Singing() {
+			fg = termbox.ColorCyan
+		}
+		s.display.Write(1, 1+i, fmt.Sprintf("%c - %-12s %2d MP/turn", alphabet[i], song.Name, singer.Cost(song)), fg, termbox.ColorBlack)
+	}
+}
+
 // Basic choosy things.
 var alphabet = []rune{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z'}
 
@@ -284,7 +394,78 @@ func renderInventory(display display, title string, inv *game.Inventory) {
 	i := 0
 	for e := items.Back(); e != nil; e = e.Prev() {
 		item := e.Value.(*game.Obj)
-		display.Write(1, 1+i, fmt.Sprintf("%c - %v", alphabet[i], item.Spec.Name), termbox.ColorWhite, termbox.ColorBlack)
+		display.Write(1, 1+i, fmt.Sprintf("%c - %v", alphabet[i], item.Describe()), termbox.ColorWhite, termbox.ColorBlack)
 		i++
 	}
 }
+
+// Asks the player how many items they want to take from a stack before the
+// selection is sent to the game. Menus that move items around own one of these
+// and defer to it while it is active.
+type quantityPrompt struct {
+	display display
+	active  bool
+	option  int
+	max     int
+	digits  string
+}
+
+func newQuantityPrompt(display display) *quantityPrompt {
+	return &quantityPrompt{display: display}
+}
+
+// Start asking for a quantity for menu option 'opt' in 'inv'. If the selected
+// item isn't a stack, there's nothing to ask and the selection is returned
+// immediately.
+func (q *quantityPrompt) Begin(opt int, inv *game.Inventory) (game.Command, error) {
+	var item *game.Obj
+	if inv != nil {
+		item = inv.At(opt)
+	}
+	if item == nil || item.Count() <= 1 {
+		return game.MenuCommand{Option: opt}, nil
+	}
+
+	q.active, q.option, q.max, q.digits = true, opt, item.Count(), ""
+	return nocommand()
+}
+
+func (q *quantityPrompt) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	switch tboxev.Key {
+	case termbox.KeyEsc:
+		q.active = false
+		return nocommand()
+	case termbox.KeyEnter:
+		q.active = false
+		return game.MenuCommand{Option: q.option, Quantity: q.quantity()}, nil
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if l := len(q.digits); l > 0 {
+			q.digits = q.digits[:l-1]
+		}
+		return nocommand()
+	}
+
+	if ch := tboxev.Ch; '0' <= ch && ch <= '9' && len(q.digits) < 3 {
+		q.digits += string(ch)
+	}
+	return nocommand()
+}
+
+// Render the prompt, if it's active.
+func (q *quantityPrompt) Render() {
+	if !q.active {
+		return
+	}
+	prompt := fmt.Sprintf("How many? (1-%d, default %d): %s", q.max, q.max, q.digits)
+	q.display.Write(0, 0, fmt.Sprintf("%-79s", prompt), termbox.ColorWhite, termbox.ColorBlack)
+}
+
+// The quantity entered so far, clamped to the size of the stack. If nothing
+// was entered, this is the whole stack.
+func (q *quantityPrompt) quantity() int {
+	n, err := strconv.Atoi(q.digits)
+	if err != nil || n > q.max {
+		return q.max
+	}
+	return math.Max(n, 1)
+}
@@ -0,0 +1,183 @@
+package console
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/config"
+	"github.com/MichaelDiBernardo/srl/lib/game"
+	"github.com/nsf/termbox-go"
+)
+
+// How the actions are laid out on the options screen.
+const (
+	optionsColumns     = 3
+	optionsColumnWidth = 26
+)
+
+// Create a new options screen.
+func newOptionsScreen(display display, settings *settings) *screen {
+	return &screen{
+		display: display,
+		panels:  []panel{newOptionsPanel(display, settings)},
+	}
+}
+
+// Lets the player change their options and rebind keys. Every change is saved
+// to the config file as soon as it's made.
+type optionsPanel struct {
+	display  display
+	settings *settings
+	// Index of the highlighted row: first the options, then the actions.
+	cur int
+	// Set while waiting for the player to press a key to bind.
+	binding bool
+	// Explains what happened after the last change.
+	status    string
+	statuserr bool
+}
+
+func newOptionsPanel(display display, settings *settings) *optionsPanel {
+	return &optionsPanel{display: display, settings: settings}
+}
+
+// How many rows can be highlighted.
+func (o *optionsPanel) rows() int {
+	return len(config.Options) + len(config.Actions)
+}
+
+// The action on the highlighted row, or false if it's an option.
+func (o *optionsPanel) curAction() (config.Action, bool) {
+	i := o.cur - len(config.Options)
+	if i < 0 {
+		return "", false
+	}
+	return config.Actions[i], true
+}
+
+func (o *optionsPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if tboxev.Type != termbox.EventKey {
+		return nocommand()
+	}
+	if o.binding {
+		o.bind(tboxev)
+		return nocommand()
+	}
+
+	switch tboxev.Key {
+	case termbox.KeyEsc:
+		o.status = ""
+		return game.ModeCommand{Mode: game.ModeHud}, nil
+	case termbox.KeyEnter, termbox.KeySpace:
+		o.change()
+		return nocommand()
+	}
+
+	switch tboxev.Ch {
+	case 'j':
+		o.cur = (o.cur + 1) % o.rows()
+	case 'k':
+		o.cur = (o.cur + o.rows() - 1) % o.rows()
+	}
+	return nocommand()
+}
+
+// Changes whatever's on the highlighted row: options move to their next
+// choice, and actions wait for a new key.
+func (o *optionsPanel) change() {
+	if action, ok := o.curAction(); ok {
+		o.binding = true
+		o.report(fmt.Sprintf("Press a key for %s, or Esc to cancel.", action), nil)
+		return
+	}
+
+	opt := config.Options[o.cur]
+	cfg := o.settings.config
+	next := opt.Choices[0]
+	for i, choice := range opt.Choices {
+		if choice == cfg.Get(opt.Name) {
+			next = opt.Choices[(i+1)%len(opt.Choices)]
+		}
+	}
+
+	if err := cfg.Set(opt.Name, next); err != nil {
+		o.report("", err)
+		return
+	}
+	o.report(fmt.Sprintf("%s is now %s.", opt.Name, next), o.settings.changed())
+}
+
+// Binds the highlighted action to the key pressed in 'tboxev'.
+func (o *optionsPanel) bind(tboxev termbox.Event) {
+	o.binding = false
+	if tboxev.Key == termbox.KeyEsc {
+		o.report("", nil)
+		return
+	}
+
+	action, _ := o.curAction()
+	name, ok := keyname(keyof(tboxev))
+	if !ok {
+		o.status, o.statuserr = "That key can't be bound.", true
+		return
+	}
+	if err := o.settings.config.Bind(action, name); err != nil {
+		o.report("", err)
+		return
+	}
+	o.report(fmt.Sprintf("%s is now on %s.", action, name), o.settings.changed())
+}
+
+// Shows 'msg', or 'err' instead if there was one.
+func (o *optionsPanel) report(msg string, err error) {
+	o.status, o.statuserr = msg, err != nil
+	if err != nil {
+		o.status = fmt.Sprintf("Couldn't change that: %v", err)
+	}
+}
+
+// Listens to nothing.
+func (o *optionsPanel) HandleEvent(e game.Event) {
+}
+
+func (o *optionsPanel) Render(g *game.Game) {
+	fg, bg := termbox.ColorWhite, termbox.ColorBlack
+	cfg := o.settings.config
+	o.display.Write(0, 0, "Options: [j/k] move [Enter] change [Esc] back", fg, bg)
+
+	row := 0
+	for i, opt := range config.Options {
+		o.display.Write(1, 2+i, fmt.Sprintf("%-8s %s", opt.Name, cfg.Get(opt.Name)), fg, o.highlight(row))
+		row++
+	}
+
+	morgue := cfg.MorgueDir
+	if morgue == "" {
+		morgue = "(default)"
+	}
+	o.display.Write(1, 2+len(config.Options), fmt.Sprintf("%-8s %s", "morgue", morgue), fg, bg)
+
+	// Actions go in columns under the options.
+	keys := cfg.Keys()
+	top := 4 + len(config.Options)
+	percol := (len(config.Actions) + optionsColumns - 1) / optionsColumns
+	for i, action := range config.Actions {
+		x, y := 1+(i/percol)*optionsColumnWidth, top+i%percol
+		o.display.Write(x, y, fmt.Sprintf("%-12s %s", action, keys[action]), fg, o.highlight(row))
+		row++
+	}
+
+	if o.status != "" {
+		color := fg
+		if o.statuserr {
+			color = termbox.ColorRed
+		}
+		o.display.Write(0, consoleBounds.Height()-1, o.status, color, bg)
+	}
+}
+
+// The background for row 'row'.
+func (o *optionsPanel) highlight(row int) termbox.Attribute {
+	if row == o.cur {
+		return termbox.ColorBlue
+	}
+	return termbox.ColorBlack
+}
@@ -7,26 +7,52 @@ import (
 )
 
 func newSheetScreen(display display) *screen {
+	state := &sheetState{}
 	return &screen{
 		display: display,
 		panels: []panel{
-			newSkillEditPanel(display),
-			newSheetPanel(display),
+			newSkillEditPanel(display, state),
+			newAbilityPanel(display, state),
+			newBreakdownPanel(display, state),
+			newSheetPanel(display, state),
 		},
 	}
 }
 
+// Tabs on the character sheet.
+type sheetTab int
+
+const (
+	sheetTabSkills sheetTab = iota
+	sheetTabAbilities
+	sheetTabBreakdown
+	numSheetTabs
+)
+
+// State shared between all the panels on the sheet screen.
+type sheetState struct {
+	tab     sheetTab
+	editing bool
+}
+
 type sheetPanel struct {
 	display display
+	state   *sheetState
 }
 
-func newSheetPanel(display display) *sheetPanel {
-	return &sheetPanel{display: display}
+func newSheetPanel(display display, state *sheetState) *sheetPanel {
+	return &sheetPanel{display: display, state: state}
 }
 
 func (s *sheetPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
-	if tboxev.Type == termbox.EventKey && tboxev.Key == termbox.KeyEsc {
+	if tboxev.Type != termbox.EventKey || s.state.editing {
+		return nocommand()
+	}
+	switch tboxev.Key {
+	case termbox.KeyEsc:
 		return game.ModeCommand{Mode: game.ModeHud}, nil
+	case termbox.KeyTab:
+		s.state.tab = (s.state.tab + 1) % numSheetTabs
 	}
 	return nocommand()
 }
@@ -58,39 +84,47 @@ func (s *sheetPanel) Render(g *game.Game) {
 	s.display.Write(22, 10, fmt.Sprintf("HP %12s", fmt.Sprintf("%d:%d", sheet.HP(), sheet.MaxHP())), termbox.ColorWhite, termbox.ColorBlack)
 	s.display.Write(22, 11, fmt.Sprintf("MP %12s", fmt.Sprintf("%d:%d", sheet.MP(), sheet.MaxMP())), termbox.ColorWhite, termbox.ColorBlack)
 
+	s.display.Write(40, 2, "[Tab] SKILLS / ABILITIES / BREAKDOWN", termbox.ColorWhite, termbox.ColorBlack)
+	if s.state.tab != sheetTabSkills {
+		return
+	}
+
 	for sk := game.Melee; sk < game.NumSkills; sk++ {
 		rowfmt := "%-5s %3d = %2d %3s"
-		row := fmt.Sprintf(rowfmt, skillname(sk), sheet.Skill(sk), sheet.UnmodSkill(sk), extrasign(sheet.SkillMod(sk)))
+		row := fmt.Sprintf(rowfmt, sk.Describe(), sheet.Skill(sk), sheet.UnmodSkill(sk), extrasign(sheet.SkillMod(sk)))
 		s.display.Write(40, 7+int(sk), row, termbox.ColorWhite, termbox.ColorBlack)
 	}
 }
 
 type skillEditPanel struct {
 	display display
-	editing bool
+	state   *sheetState
 	cur     game.SkillName
 	change  *game.SkillChange
 }
 
-func newSkillEditPanel(display display) *skillEditPanel {
-	return &skillEditPanel{display: display}
+func newSkillEditPanel(display display, state *sheetState) *skillEditPanel {
+	return &skillEditPanel{display: display, state: state}
 }
 
 func (s *skillEditPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
-	if !s.editing {
+	if s.state.tab != sheetTabSkills {
+		return nocommand()
+	}
+	if !s.state.editing {
 		switch tboxev.Ch {
 		case 'i':
-			s.editing = true
+			s.state.editing = true
 			return game.StartLearningCommand{}, nil
 		}
 	} else {
 		switch tboxev.Key {
 		case termbox.KeyEsc:
-			s.editing = false
+			s.state.editing = false
 			s.change = nil
 			return game.CancelLearningCommand{}, nil
 		case termbox.KeyEnter:
-			s.editing = false
+			s.state.editing = false
 			s.change = nil
 			return game.FinishLearningCommand{}, nil
 		}
@@ -114,6 +148,9 @@ func (s *skillEditPanel) HandleInput(tboxev termbox.Event) (game.Command, error)
 }
 
 func (s *skillEditPanel) HandleEvent(e game.Event) {
+	if s.state.tab != sheetTabSkills {
+		return
+	}
 	switch ev := e.(type) {
 	case game.SkillChangeEvent:
 		s.change = ev.Change
@@ -121,7 +158,7 @@ func (s *skillEditPanel) HandleEvent(e game.Event) {
 }
 
 func (s *skillEditPanel) Render(g *game.Game) {
-	if !s.editing || s.change == nil {
+	if s.state.tab != sheetTabSkills || !s.state.editing || s.change == nil {
 		return
 	}
 
@@ -134,6 +171,210 @@ func (s *skillEditPanel) Render(g *game.Game) {
 	}
 }
 
+// Lists every ability by skill, and lets the player buy them with XP.
+type abilityPanel struct {
+	display display
+	state   *sheetState
+	// Index into abilityOrder() of the highlighted ability.
+	cur    int
+	change *game.SkillChange
+}
+
+func newAbilityPanel(display display, state *sheetState) *abilityPanel {
+	return &abilityPanel{display: display, state: state}
+}
+
+func (a *abilityPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if a.state.tab != sheetTabAbilities {
+		return nocommand()
+	}
+	if !a.state.editing {
+		switch tboxev.Ch {
+		case 'i':
+			a.state.editing = true
+			return game.StartLearningCommand{}, nil
+		}
+		return nocommand()
+	}
+
+	switch tboxev.Key {
+	case termbox.KeyEsc:
+		a.state.editing = false
+		a.change = nil
+		return game.CancelLearningCommand{}, nil
+	case termbox.KeyEnter:
+		a.state.editing = false
+		a.change = nil
+		return game.FinishLearningCommand{}, nil
+	}
+
+	order := abilityOrder()
+	switch tboxev.Ch {
+	case 'j':
+		a.cur = (a.cur + 1) % len(order)
+	case 'k':
+		a.cur = (a.cur + len(order) - 1) % len(order)
+	case 'h':
+		return game.UnlearnAbilityCommand{Ability: order[a.cur]}, nil
+	case 'l':
+		return game.LearnAbilityCommand{Ability: order[a.cur]}, nil
+	}
+	return nocommand()
+}
+
+func (a *abilityPanel) HandleEvent(e game.Event) {
+	if a.state.tab != sheetTabAbilities {
+		return
+	}
+	switch ev := e.(type) {
+	case game.SkillChangeEvent:
+		a.change = ev.Change
+	}
+}
+
+// Known abilities are green, ones that can't be bought yet are red.
+func (a *abilityPanel) Render(g *game.Game) {
+	if a.state.tab != sheetTabAbilities {
+		return
+	}
+
+	learner := g.Player.Learner
+	y := 7
+	for sk := game.Melee; sk < game.NumSkills; sk++ {
+		abilities := game.SkillAbilities(sk)
+		if len(abilities) == 0 {
+			continue
+		}
+		a.display.Write(40, y, sk.Describe(), termbox.ColorWhite, termbox.ColorBlack)
+		y++
+
+		for _, ab := range abilities {
+			fg, info := termbox.ColorWhite, game.Abilities[ab]
+			switch learner.CanLearnAbility(ab) {
+			case game.ErrAbilityKnown:
+				fg = termbox.ColorGreen
+			case game.ErrAbilityLocked, game.ErrNotEnoughXP:
+				fg = termbox.ColorRed
+			}
+			if a.state.editing && ab == abilityOrder()[a.cur] {
+				fg = termbox.ColorBlue
+			}
+			row := fmt.Sprintf("  %-15s %2d %5dxp", info.Name, info.Level, info.Cost)
+			a.display.Write(40, y, row, fg, termbox.ColorBlack)
+			y++
+		}
+	}
+
+	if a.state.editing {
+		info := game.Abilities[abilityOrder()[a.cur]]
+		a.display.Write(1, 14, info.Desc, termbox.ColorWhite, termbox.ColorBlack)
+		if a.change != nil {
+			a.display.Write(1, 15, fmt.Sprintf("COST %12d", a.change.TotalCost), termbox.ColorWhite, termbox.ColorBlack)
+		}
+	}
+}
+
+// Explains where every number on the sheet comes from.
+type breakdownPanel struct {
+	display display
+	state   *sheetState
+	// Index into breakdownRows of the highlighted number.
+	cur int
+}
+
+func newBreakdownPanel(display display, state *sheetState) *breakdownPanel {
+	return &breakdownPanel{display: display, state: state}
+}
+
+// The numbers that can be explained, in the order they're listed.
+var breakdownRows = []string{
+	"STR", "AGI", "VIT", "MND",
+	"FIGHT", "DODGE", "SHOOT", "SNEAK", "CHI", "SENSE", "MAGIC", "SONG",
+	"MELEE", "EVASION", "SPEED", "PROT",
+}
+
+func (b *breakdownPanel) HandleInput(tboxev termbox.Event) (game.Command, error) {
+	if b.state.tab != sheetTabBreakdown {
+		return nocommand()
+	}
+	switch tboxev.Ch {
+	case 'j':
+		b.cur = (b.cur + 1) % len(breakdownRows)
+	case 'k':
+		b.cur = (b.cur + len(breakdownRows) - 1) % len(breakdownRows)
+	}
+	return nocommand()
+}
+
+func (b *breakdownPanel) HandleEvent(e game.Event) {
+}
+
+func (b *breakdownPanel) Render(g *game.Game) {
+	if b.state.tab != sheetTabBreakdown {
+		return
+	}
+
+	for i, name := range breakdownRows {
+		fg := termbox.ColorWhite
+		if i == b.cur {
+			fg = termbox.ColorBlue
+		}
+		b.display.Write(40, 4+i, name, fg, termbox.ColorBlack)
+	}
+
+	sheet, y := g.Player.Sheet, 4
+	if breakdownRows[b.cur] == "PROT" {
+		for _, mod := range sheet.ProtBreakdown() {
+			row := fmt.Sprintf("%-20s %s", mod.Source.Name, mod.Dice)
+			b.display.Write(50, y, row, termbox.ColorWhite, termbox.ColorBlack)
+			y++
+		}
+		return
+	}
+
+	breakdown := b.breakdown(sheet)
+	b.display.Write(50, y, fmt.Sprintf("%-20s %3d", "BASE", breakdown.Base), termbox.ColorWhite, termbox.ColorBlack)
+	y++
+	for _, mod := range breakdown.Mods {
+		fg := termbox.ColorGreen
+		if mod.Amount < 0 {
+			fg = termbox.ColorRed
+		}
+		row := fmt.Sprintf("%-20s %3s", mod.Source.Name, extrasign(mod.Amount))
+		b.display.Write(50, y, row, fg, termbox.ColorBlack)
+		y++
+	}
+	b.display.Write(50, y, fmt.Sprintf("%-20s %3d", "TOTAL", breakdown.Total()), termbox.ColorWhite, termbox.ColorBlack)
+}
+
+// The breakdown for the highlighted row, which can't be PROT.
+func (b *breakdownPanel) breakdown(sheet game.Sheet) game.Breakdown {
+	nstats, nskills := int(game.NumStats), int(game.NumSkills)
+	switch cur := b.cur; {
+	case cur < nstats:
+		return sheet.StatBreakdown(game.StatName(cur))
+	case cur < nstats+nskills:
+		return sheet.SkillBreakdown(game.SkillName(cur - nstats))
+	}
+	switch breakdownRows[b.cur] {
+	case "MELEE":
+		return sheet.MeleeBreakdown()
+	case "EVASION":
+		return sheet.EvasionBreakdown()
+	default:
+		return sheet.SpeedBreakdown()
+	}
+}
+
+// Every ability, in the order they're listed on the sheet.
+func abilityOrder() []game.AbilityName {
+	order := make([]game.AbilityName, 0, game.NumAbilities)
+	for sk := game.Melee; sk < game.NumSkills; sk++ {
+		order = append(order, game.SkillAbilities(sk)...)
+	}
+	return order
+}
+
 func extrasign(x int) string {
 	s := fmt.Sprintf("%d", x)
 	if x >= 0 {
@@ -141,17 +382,3 @@ func extrasign(x int) string {
 	}
 	return s
 }
-
-func skillname(s game.SkillName) string {
-	skillnames := []string{
-		"FIGHT",
-		"DODGE",
-		"SHOOT",
-		"SNEAK",
-		"CHI",
-		"SENSE",
-		"MAGIC",
-		"SONG",
-	}
-	return skillnames[s]
-}
@@ -0,0 +1,514 @@
+// Package config reads and writes the player's srl config file. The file is a
+// list of 'name = value' lines, e.g.
+//
+//	# Move with the numpad instead of hjklyubn.
+//	layout = numpad
+//	more = anykey
+//	bind.pickup = g
+//
+// Blank lines and lines starting with '#' are ignored.
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Which keys move the player by default.
+type Layout string
+
+const (
+	// hjkl and yubn, like vi.
+	LayoutRoguelike Layout = "roguelike"
+	// The digits around 5 on a numpad.
+	LayoutNumpad Layout = "numpad"
+	// The arrow keys, with home/pgup/end/pgdn for diagonals.
+	LayoutArrows Layout = "arrows"
+)
+
+// How --more-- prompts are dismissed.
+type More string
+
+const (
+	// Wait for Enter or Esc.
+	MorePrompt More = "prompt"
+	// Wait for any key.
+	MoreAnyKey More = "anykey"
+	// Don't wait at all.
+	MoreOff More = "off"
+)
+
+// How things are coloured.
+type Colors string
+
+const (
+	ColorsDefault Colors = "default"
+	// Everything in one colour, for terminals where colours are hard to read.
+	ColorsMono Colors = "mono"
+)
+
+// Whether exploring picks up the items it finds.
+type Pickup string
+
+const (
+	PickupOn  Pickup = "on"
+	PickupOff Pickup = "off"
+)
+
+// An option that can be set to one of a few values.
+type Option struct {
+	Name    string
+	Choices []string
+}
+
+// Every option that has a fixed set of values, in the order they're listed on
+// the options screen. The morgue directory can be anything, so isn't here.
+var Options = []Option{
+	{Name: "layout", Choices: []string{string(LayoutRoguelike), string(LayoutNumpad), string(LayoutArrows)}},
+	{Name: "more", Choices: []string{string(MorePrompt), string(MoreAnyKey), string(MoreOff)}},
+	{Name: "colors", Choices: []string{string(ColorsDefault), string(ColorsMono)}},
+	{Name: "pickup", Choices: []string{string(PickupOn), string(PickupOff)}},
+}
+
+// Something the player can do from the HUD.
+type Action string
+
+const (
+	ActionWest      Action = "west"
+	ActionSouth     Action = "south"
+	ActionNorth     Action = "north"
+	ActionEast      Action = "east"
+	ActionNorthwest Action = "northwest"
+	ActionNortheast Action = "northeast"
+	ActionSouthwest Action = "southwest"
+	ActionSoutheast Action = "southeast"
+	ActionRest      Action = "rest"
+	ActionPickup    Action = "pickup"
+	ActionDrop      Action = "drop"
+	ActionEquip     Action = "equip"
+	ActionRemove    Action = "remove"
+	ActionUse       Action = "use"
+	ActionCast      Action = "cast"
+	ActionSing      Action = "sing"
+	ActionStopSing  Action = "stopsinging"
+	ActionInventory Action = "inventory"
+	ActionAscend    Action = "ascend"
+	ActionDescend   Action = "descend"
+	ActionSheet     Action = "sheet"
+	ActionHistory   Action = "history"
+	ActionExplain   Action = "explainblow"
+	ActionVerbose   Action = "verbose"
+	ActionOptions   Action = "options"
+	ActionQuit      Action = "quit"
+)
+
+// Running keeps the player going in one direction until something interesting
+// happens. Travel takes them to a tile picked on the map, or to the nearest
+// stairs. Exploring takes them wherever they haven't been yet.
+const (
+	ActionRunWest      Action = "runwest"
+	ActionRunSouth     Action = "runsouth"
+	ActionRunNorth     Action = "runnorth"
+	ActionRunEast      Action = "runeast"
+	ActionRunNorthwest Action = "runnorthwest"
+	ActionRunNortheast Action = "runnortheast"
+	ActionRunSouthwest Action = "runsouthwest"
+	ActionRunSoutheast Action = "runsoutheast"
+	ActionTravel       Action = "travel"
+	ActionTravelStairs Action = "travelstairs"
+	ActionExplore      Action = "explore"
+)
+
+// Searching looks around for hidden traps and doors. Disarming asks which way
+// the trap is first.
+const (
+	ActionSearch Action = "search"
+	ActionDisarm Action = "disarm"
+)
+
+// Every action, in the order they're listed on the options screen.
+var Actions = []Action{
+	ActionWest, ActionSouth, ActionNorth, ActionEast,
+	ActionNorthwest, ActionNortheast, ActionSouthwest, ActionSoutheast,
+	ActionRunWest, ActionRunSouth, ActionRunNorth, ActionRunEast,
+	ActionRunNorthwest, ActionRunNortheast, ActionRunSouthwest, ActionRunSoutheast,
+	ActionTravel, ActionTravelStairs, ActionExplore, ActionSearch, ActionDisarm,
+	ActionRest, ActionPickup, ActionDrop, ActionEquip, ActionRemove, ActionUse,
+	ActionCast, ActionSing, ActionStopSing, ActionInventory, ActionAscend,
+	ActionDescend, ActionSheet, ActionHistory, ActionExplain, ActionVerbose,
+	ActionOptions, ActionQuit,
+}
+
+// Keys that have names instead of being written as themselves. Enter and Esc
+// are left out since every menu uses them.
+var NamedKeys = []string{
+	"up", "down", "left", "right", "home", "end", "pgup", "pgdn", "insert",
+	"delete", "space", "tab",
+}
+
+// Keys for the actions that don't depend on the layout.
+var commonKeys = map[Action]string{
+	ActionPickup:    ",",
+	ActionDrop:      "d",
+	ActionEquip:     "w",
+	ActionRemove:    "r",
+	ActionUse:       "a",
+	ActionCast:      "m",
+	ActionSing:      "s",
+	ActionStopSing:  "S",
+	ActionInventory: "i",
+	ActionAscend:    ">",
+	ActionDescend:   "<",
+	ActionSheet:     "@",
+	ActionHistory:   "P",
+	ActionExplain:   "X",
+	ActionVerbose:   "V",
+	ActionOptions:   "=",
+	ActionQuit:      "q",
+
+	ActionRunWest:      "H",
+	ActionRunSouth:     "J",
+	ActionRunNorth:     "K",
+	ActionRunEast:      "L",
+	ActionRunNorthwest: "Y",
+	ActionRunNortheast: "U",
+	ActionRunSouthwest: "B",
+	ActionRunSoutheast: "N",
+	ActionTravel:       "t",
+	ActionTravelStairs: "G",
+	ActionExplore:      "o",
+	ActionSearch:       "f",
+	ActionDisarm:       "D",
+}
+
+// Keys for moving and resting in each layout.
+var layoutKeys = map[Layout]map[Action]string{
+	LayoutRoguelike: {
+		ActionWest: "h", ActionSouth: "j", ActionNorth: "k", ActionEast: "l",
+		ActionNorthwest: "y", ActionNortheast: "u", ActionSouthwest: "b", ActionSoutheast: "n",
+		ActionRest: "z",
+	},
+	LayoutNumpad: {
+		ActionWest: "4", ActionSouth: "2", ActionNorth: "8", ActionEast: "6",
+		ActionNorthwest: "7", ActionNortheast: "9", ActionSouthwest: "1", ActionSoutheast: "3",
+		ActionRest: "5",
+	},
+	LayoutArrows: {
+		ActionWest: "left", ActionSouth: "down", ActionNorth: "up", ActionEast: "right",
+		ActionNorthwest: "home", ActionNortheast: "pgup", ActionSouthwest: "end", ActionSoutheast: "pgdn",
+		ActionRest: "z",
+	},
+}
+
+var (
+	ErrUnknownOption = errors.New("UnknownOption")
+	ErrBadValue      = errors.New("BadValue")
+	ErrUnknownAction = errors.New("UnknownAction")
+	ErrBadKey        = errors.New("BadKey")
+	ErrDuplicateKey  = errors.New("DuplicateKey")
+	ErrSyntax        = errors.New("Syntax")
+)
+
+// Explains what's wrong with a config. Err is one of the Err* values above.
+type Error struct {
+	// The line in the file that has the problem, or 0 if it isn't any one
+	// line's fault.
+	Line   int
+	Err    error
+	Detail string
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%v: %s", e.Err, e.Detail)
+	}
+	return fmt.Sprintf("line %d: %v: %s", e.Line, e.Err, e.Detail)
+}
+
+// The player's settings.
+type Config struct {
+	Layout Layout
+	More   More
+	Colors Colors
+	Pickup Pickup
+	// Where to write morgue files. Empty means wherever srl would by default.
+	MorgueDir string
+	// Keys the player has chosen for actions, overriding the layout.
+	Bindings map[Action]string
+}
+
+// The settings srl uses if there is no config file.
+func Default() *Config {
+	return &Config{
+		Layout:   LayoutRoguelike,
+		More:     MorePrompt,
+		Colors:   ColorsDefault,
+		Pickup:   PickupOn,
+		Bindings: map[Action]string{},
+	}
+}
+
+// Loads the config file at 'path'. If there isn't one, the default config is
+// returned.
+func Load(path string) (*Config, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Reads a config from 'r', and makes sure that it's valid.
+func Parse(r io.Reader) (*Config, error) {
+	c := Default()
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		eq := strings.Index(text, "=")
+		if eq < 0 {
+			return nil, &Error{Line: line, Err: ErrSyntax, Detail: "expected 'name = value'"}
+		}
+		name, value := strings.TrimSpace(text[:eq]), strings.TrimSpace(text[eq+1:])
+
+		if err := c.set(name, value); err != nil {
+			err.Line = line
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Sets option 'name' to 'value', which must be one of its choices. If this
+// would leave two actions on the same key, nothing is changed.
+func (c *Config) Set(name, value string) error {
+	if strings.HasPrefix(name, "bind.") {
+		return c.Bind(Action(strings.TrimPrefix(name, "bind.")), value)
+	}
+	old := c.Get(name)
+	if err := c.set(name, value); err != nil {
+		return err
+	}
+	if err := c.Validate(); err != nil {
+		c.set(name, old)
+		return err
+	}
+	return nil
+}
+
+// Gets the value of option 'name'.
+func (c *Config) Get(name string) string {
+	switch name {
+	case "layout":
+		return string(c.Layout)
+	case "more":
+		return string(c.More)
+	case "colors":
+		return string(c.Colors)
+	case "pickup":
+		return string(c.Pickup)
+	case "morgue":
+		return c.MorgueDir
+	}
+	return ""
+}
+
+func (c *Config) set(name, value string) *Error {
+	for _, opt := range Options {
+		if opt.Name == name && !oneof(value, opt.Choices) {
+			return &Error{Err: ErrBadValue, Detail: fmt.Sprintf("%s can't be %q", name, value)}
+		}
+	}
+
+	switch {
+	case name == "layout":
+		c.Layout = Layout(value)
+	case name == "more":
+		c.More = More(value)
+	case name == "colors":
+		c.Colors = Colors(value)
+	case name == "pickup":
+		c.Pickup = Pickup(value)
+	case name == "morgue":
+		c.MorgueDir = value
+	case strings.HasPrefix(name, "bind."):
+		action := Action(strings.TrimPrefix(name, "bind."))
+		if !knownAction(action) {
+			return &Error{Err: ErrUnknownAction, Detail: string(action)}
+		}
+		if !ValidKey(value) {
+			return &Error{Err: ErrBadKey, Detail: fmt.Sprintf("can't bind %q", value)}
+		}
+		c.Bindings[action] = value
+	default:
+		return &Error{Err: ErrUnknownOption, Detail: name}
+	}
+	return nil
+}
+
+// Checks that no two actions are bound to the same key.
+func (c *Config) Validate() error {
+	keys := c.Keys()
+	bound := map[string]Action{}
+	for _, action := range Actions {
+		key := keys[action]
+		if other, ok := bound[key]; ok {
+			detail := fmt.Sprintf("%q is bound to both %s and %s", key, other, action)
+			return &Error{Err: ErrDuplicateKey, Detail: detail}
+		}
+		bound[key] = action
+	}
+	return nil
+}
+
+// The key for every action: the player's bindings, then the layout's, then
+// the common defaults.
+func (c *Config) Keys() map[Action]string {
+	keys := map[Action]string{}
+	for action, key := range commonKeys {
+		keys[action] = key
+	}
+	for action, key := range layoutKeys[c.Layout] {
+		keys[action] = key
+	}
+	for action, key := range c.Bindings {
+		keys[action] = key
+	}
+	return keys
+}
+
+// Binds 'action' to 'key'. If that key was already bound to something else,
+// the two actions swap keys so that the config stays valid.
+func (c *Config) Bind(action Action, key string) error {
+	if !knownAction(action) {
+		return &Error{Err: ErrUnknownAction, Detail: string(action)}
+	}
+	if !ValidKey(key) {
+		return &Error{Err: ErrBadKey, Detail: fmt.Sprintf("can't bind %q", key)}
+	}
+
+	keys := c.Keys()
+	old := keys[action]
+	for other, otherkey := range keys {
+		if other != action && otherkey == key {
+			c.Bindings[other] = old
+		}
+	}
+	c.Bindings[action] = key
+	c.tidy()
+	return nil
+}
+
+// Forget bindings that are the same as the defaults.
+func (c *Config) tidy() {
+	defaults := (&Config{Layout: c.Layout}).Keys()
+	for action, key := range c.Bindings {
+		if defaults[action] == key {
+			delete(c.Bindings, action)
+		}
+	}
+}
+
+// Writes the config to 'w' in a form that Parse can read back.
+func (c *Config) Write(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# srl config. Lines are 'name = value'.\n")
+	for _, opt := range Options {
+		fmt.Fprintf(b, "# %s: %s\n", opt.Name, strings.Join(opt.Choices, " | "))
+		fmt.Fprintf(b, "%s = %s\n", opt.Name, c.Get(opt.Name))
+	}
+	if c.MorgueDir != "" {
+		fmt.Fprintf(b, "morgue = %s\n", c.MorgueDir)
+	}
+
+	fmt.Fprintf(b, "# Keys can be rebound with 'bind.<action> = <key>'.\n")
+	for _, action := range Actions {
+		if key, ok := c.Bindings[action]; ok {
+			fmt.Fprintf(b, "bind.%s = %s\n", action, key)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Saves the config to 'path'. It's written to a temporary file first, which
+// is then renamed over the old config.
+func (c *Config) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, ".config")
+	if err != nil {
+		return err
+	}
+	if err := c.Write(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Is 'key' something that can be bound? That's either a single printable
+// character, or one of NamedKeys.
+func ValidKey(key string) bool {
+	if utf8.RuneCountInString(key) == 1 {
+		r, _ := utf8.DecodeRuneInString(key)
+		return r > ' ' && r != utf8.RuneError && r != 0x7f
+	}
+	for _, name := range NamedKeys {
+		if key == name {
+			return true
+		}
+	}
+	return false
+}
+
+func knownAction(action Action) bool {
+	for _, a := range Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// Is 'value' one of 'choices'?
+func oneof(value string, choices []string) bool {
+	for _, choice := range choices {
+		if value == choice {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultIsValid(t *testing.T) {
+	if err := Default().Validate(); err != nil {
+		t.Errorf(`Default config was invalid: %v`, err)
+	}
+}
+
+func TestEveryLayoutIsValid(t *testing.T) {
+	for _, layout := range Options[0].Choices {
+		c := Default()
+		if err := c.Set("layout", layout); err != nil {
+			t.Errorf(`Layout %s was invalid: %v`, layout, err)
+		}
+		if keys := c.Keys(); len(keys) != len(Actions) {
+			t.Errorf(`Layout %s bound %d actions; want %d`, layout, len(keys), len(Actions))
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	text := `
+# A comment.
+layout = numpad
+more=anykey
+colors = mono
+morgue = /tmp/morgue dir
+bind.pickup = g
+bind.options = =
+bind.rest = space
+`
+	c, err := Parse(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf(`Parse returned error %v`, err)
+	}
+
+	if c.Layout != LayoutNumpad || c.More != MoreAnyKey || c.Colors != ColorsMono {
+		t.Errorf(`Parse gave options %v, %v, %v; want numpad, anykey, mono`, c.Layout, c.More, c.Colors)
+	}
+	if c.MorgueDir != "/tmp/morgue dir" {
+		t.Errorf(`Parse gave morgue "%s"; want "/tmp/morgue dir"`, c.MorgueDir)
+	}
+
+	keys := c.Keys()
+	wants := map[Action]string{
+		ActionPickup:  "g",
+		ActionOptions: "=",
+		ActionRest:    "space",
+		ActionWest:    "4",
+		ActionDrop:    "d",
+	}
+	for action, want := range wants {
+		if got := keys[action]; got != want {
+			t.Errorf(`%s was bound to %q; want %q`, action, got, want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		text string
+		line int
+		err  error
+	}{
+		{"layout = dvorak", 1, ErrBadValue},
+		{"\n\nspeed = fast", 3, ErrUnknownOption},
+		{"layout roguelike", 1, ErrSyntax},
+		{"bind.fly = f", 1, ErrUnknownAction},
+		{"bind.rest = enter", 1, ErrBadKey},
+		{"bind.rest = zz", 1, ErrBadKey},
+		{"bind.rest = h", 0, ErrDuplicateKey},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(strings.NewReader(test.text))
+		cerr, ok := err.(*Error)
+		if !ok {
+			t.Errorf(`Parse(%q) returned %v; want a config error`, test.text, err)
+			continue
+		}
+		if cerr.Err != test.err || cerr.Line != test.line {
+			t.Errorf(`Parse(%q) gave %v on line %d; want %v on line %d`, test.text, cerr.Err, cerr.Line, test.err, test.line)
+		}
+	}
+}
+
+func TestSetRevertsDuplicates(t *testing.T) {
+	c := Default()
+	c.Bind(ActionPickup, "4")
+
+	err := c.Set("layout", string(LayoutNumpad))
+	if cerr, ok := err.(*Error); !ok || cerr.Err != ErrDuplicateKey {
+		t.Errorf(`Set layout with clashing binding returned %v; want ErrDuplicateKey`, err)
+	}
+	if c.Layout != LayoutRoguelike {
+		t.Errorf(`Failed Set left layout as %s; want %s`, c.Layout, LayoutRoguelike)
+	}
+}
+
+func TestBindSwapsClashingKeys(t *testing.T) {
+	c := Default()
+	if err := c.Bind(ActionPickup, "d"); err != nil {
+		t.Fatalf(`Bind returned error %v`, err)
+	}
+
+	keys := c.Keys()
+	if keys[ActionPickup] != "d" || keys[ActionDrop] != "," {
+		t.Errorf(`After binding pickup to d, pickup=%q drop=%q; want "d" and ","`, keys[ActionPickup], keys[ActionDrop])
+	}
+	if err := c.Validate(); err != nil {
+		t.Errorf(`Config invalid after Bind: %v`, err)
+	}
+}
+
+func TestBindForgetsDefaults(t *testing.T) {
+	c := Default()
+	c.Bind(ActionPickup, "g")
+	c.Bind(ActionPickup, ",")
+
+	if n := len(c.Bindings); n != 0 {
+		t.Errorf(`Binding back to default left %d bindings; want 0`, n)
+	}
+}
+
+func TestWriteParsesBack(t *testing.T) {
+	c := Default()
+	c.Set("layout", string(LayoutArrows))
+	c.Set("more", string(MoreOff))
+	c.Set("pickup", string(PickupOff))
+	c.MorgueDir = "/somewhere"
+	c.Bind(ActionQuit, "Q")
+
+	var buf bytes.Buffer
+	if err := c.Write(&buf); err != nil {
+		t.Fatalf(`Write returned error %v`, err)
+	}
+	back, err := Parse(&buf)
+	if err != nil {
+		t.Fatalf(`Parsing written config returned error %v`, err)
+	}
+
+	if back.Layout != c.Layout || back.More != c.More || back.Colors != c.Colors || back.Pickup != c.Pickup || back.MorgueDir != c.MorgueDir {
+		t.Errorf(`Config came back as %+v; want %+v`, back, c)
+	}
+	if key := back.Keys()[ActionQuit]; key != "Q" {
+		t.Errorf(`quit came back bound to %q; want "Q"`, key)
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srlconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "srl", "config")
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf(`Load on missing file returned error %v`, err)
+	}
+	if c.Layout != LayoutRoguelike {
+		t.Errorf(`Load on missing file gave layout %s; want default`, c.Layout)
+	}
+
+	c.Set("colors", string(ColorsMono))
+	if err := c.Save(path); err != nil {
+		t.Fatalf(`Save returned error %v`, err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf(`Load returned error %v`, err)
+	}
+	if loaded.Colors != ColorsMono {
+		t.Errorf(`Loaded colors %s; want %s`, loaded.Colors, ColorsMono)
+	}
+}
+
+func TestValidKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"a", true},
+		{"=", true},
+		{"pgup", true},
+		{"", false},
+		{" ", false},
+		{"ab", false},
+		{"esc", false},
+	}
+	for _, test := range tests {
+		if got := ValidKey(test.key); got != test.want {
+			t.Errorf(`ValidKey(%q) was %v; want %v`, test.key, got, test.want)
+		}
+	}
+}
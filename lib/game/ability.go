@@ -0,0 +1,55 @@
+package game
+
+import (
+	"errors"
+)
+
+// Names of abilities that can be bought with XP.
+type AbilityName uint
+
+// Something a character can learn within a skill once they are good enough at
+// it, by spending XP.
+type Ability struct {
+	Name string
+	// A short explanation of what this does, for the character sheet.
+	Desc string
+	// The skill this ability belongs to.
+	Skill SkillName
+	// How many points of 'Skill' need to have been learned before this can be
+	// bought.
+	Level int
+	// How much XP this costs.
+	Cost int
+	// Abilities that must already be known before this one can be learned.
+	Requires []AbilityName
+}
+
+var (
+	ErrAbilityKnown      = errors.New("AbilityKnown")
+	ErrAbilityLocked     = errors.New("AbilityLocked")
+	ErrAbilityNotLearned = errors.New("AbilityNotLearned")
+	ErrAbilityRequired   = errors.New("AbilityRequired")
+	ErrSkillRequired     = errors.New("SkillRequired")
+)
+
+// Returns the abilities that belong to 'sk', in the order they should be
+// shown.
+func SkillAbilities(sk SkillName) []AbilityName {
+	abilities := make([]AbilityName, 0)
+	for a := AbilityName(0); a < NumAbilities; a++ {
+		if Abilities[a].Skill == sk {
+			abilities = append(abilities, a)
+		}
+	}
+	return abilities
+}
+
+// Does 'obj' know ability 'a'? Things that can't learn don't know anything.
+func hasability(obj *Obj, a AbilityName) bool {
+	return obj.Learner != nil && obj.Learner.HasAbility(a)
+}
+
+// Modifiers granted by an ability are tagged with its name.
+func abilitysource(a AbilityName) ModSource {
+	return ModSource{Kind: ModAbility, Name: Abilities[a].Name}
+}
@@ -0,0 +1,93 @@
+package game
+
+// Every ability in the game. There's nothing for Shooting yet, since nothing
+// can shoot.
+const (
+	// Melee
+	AbilityPower AbilityName = iota
+	AbilityRapidAttack
+
+	// Evasion
+	AbilityDodging
+
+	// Stealth
+	AbilityLightStep
+
+	// Chi
+	AbilityInnerStrength
+
+	// Sense
+	AbilityKeenEyes
+
+	// Magic
+	AbilityChanneling
+
+	// Song
+	AbilitySustain
+
+	// Sentinel.
+	NumAbilities
+)
+
+// How much melee each blow of a rapid attack loses.
+const RapidAttackPenalty = 3
+
+var Abilities = map[AbilityName]*Ability{
+	AbilityPower: {
+		Name:  "POWER",
+		Desc:  "+1 damage side on every blow.",
+		Skill: Melee,
+		Level: 2,
+		Cost:  500,
+	},
+	AbilityRapidAttack: {
+		Name:     "RAPID ATTACK",
+		Desc:     "Strike twice each turn, at -3 melee.",
+		Skill:    Melee,
+		Level:    8,
+		Cost:     1500,
+		Requires: []AbilityName{AbilityPower},
+	},
+	AbilityDodging: {
+		Name:  "DODGING",
+		Desc:  "+2 evasion.",
+		Skill: Evasion,
+		Level: 2,
+		Cost:  500,
+	},
+	AbilityLightStep: {
+		Name:  "LIGHT STEP",
+		Desc:  "Leave half as much scent for monsters to follow.",
+		Skill: Stealth,
+		Level: 3,
+		Cost:  500,
+	},
+	AbilityInnerStrength: {
+		Name:  "INNER STRENGTH",
+		Desc:  "Regenerate faster.",
+		Skill: Chi,
+		Level: 3,
+		Cost:  500,
+	},
+	AbilityKeenEyes: {
+		Name:  "KEEN EYES",
+		Desc:  "+1 sight radius.",
+		Skill: Sense,
+		Level: 3,
+		Cost:  500,
+	},
+	AbilityChanneling: {
+		Name:  "CHANNELING",
+		Desc:  "Spells cost 1 less MP.",
+		Skill: Magic,
+		Level: 4,
+		Cost:  800,
+	},
+	AbilitySustain: {
+		Name:  "SUSTAIN",
+		Desc:  "Songs cost 1 less MP each turn.",
+		Skill: Song,
+		Level: 4,
+		Cost:  800,
+	},
+}
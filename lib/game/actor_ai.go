@@ -12,6 +12,8 @@ type AI interface {
 	Objgetter
 	Init()
 	Act() bool
+	// Makes me aware of the player, even if I can't see them.
+	Alert()
 }
 
 // State-machine-based "AI".
@@ -58,6 +60,13 @@ func (s *SMAI) Act() bool {
 	return true
 }
 
+// Starts chasing the player, if I'm doing something that I'd stop for that.
+func (s *SMAI) Alert() {
+	if _, ok := s.Brain[smaiKey{s.cur.State(), smaiFoundPlayer}]; ok {
+		s.transition(smaiFoundPlayer)
+	}
+}
+
 func (s *SMAI) transition(trans smaiTransition) {
 	nextState, ok := s.Brain[smaiKey{s.cur.State(), trans}]
 	if !ok {
@@ -262,9 +271,17 @@ func (s *smaiStateWandering) Act(me *SMAI) smaiTransition {
 	dir := nextpos.Sub(mypos)
 	_, err := me.obj.Mover.Move(dir)
 
-	if err == ErrMoveBlocked {
+	switch err {
+	case ErrMoveBlocked:
 		s.turnsBlocked++
-	} else {
+	case ErrMoveSwapFailed:
+		// Someone's in the way. Rather than start over, find a way around
+		// them and back onto the path.
+		s.turnsBlocked = 0
+		if path, ok := me.obj.Level.RepairPath(mypos, s.path, PathCost, unoccupiedfor(me.obj)); ok {
+			s.path = path
+		}
+	default:
 		s.turnsBlocked = 0
 		s.path = s.path[1:]
 	}
@@ -277,8 +294,7 @@ func (s *smaiStateWandering) Act(me *SMAI) smaiTransition {
 }
 
 func (s *smaiStateWandering) findpath(me *SMAI, dest math.Point) {
-	mypos := me.obj.Pos()
-	path, ok := me.obj.Level.FindPath(mypos, dest, PathCost)
+	path, ok := me.obj.Level.FindPathFor(me.obj, dest, PathCost)
 	if !ok {
 		// We can't find our way to our destination. Let's pretend our
 		// destination is right here.
@@ -299,7 +315,7 @@ type smaiStateChasing struct {
 }
 
 func (s *smaiStateChasing) Init(me *SMAI) {
-	me.obj.Game.Events.Message(fmt.Sprintf("%s shouts!", me.obj.Spec.Name))
+	me.obj.Game.Events.Message(fmt.Sprintf("%s shouts!", actorname(me.obj)))
 
 	// Figure out how long I'll chase by scent.
 	persistence := me.Personality.Persistence
@@ -323,20 +339,31 @@ func (s *smaiStateChasing) Act(me *SMAI) smaiTransition {
 		dir := math.Origin
 		s.turnsUnseen = 0
 
-		// Try chasing by sight.
+		// Casters sometimes prefer a spell to getting closer.
+		if obj.Caster != nil && obj.Caster.MaybeCast(obj.Game.Player) {
+			log.Printf("id%d. I cast a spell at the player.", obj.id)
+			return smaiNoTransition
+		}
+
+		// Try chasing by sight, following the level's map to the player. If
+		// there's no way through, just head straight for them.
 		playerpos := obj.Game.Player.Pos()
 
-		switch {
-		case pos.X < playerpos.X:
-			dir.X = 1
-		case pos.X > playerpos.X:
-			dir.X = -1
-		}
-		switch {
-		case pos.Y < playerpos.Y:
-			dir.Y = 1
-		case pos.Y > playerpos.Y:
-			dir.Y = -1
+		if next, ok := obj.Level.PlayerFlow(obj).Downhill(obj.Level, pos); ok {
+			dir = next.Sub(pos)
+		} else {
+			switch {
+			case pos.X < playerpos.X:
+				dir.X = 1
+			case pos.X > playerpos.X:
+				dir.X = -1
+			}
+			switch {
+			case pos.Y < playerpos.Y:
+				dir.Y = 1
+			case pos.Y > playerpos.Y:
+				dir.Y = -1
+			}
 		}
 		log.Printf("id%d. I see player at %v. I'm at %v moving %v", obj.id, playerpos, pos, dir)
 
@@ -381,77 +408,34 @@ func (s *smaiStateChasing) Act(me *SMAI) smaiTransition {
 	return smaiNoTransition
 }
 
-// Runs away from the player.
+// Runs away from the player, following the level's flee map. Fleeing monsters
+// that move the same way share a map, so this costs almost nothing per monster.
 type smaiStateFleeing struct {
 	smaiSB
-	turnsBlocked int
-	path         Path
-	dest         math.Point
-	helpless     bool
 }
 
 func (s *smaiStateFleeing) Init(me *SMAI) {
-	me.obj.Game.Events.Message(fmt.Sprintf("%s flees!", me.obj.Spec.Name))
+	me.obj.Game.Events.Message(fmt.Sprintf("%s flees!", actorname(me.obj)))
 	log.Printf("id%d. I'm running!! My pos is %v", me.obj.id, me.obj.Game.Player.Pos())
-	s.findsafety(me)
 }
 
 func (s *smaiStateFleeing) Act(me *SMAI) smaiTransition {
-	// If I'm stuck, don't do anything.
-	if s.helpless {
-		return smaiNoTransition
-	}
-
-	// If I arrived, repath.
-	if len(s.path) == 0 {
-		s.findsafety(me)
-		return s.Act(me)
-	}
-
-	// If I lost the path, repath.
-	mypos, nextpos := me.obj.Pos(), s.path[0]
-	if math.ChebyDist(mypos, nextpos) > 1 {
-		s.findsafety(me)
-		return s.Act(me)
-	}
-
-	// Move.
-	dir := nextpos.Sub(mypos)
-	ok, _ := me.obj.Mover.Move(dir)
+	obj := me.obj
+	pos := obj.Pos()
 
+	// If I'm cornered, don't do anything.
+	next, ok := obj.Level.FleeFlow(obj).Downhill(obj.Level, pos)
 	if !ok {
-		s.turnsBlocked++
-	} else {
-		s.turnsBlocked = 0
-		s.path = s.path[1:]
+		log.Printf("id%d. I'm cornered at %v", obj.id, pos)
+		return smaiNoTransition
 	}
 
-	// If we're blocked, try a different direction.
-	if s.turnsBlocked > 5 {
-		s.findsafety(me)
+	if _, err := obj.Mover.Move(next.Sub(pos)); err != nil {
+		log.Printf("id%d. I couldn't flee to %v: %v", obj.id, next, err)
 	}
-
 	return smaiNoTransition
 }
 
-func (s *smaiStateFleeing) findsafety(me *SMAI) {
-	tile := me.obj.Level.RandomClearTile()
-	if tile == nil {
-		s.helpless = true
-		return
-	}
-
-	dest := tile.Pos
-	path, ok := me.obj.Level.FindPath(me.obj.Pos(), dest, fleecost)
-
-	if !ok {
-		s.helpless = true
-		return
-	}
-	s.path = path
-	s.dest = dest
-}
-
 // A very homey state.
 type smaiStateAtHome struct {
 	smaiSB
@@ -520,14 +504,24 @@ func (s *smaiStateGoingHome) Act(me *SMAI) smaiTransition {
 }
 
 func (s *smaiStateGoingHome) findhome(me *SMAI) {
-	mypos := me.obj.Pos()
-	path, ok := me.obj.Level.FindPath(mypos, me.Personality.home, PathCost)
+	obj := me.obj
+	if path, ok := obj.Level.FindPathFor(obj, me.Personality.home, PathCost); ok {
+		s.path = path
+		return
+	}
+
+	// We can't find our way home, so we'll settle down by the nearest stairs.
+	path, ok := obj.Level.StairsFlow(obj).PathFrom(obj.Level, obj.Pos())
 	if !ok {
-		// We can't find our way to our destination. Let's pretend our
-		// destination is right here.
+		// There's nowhere to go. Let's pretend our destination is right here.
 		s.path = Path{}
 		return
 	}
+	me.Personality.home = obj.Pos()
+	if len(path) > 0 {
+		me.Personality.home = path[len(path)-1]
+	}
+	log.Printf("id%d. I can't get home. I'll move to %v instead.", obj.id, me.Personality.home)
 	s.path = path
 }
 
@@ -543,6 +537,21 @@ func fleecost(l *Level, loc math.Point) int {
 	return PathCost(l, loc)
 }
 
+// Pathfinding passability to use when we're trying to get around someone. We
+// can go wherever we normally could, as long as nobody's standing there.
+func unoccupied(t *Tile) bool {
+	return patheligible(t) && t.Actor == nil
+}
+
+// Same as unoccupied, but 'obj' can also go through secret doors it knows
+// about.
+func unoccupiedfor(obj *Obj) func(*Tile) bool {
+	pathable := pathableby(obj)
+	return func(t *Tile) bool {
+		return pathable(t) && t.Actor == nil
+	}
+}
+
 // A wandering monster. Randomly picks destinations to walk to, until it
 // detects the player.
 var SMAIWanderer = SMAIStateMachine{
@@ -0,0 +1,254 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+// A thing that can cast spells.
+type Caster interface {
+	Objgetter
+	// Bring up the 'cast' screen if this actor knows any spells.
+	TryCast()
+	// Cast the spell at index 'index' in Spells(). Targeted spells are aimed
+	// at the closest enemy in sight. Return true if a turn should pass.
+	Cast(index int) bool
+	// Maybe cast a spell at 'target', depending on how often this actor likes
+	// to cast. Used by the AI; returns true if a spell was cast.
+	MaybeCast(target *Obj) bool
+	// The spells that this actor is currently skilled enough to cast.
+	Spells() []*Spell
+	// How much MP it costs this actor to cast 'spell'.
+	Cost(spell *Spell) int
+}
+
+// A spell that can be cast by an actor.
+type Spell struct {
+	Name string
+	// The Magic skill needed to be able to cast this.
+	Level int
+	// How much MP this costs to cast.
+	Cost int
+	// Does this spell need a target?
+	Targeted bool
+	// Actually does the thing. 'target' will be nil for untargeted spells.
+	// 'power' is the caster's Magic skill.
+	Effect func(caster, target *Obj, power int)
+}
+
+type ActorCaster struct {
+	Trait
+	// All of the spells this actor could know.
+	spells []*Spell
+	// Monsters cast 1 in 'freq' turns when they can.
+	freq int
+}
+
+// Given a copy of an ActorCaster literal, this will return a function that
+// will bind the owner of the caster to it at object creation time.
+func NewActorCaster(spec *ActorCaster) func(*Obj) Caster {
+	return func(o *Obj) Caster {
+		c := &ActorCaster{}
+		*c = *spec
+		c.obj = o
+		return c
+	}
+}
+
+func (c *ActorCaster) Spells() []*Spell {
+	skill := c.obj.Sheet.Skill(Magic)
+	known := make([]*Spell, 0, len(c.spells))
+	for _, spell := range c.spells {
+		if spell.Level <= skill {
+			known = append(known, spell)
+		}
+	}
+	return known
+}
+
+func (c *ActorCaster) Cost(spell *Spell) int {
+	if hasability(c.obj, AbilityChanneling) {
+		return math.Max(1, spell.Cost-1)
+	}
+	return spell.Cost
+}
+
+func (c *ActorCaster) TryCast() {
+	if len(c.Spells()) == 0 {
+		c.obj.Game.Events.Message(fmt.Sprintf("%s doesn't know any spells.", actorname(c.obj)))
+	} else if c.obj.Sheet.Silenced() {
+		c.obj.Game.Events.Message(fmt.Sprintf("%s can't speak!", c.obj.Spec.Name))
+	} else {
+		c.obj.Game.SwitchMode(ModeCast)
+	}
+}
+
+func (c *ActorCaster) Cast(index int) bool {
+	c.obj.Game.SwitchMode(ModeHud)
+
+	spells := c.Spells()
+
+	// Bounds-check the index the player requested.
+	if index < 0 || index >= len(spells) {
+		return false
+	}
+
+	spell := spells[index]
+	var target *Obj
+
+	if spell.Targeted {
+		target = c.closestenemy()
+		if target == nil {
+			c.obj.Game.Events.Message("No target in sight.")
+			return false
+		}
+	}
+
+	return c.cast(spell, target)
+}
+
+func (c *ActorCaster) MaybeCast(target *Obj) bool {
+	if c.freq == 0 || c.obj.Sheet.Silenced() || !OneIn(c.freq) {
+		return false
+	}
+
+	spells := c.Spells()
+	if len(spells) == 0 {
+		return false
+	}
+
+	spell := spells[RandInt(0, len(spells))]
+	if c.Cost(spell) > c.obj.Sheet.MP() {
+		return false
+	}
+	return c.cast(spell, target)
+}
+
+// Cast 'spell' at 'target', spending MP. Returns false if the spell could not
+// be cast at all.
+func (c *ActorCaster) cast(spell *Spell, target *Obj) bool {
+	obj := c.obj
+	sheet := obj.Sheet
+
+	if sheet.Silenced() {
+		obj.Game.Events.Message(fmt.Sprintf("%s can't speak!", obj.Spec.Name))
+		return false
+	}
+	cost := c.Cost(spell)
+	if cost > sheet.MP() {
+		obj.Game.Events.Message(fmt.Sprintf("%s doesn't have enough MP to cast %s.", obj.Spec.Name, spell.Name))
+		return false
+	}
+
+	sheet.HurtMP(cost)
+	obj.Game.Events.Message(fmt.Sprintf("%s casts %s.", actorname(obj), spell.Name))
+	spell.Effect(obj, target, sheet.Skill(Magic))
+	return true
+}
+
+// Finds the closest actor in sight that this caster would want to hurt.
+// Returns nil if there isn't one.
+func (c *ActorCaster) closestenemy() *Obj {
+	if c.obj.Senser == nil {
+		return nil
+	}
+
+	var closest *Obj
+	mindist, pos := 0, c.obj.Pos()
+
+	for _, pt := range c.obj.Senser.FOV() {
+		other := c.obj.Level.At(pt).Actor
+		if other == nil || other.IsPlayer() == c.obj.IsPlayer() {
+			continue
+		}
+		if dist := math.ChebyDist(pos, pt); closest == nil || dist < mindist {
+			closest, mindist = other, dist
+		}
+	}
+	return closest
+}
+
+// Makes a spell effect that does 'n'd'sides' damage of element 'brand' to the
+// target, plus another die for every 5 points of power. 'brand' can be
+// EffectNone for pure magic damage.
+func spellbolt(brand Effect, n, sides int) func(*Obj, *Obj, int) {
+	return func(caster, target *Obj, power int) {
+		spelldamage(caster, target, brand, NewDice(n+power/5, sides).Roll())
+	}
+}
+
+// Like spellbolt, but also hits everything adjacent to the target except the
+// caster.
+func spellball(brand Effect, n, sides int) func(*Obj, *Obj, int) {
+	return func(caster, target *Obj, power int) {
+		victims := []*Obj{target}
+		for _, tile := range target.Level.Around(target.Pos()) {
+			if tile.Actor != nil && tile.Actor != caster {
+				victims = append(victims, tile.Actor)
+			}
+		}
+
+		dmg := NewDice(n+power/5, sides).Roll()
+		for _, victim := range victims {
+			spelldamage(caster, victim, brand, dmg)
+		}
+	}
+}
+
+// Makes a spell effect that tries to inflict 'effect' on the target for
+// 'n'd'sides' turns. The target gets a saving throw.
+func spellstatus(effect Effect, n, sides int) func(*Obj, *Obj, int) {
+	return func(caster, target *Obj, power int) {
+		if savingthrow(target, target.Sheet.Defense().Effects, effect) {
+			target.Ticker.AddEffect(effect, DieRoll(n, sides))
+		} else {
+			target.Game.Events.Message(fmt.Sprintf("%s resists.", actorname(target)))
+		}
+	}
+}
+
+// Makes a spell effect that heals the caster by 'base' HP, plus 2 for every
+// point of power.
+func spellheal(base int) func(*Obj, *Obj, int) {
+	return func(caster, _ *Obj, power int) {
+		caster.Sheet.Heal(base + 2*power)
+		caster.Game.Events.Message(fmt.Sprintf("%s looks %s.", actorname(caster), mark(StyleGood, "healthier")))
+	}
+}
+
+// Makes a spell effect that teleports the caster to a random open spot that it
+// can see, no further than 'dist' squares away.
+func spellblink(dist int) func(*Obj, *Obj, int) {
+	return func(caster, _ *Obj, _ int) {
+		pos, level := caster.Pos(), caster.Level
+
+		// Only spots in view will do, so that nobody blinks through a wall.
+		caster.Senser.CalcFields()
+		dests := make([]math.Point, 0)
+		for _, pt := range caster.Senser.FOV() {
+			if pt != pos && math.ChebyDist(pos, pt) <= dist {
+				dests = append(dests, pt)
+			}
+		}
+
+		for tries := 0; tries < 100 && len(dests) > 0; tries++ {
+			if level.Place(caster, dests[RandInt(0, len(dests))]) {
+				return
+			}
+		}
+		caster.Game.Events.Message(fmt.Sprintf("%s flickers.", caster.Spec.Name))
+	}
+}
+
+// Does 'dmg' magic damage to 'target', taking resistances to 'brand' into
+// account.
+func spelldamage(caster, target *Obj, brand Effect, dmg int) {
+	if brand != EffectNone {
+		dmg = target.Sheet.Defense().Effects.ResistDmg(brand, dmg)
+	}
+	msg := fmt.Sprintf("%s hits %s (%s).", actorname(caster), actorname(target), mark(StyleDamage, fmt.Sprint(dmg)))
+	target.Game.Events.Message(msg)
+	target.Game.blame(target, caster.Spec.Name)
+	target.Sheet.Hurt(dmg)
+}
@@ -0,0 +1,258 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+var (
+	actTestCast = false
+
+	actTestSpells = []*Spell{
+		{
+			Name:   "EASY",
+			Level:  1,
+			Cost:   2,
+			Effect: func(_, _ *Obj, _ int) { actTestCast = true },
+		},
+		{
+			Name:     "HARD",
+			Level:    5,
+			Cost:     2,
+			Targeted: true,
+			Effect:   func(_, _ *Obj, _ int) { actTestCast = true },
+		},
+	}
+
+	actCasterSpec = &Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: "TestSpecies",
+		Name:    "Caster",
+		Traits: &Traits{
+			Mover:  NewActorMover,
+			Sheet:  NewPlayerSheet,
+			Senser: NewActorSenser,
+			Ticker: NewActorTicker,
+			Caster: NewActorCaster(&ActorCaster{spells: actTestSpells}),
+		},
+	}
+)
+
+// Sets the Magic skill of 'obj' so that it will be 'n' after mods are applied.
+func actSetMagic(obj *Obj, n int) {
+	obj.Sheet.SetSkill(Magic, n-obj.Sheet.SkillMod(Magic))
+}
+
+func TestSpellsAreGatedByMagicSkill(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+
+	actSetMagic(caster, 0)
+	if n := len(caster.Caster.Spells()); n != 0 {
+		t.Errorf(`Spells() with Magic 0 had %d spells, want 0`, n)
+	}
+
+	actSetMagic(caster, 3)
+	if n := len(caster.Caster.Spells()); n != 1 {
+		t.Errorf(`Spells() with Magic 3 had %d spells, want 1`, n)
+	}
+
+	actSetMagic(caster, 5)
+	if n := len(caster.Caster.Spells()); n != 2 {
+		t.Errorf(`Spells() with Magic 5 had %d spells, want 2`, n)
+	}
+}
+
+func TestTryCastWithNoSpells(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	actSetMagic(caster, 0)
+
+	caster.Caster.TryCast()
+	if mode := g.mode; mode != ModeHud {
+		t.Errorf(`TryCast w no spells switched to mode %v, want %v`, mode, ModeHud)
+	}
+}
+
+func TestTryCastWhileSilenced(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	actSetMagic(caster, 1)
+	caster.Sheet.SetSilenced(true)
+
+	caster.Caster.TryCast()
+	if mode := g.mode; mode != ModeHud {
+		t.Errorf(`TryCast while silenced switched to mode %v, want %v`, mode, ModeHud)
+	}
+}
+
+func TestCastSpendsMP(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	actSetMagic(caster, 1)
+	g.Level.Place(caster, math.Pt(1, 1))
+	actTestCast = false
+
+	mp := caster.Sheet.MP()
+	caster.Caster.TryCast()
+	ok := caster.Caster.Cast(0)
+
+	if !ok {
+		t.Error(`Cast() returned false, want true`)
+	}
+	if !actTestCast {
+		t.Error(`Cast() did not cast spell`)
+	}
+	if now, want := caster.Sheet.MP(), mp-2; now != want {
+		t.Errorf(`Cast() left caster with %d MP, want %d`, now, want)
+	}
+	if mode := g.mode; mode != ModeHud {
+		t.Errorf(`Cast() switched to mode %v, want %v`, mode, ModeHud)
+	}
+}
+
+func TestCastWithoutEnoughMP(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	actSetMagic(caster, 1)
+	caster.Sheet.HurtMP(caster.Sheet.MP() - 1)
+	actTestCast = false
+
+	if caster.Caster.Cast(0) {
+		t.Error(`Cast() without enough MP returned true, want false`)
+	}
+	if actTestCast {
+		t.Error(`Cast() without enough MP cast the spell`)
+	}
+	if mp := caster.Sheet.MP(); mp != 1 {
+		t.Errorf(`Cast() without enough MP left caster with %d MP, want 1`, mp)
+	}
+}
+
+func TestCastWhileSilenced(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	actSetMagic(caster, 1)
+	caster.Sheet.SetSilenced(true)
+	actTestCast = false
+
+	mp := caster.Sheet.MP()
+	if caster.Caster.Cast(0) {
+		t.Error(`Cast() while silenced returned true, want false`)
+	}
+	if actTestCast {
+		t.Error(`Cast() while silenced cast the spell`)
+	}
+	if now := caster.Sheet.MP(); now != mp {
+		t.Errorf(`Cast() while silenced left caster with %d MP, want %d`, now, mp)
+	}
+}
+
+func TestCastTargetedWithNoTarget(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	actSetMagic(caster, 5)
+	g.Level.Remove(g.Player)
+	g.Level.Place(caster, math.Pt(1, 1))
+	caster.Senser.CalcFields()
+	actTestCast = false
+
+	if caster.Caster.Cast(1) {
+		t.Error(`Cast() with no target returned true, want false`)
+	}
+	if actTestCast {
+		t.Error(`Cast() with no target cast the spell`)
+	}
+}
+
+func TestSpellBoltHurtsTarget(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	target := g.NewObj(actCasterSpec)
+	g.Level.Place(caster, math.Pt(1, 1))
+	g.Level.Place(target, math.Pt(1, 2))
+
+	FixRandomDie([]int{3, 4})
+	defer RestoreRandom()
+
+	hp := target.Sheet.HP()
+	spellbolt(EffectNone, 2, 4)(caster, target, 0)
+
+	if now, want := target.Sheet.HP(), hp-7; now != want {
+		t.Errorf(`spellbolt left target with %d HP, want %d`, now, want)
+	}
+}
+
+func TestSpellBallHurtsNeighboursButNotCaster(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(actCasterSpec)
+	target := g.NewObj(actCasterSpec)
+	bystander := g.NewObj(actCasterSpec)
+	g.Level.Place(caster, math.Pt(1, 1))
+	g.Level.Place(target, math.Pt(1, 2))
+	g.Level.Place(bystander, math.Pt(2, 1))
+
+	FixRandomDie([]int{1, 1})
+	defer RestoreRandom()
+
+	chp, thp, bhp := caster.Sheet.HP(), target.Sheet.HP(), bystander.Sheet.HP()
+	spellball(EffectNone, 2, 4)(caster, target, 0)
+
+	if now := caster.Sheet.HP(); now != chp {
+		t.Errorf(`spellball left caster with %d HP, want %d`, now, chp)
+	}
+	if now, want := target.Sheet.HP(), thp-2; now != want {
+		t.Errorf(`spellball left target with %d HP, want %d`, now, want)
+	}
+	if now, want := bystander.Sheet.HP(), bhp-2; now != want {
+		t.Errorf(`spellball left bystander with %d HP, want %d`, now, want)
+	}
+}
+
+func TestSpellBlinkStaysInView(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#  #    #
+#########`)
+	caster := g.NewObj(actCasterSpec)
+	g.Level.Place(caster, math.Pt(1, 1))
+
+	for i := 0; i < 20; i++ {
+		spellblink(4)(caster, nil, 0)
+		if pos := caster.Pos(); pos.X > 2 {
+			t.Fatalf(`spellblink moved caster through a wall to %v`, pos)
+		}
+	}
+
+	g.Level.Place(caster, math.Pt(6, 1))
+	spellblink(4)(caster, nil, 0)
+	if pos := caster.Pos(); pos.X < 4 || pos == math.Pt(6, 1) {
+		t.Errorf(`spellblink moved caster from %v to %v; want somewhere in view`, math.Pt(6, 1), pos)
+	}
+}
+
+func TestMaybeCastWhileSilenced(t *testing.T) {
+	g := newTestGame()
+	caster := g.NewObj(&Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: "TestSpecies",
+		Name:    "Caster",
+		Traits: &Traits{
+			Sheet:  NewPlayerSheet,
+			Caster: NewActorCaster(&ActorCaster{spells: actTestSpells, freq: 1}),
+		},
+	})
+	actSetMagic(caster, 1)
+	caster.Sheet.SetSilenced(true)
+	actTestCast = false
+
+	if caster.Caster.MaybeCast(g.Player) {
+		t.Error(`MaybeCast() while silenced returned true, want false`)
+	}
+	if actTestCast {
+		t.Error(`MaybeCast() while silenced cast the spell`)
+	}
+}
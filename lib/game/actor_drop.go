@@ -9,8 +9,11 @@ type ItemDropper struct {
 	Trait
 	// Drop [1..num] items...
 	num int
-	// with gen depth boosted by 'boost'.
+	// with enchantments rolled as if 'boost' floors deeper.
 	boost int
+	// Always drop one of each of these, as long as they haven't been created
+	// already (some of them might be unique.)
+	always []*Spec
 }
 
 func NewItemDropper(spec *ItemDropper) func(*Obj) Dropper {
@@ -23,14 +26,21 @@ func NewItemDropper(spec *ItemDropper) func(*Obj) Dropper {
 }
 
 func (i *ItemDropper) DropItems() {
+	g := i.obj.Game
+
+	for _, spec := range i.always {
+		if g.Uniques.Available(spec) {
+			g.Level.Place(g.NewObj(spec), i.obj.Pos())
+		}
+	}
+
 	if i.num == 0 {
 		return
 	}
 
-	g := i.obj.Game
 	num := RandInt(0, i.num) + 1
 
-	groups := Generate(num, g.Progress.Floor, 2, Items, g)
+	groups := generateBoosted(num, g.Progress.Floor, 2, i.boost, Items, g)
 
 	for _, group := range groups {
 		for _, item := range group {
@@ -62,7 +62,7 @@ func (a *ActorEquipper) Equip(index int) bool {
 	}
 
 	if equip.Spec.Genus != GenEquipment {
-		a.obj.Game.Events.Message(fmt.Sprintf("Cannot equip %v.", equip.Spec.Name))
+		a.obj.Game.Events.Message(fmt.Sprintf("Cannot equip %v.", itemname(equip)))
 		return false
 	}
 	equip = inv.Take(index)
@@ -87,7 +87,7 @@ func (a *ActorEquipper) Remove(slot Slot) bool {
 
 	// No room for unequipped item in inventory; drop it.
 	a.obj.Tile.Items.Add(removed)
-	a.obj.Game.Events.Message(fmt.Sprintf("No room in pack! Dropped %v.", removed.Spec.Name))
+	a.obj.Game.Events.Message(fmt.Sprintf("No room in pack! Dropped %v.", itemname(removed)))
 	return true
 }
 
@@ -2,7 +2,6 @@ package game
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/MichaelDiBernardo/srl/lib/math"
 )
@@ -25,42 +24,63 @@ func NewActorFighter(obj *Obj) Fighter {
 }
 
 func (f *ActorFighter) Hit(other Fighter) {
-	hit(f, other)
+	if !hasability(f.obj, AbilityRapidAttack) {
+		hit(f, other, 0)
+		return
+	}
+
+	// Two quick blows instead of one careful one.
+	for i := 0; i < 2 && !other.Obj().Sheet.Dead(); i++ {
+		hit(f, other, -RapidAttackPenalty)
+	}
 }
 
-func hit(attacker Fighter, defender Fighter) {
+// Resolves a single blow from 'attacker' to 'defender'. 'meleemod' is added to
+// the attacker's melee for this blow only. A CombatEvent explaining the blow is
+// sent whether it lands or not.
+func hit(attacker Fighter, defender Fighter, meleemod int) {
 	a, d := attacker.Obj(), defender.Obj()
 	atk, def := a.Sheet.Attack(), d.Sheet.Defense()
+	// Fire brands don't burn in water.
+	if a.Tile != nil && a.Tile.Feature.Douses() {
+		atk.Effects = atk.Effects.Without(BrandFire)
+	}
 
-	atkroll := combatroll(attacker.Obj()) + atk.Melee
-	defroll := combatroll(defender.Obj()) + def.Evasion
-	residual := atkroll - defroll
+	ev := &CombatEvent{
+		Attacker: a.Spec.Name,
+		Defender: d.Spec.Name,
+		AtkRoll:  combatroll(attacker.Obj()) + atk.Melee + meleemod,
+		DefRoll:  combatroll(defender.Obj()) + def.Evasion,
+	}
+	ev.Residual = ev.AtkRoll - ev.DefRoll
+	defer a.Game.Events.Combat(ev)
 
-	aname, dname := a.Spec.Name, d.Spec.Name
+	aname, dname := actorname(a), actorname(d)
 
-	if residual <= 0 {
+	if ev.Residual <= 0 {
 		msg := fmt.Sprintf("%v missed %v.", aname, dname)
 		a.Game.Events.Message(msg)
 		return
 	}
 
-	crits := residual / (atk.CritDiv + def.Effects.Has(ResistCrit))
+	crits := ev.Residual / (atk.CritDiv + def.Effects.Has(ResistCrit))
+	ev.Crits = crits
 
 	// Calculate raw phys damage.
-	droll, proll := atk.RollDamage(crits), def.RollProt()
-	log.Printf("DR: %d PR: %d", droll, proll)
-	dmg := math.Max(0, droll-proll)
+	ev.DmgRoll, ev.ProtRoll = atk.RollDamage(crits), def.RollProt()
+	ev.BaseDmg = math.Max(0, ev.DmgRoll-ev.ProtRoll)
 
 	// Figure out how much branded damage we did.
-	xdmg, poisondmg := applybs(dmg, atk.Effects, def.Effects)
-	dmg += xdmg
+	applybs(ev, atk.Effects, def.Effects)
+	dmg := ev.BaseDmg + ev.ExtraDmg
+	ev.Damage = dmg
 
 	critstr := ""
 	if crits > 0 {
-		critstr = fmt.Sprintf(" %dx critical!", crits)
+		critstr = " " + mark(StyleCrit, fmt.Sprintf("%dx critical!", crits))
 	}
 
-	msg := fmt.Sprintf("%s %s %s (%d).%s", aname, atk.Verb, dname, dmg, critstr)
+	msg := fmt.Sprintf("%s %s %s (%s).%s", aname, atk.Verb, dname, mark(StyleDamage, fmt.Sprint(dmg)), critstr)
 	a.Game.Events.Message(msg)
 
 	if dmg <= 0 {
@@ -69,13 +89,19 @@ func hit(attacker Fighter, defender Fighter) {
 
 	ispara := d.Sheet.Paralyzed()
 
+	// Adds an effect to the defender, and records that we did so.
+	inflict := func(effect Effect, count int) {
+		d.Ticker.AddEffect(effect, count)
+		ev.Inflicted = append(ev.Inflicted, effect)
+	}
+
 	for effect, _ := range atk.Effects {
 		switch effect {
 		case BrandPoison:
-			d.Ticker.AddEffect(EffectPoison, poisondmg)
+			inflict(EffectPoison, ev.PoisonDmg)
 		case BrandAcid:
 			if OneIn(def.Effects.Resists(effect) + 1) {
-				d.Ticker.AddEffect(EffectShatter, DieRoll(4, 4))
+				inflict(EffectShatter, DieRoll(4, 4))
 			}
 		case EffectStun:
 			score := atk.CritDiv - BaseCritDiv + a.Sheet.Stat(Str)
@@ -83,60 +109,42 @@ func hit(attacker Fighter, defender Fighter) {
 			resists := def.Effects.Resists(effect)
 			won, _ := skillcheck(score, difficulty, resists, a, d)
 			if won {
-				d.Ticker.AddEffect(EffectStun, dmg)
+				inflict(EffectStun, dmg)
 			}
 		case EffectBlind:
 			if savingthrow(d, def.Effects, effect) {
-				d.Ticker.AddEffect(EffectBlind, DieRoll(5, 4))
+				inflict(EffectBlind, DieRoll(5, 4))
 			}
 		case EffectConfuse:
 			// TODO: Eventually remove this check and instead use a Cruel-Blow
 			// style check, cruel blow should be the only ability that gives
 			// confusion melee anyways.
 			if savingthrow(d, def.Effects, effect) {
-				d.Ticker.AddEffect(EffectConfuse, DieRoll(5, 4))
+				inflict(EffectConfuse, DieRoll(5, 4))
 			}
 		case EffectPara:
 			if savingthrow(d, def.Effects, effect) {
-				d.Ticker.AddEffect(EffectPara, DieRoll(4, 4))
+				inflict(EffectPara, DieRoll(4, 4))
 			}
 		case EffectPetrify:
 			if savingthrow(d, def.Effects, effect) {
-				d.Ticker.AddEffect(EffectPetrify, DieRoll(4, 4))
+				inflict(EffectPetrify, DieRoll(4, 4))
 			}
 		case EffectCut:
 			if crits > DieRoll(1, 2) {
-				d.Ticker.AddEffect(EffectCut, dmg/2)
+				inflict(EffectCut, dmg/2)
 			}
 		case EffectShatter:
 			score := atk.CritDiv - BaseCritDiv + a.Sheet.Stat(Str)
 			won, _ := skillcheck(score, 10, 0, a, d)
 			if won {
-				d.Ticker.AddEffect(EffectShatter, DieRoll(4, 4))
-			}
-		case EffectDrainStr:
-			r := def.Effects.Resists(effect)
-			won, _ := skillcheck(a.Sheet.Skill(Chi), d.Sheet.Skill(Chi), r, a, d)
-			if won {
-				d.Ticker.AddEffect(EffectDrainStr, 1)
-			}
-		case EffectDrainAgi:
-			r := def.Effects.Resists(effect)
-			won, _ := skillcheck(a.Sheet.Skill(Chi), d.Sheet.Skill(Chi), r, a, d)
-			if won {
-				d.Ticker.AddEffect(EffectDrainAgi, 1)
+				inflict(EffectShatter, DieRoll(4, 4))
 			}
-		case EffectDrainVit:
+		case EffectDrainStr, EffectDrainAgi, EffectDrainVit, EffectDrainMnd:
 			r := def.Effects.Resists(effect)
 			won, _ := skillcheck(a.Sheet.Skill(Chi), d.Sheet.Skill(Chi), r, a, d)
 			if won {
-				d.Ticker.AddEffect(EffectDrainVit, 1)
-			}
-		case EffectDrainMnd:
-			r := def.Effects.Resists(effect)
-			won, _ := skillcheck(a.Sheet.Skill(Chi), d.Sheet.Skill(Chi), r, a, d)
-			if won {
-				d.Ticker.AddEffect(EffectDrainMnd, 1)
+				inflict(effect, 1)
 			}
 		}
 	}
@@ -144,6 +152,7 @@ func hit(attacker Fighter, defender Fighter) {
 	if ispara {
 		checkpara(defender)
 	}
+	a.Game.blame(d, a.Spec.Name)
 	d.Sheet.Hurt(dmg)
 
 	// Have to handle this outside the effect loop above because we need the
@@ -152,39 +161,86 @@ func hit(attacker Fighter, defender Fighter) {
 		atk.Effects.Has(EffectVamp) > 0 &&
 		def.Effects.Resists(EffectVamp) <= 0 {
 		vamp(a, d)
+		ev.Inflicted = append(ev.Inflicted, EffectVamp)
 	}
 }
 
-// Given the base physical damage done by an attack, and the atk and def
-// effects, this figures out how much extra and poison damage should be done from
-// brands and slays. Poison damage is separated out because it is applied as
-// damage-over-time, instead of being immediately inflicted on the target.
-func applybs(basedmg int, atk Effects, def Effects) (xdmg, poisondmg int) {
+// Given the base physical damage done by a blow, and the atk and def effects,
+// this figures out how much extra and poison damage should be done from brands
+// and slays, and records each of them in 'ev'. Poison damage is separated out
+// because it is applied as damage-over-time, instead of being immediately
+// inflicted on the target.
+func applybs(ev *CombatEvent, atk Effects, def Effects) {
+	basedmg := ev.BaseDmg
 	if basedmg == 0 {
-		return 0, 0
+		return
 	}
 
 	slays, brands := atk.Slays(), atk.Brands()
-	xdmg, poisondmg = 0, 0
 
 	for slay, _ := range slays {
 		if def.SlainBy(slay) <= 0 {
-			return
+			continue
 		}
-		xdmg += DieRoll(1, basedmg)
+		raw := DieRoll(1, basedmg)
+		ev.Extras = append(ev.Extras, ExtraDamage{Effect: slay, Raw: raw, Dealt: raw})
+		ev.ExtraDmg += raw
 	}
 
 	for brand, _ := range brands {
 		raw := DieRoll(1, basedmg)
 		resisted := def.ResistDmg(brand, raw)
+		ev.Extras = append(ev.Extras, ExtraDamage{Effect: brand, Raw: raw, Dealt: resisted})
 
 		if brand == BrandPoison {
-			poisondmg += resisted
+			ev.PoisonDmg += resisted
 		} else {
-			xdmg += resisted
+			ev.ExtraDmg += resisted
 		}
 	}
-	return xdmg, poisondmg
+}
+
+// A full account of a single blow in melee, so that clients can explain exactly
+// how the outcome was worked out.
+type CombatEvent struct {
+	Attacker string
+	Defender string
+	// The attacker's melee roll and the defender's evasion roll, including
+	// their melee and evasion scores.
+	AtkRoll int
+	DefRoll int
+	// AtkRoll - DefRoll. The blow lands if this is positive.
+	Residual int
+	Crits    int
+	// The damage rolled by the attack, including crits, and the protection
+	// rolled against it.
+	DmgRoll  int
+	ProtRoll int
+	// The physical damage that got through protection.
+	BaseDmg int
+	// What each brand and slay contributed, after resistances.
+	Extras []ExtraDamage
+	// The sum of the non-poison Extras.
+	ExtraDmg int
+	// Damage that is dealt over time instead of immediately.
+	PoisonDmg int
+	// All the damage that was inflicted by this blow, not counting poison.
+	Damage int
+	// The effects this blow inflicted on the defender.
+	Inflicted []Effect
+}
+
+// Did this blow land?
+func (ev CombatEvent) Hit() bool {
+	return ev.Residual > 0
+}
+
+// Extra damage done by a single brand or slay.
+type ExtraDamage struct {
+	Effect Effect
+	// Damage before and after the defender's resistances.
+	Raw   int
+	Dealt int
 }
 
 func checkpara(defender Fighter) {
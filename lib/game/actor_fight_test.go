@@ -124,10 +124,12 @@ func TestHit(t *testing.T) {
 
 		g := newTestGame()
 		attacker, defender := g.NewObj(testMonSpec), g.NewObj(testMonSpec)
-		FixRandomDie(test.rolls)
-		defer RestoreRandom()
+		func() {
+			FixRandomDie(test.rolls)
+			defer RestoreRandom()
 
-		attacker.Fighter.Hit(defender.Fighter)
+			attacker.Fighter.Hit(defender.Fighter)
+		}()
 		if hp := defender.Sheet.HP(); hp != test.wanthp {
 			t.Errorf(`Test %d: Defender has %d hp; want %d.`, i, hp, test.wanthp)
 		}
@@ -196,6 +198,27 @@ func TestHitCritResist(t *testing.T) {
 	}
 }
 
+func TestHitRapidAttack(t *testing.T) {
+	g := newTestGame()
+	spec := makeTestHitterSpec(Effects{})
+	rapid := *spec
+	rapid.Traits = &Traits{}
+	*rapid.Traits = *spec.Traits
+	rapid.Traits.Learner = NewActorLearner
+
+	attacker, defender := g.NewObj(&rapid), g.NewObj(spec)
+	attacker.Learner.(*ActorLearner).abilities[AbilityRapidAttack] = true
+
+	// Two blows: melee 5 - 3 vs evasion 1, 2 damage each.
+	FixRandomDie([]int{5, 1, 2, 5, 1, 2})
+	defer RestoreRandom()
+
+	attacker.Fighter.Hit(defender.Fighter)
+	if hp, want := defender.Sheet.HP(), 16; hp != want {
+		t.Errorf(`Defender has %d hp; want %d.`, hp, want)
+	}
+}
+
 func TestHitVamp(t *testing.T) {
 	g := newTestGame()
 	spec := makeTestHitterSpec(NewEffects(map[Effect]int{EffectVamp: 1}))
@@ -499,6 +522,12 @@ func TestApplyBS(t *testing.T) {
 			rolls: []int{5, 5},
 			xdmg:  10,
 		},
+		{
+			atk:   NewEffects(map[Effect]int{fakeSlay1: 1, fakeBrand1: 1}),
+			def:   NewEffects(map[Effect]int{}),
+			rolls: []int{5},
+			xdmg:  5,
+		},
 	}
 
 	// No resist.
@@ -506,7 +535,9 @@ func TestApplyBS(t *testing.T) {
 		func() {
 			FixRandomDie(test.rolls)
 			defer RestoreRandom()
-			if xdmg, _ := applybs(10, test.atk, test.def); xdmg != test.xdmg {
+			ev := &CombatEvent{BaseDmg: 10}
+			applybs(ev, test.atk, test.def)
+			if xdmg := ev.ExtraDmg; xdmg != test.xdmg {
 				t.Errorf(`Test %d: got %d, want %d`, i, xdmg, test.xdmg)
 			}
 		}()
@@ -514,16 +545,105 @@ func TestApplyBS(t *testing.T) {
 }
 
 func TestApplyBrandPoison(t *testing.T) {
+	tests := []struct {
+		atk, def        Effects
+		xdmg, poisondmg int
+	}{
+		{NewEffects(map[Effect]int{BrandPoison: 1}), NewEffects(map[Effect]int{}), 0, 5},
+		{NewEffects(map[Effect]int{BrandPoison: 1}), NewEffects(map[Effect]int{ResistPoison: 1}), 0, 2},
+		{NewEffects(map[Effect]int{BrandPoison: 1, BrandFire: 1}), NewEffects(map[Effect]int{}), 5, 5},
+	}
+
 	FixRandomDie([]int{5, 5, 5, 5})
 	defer RestoreRandom()
 
-	if xdmg, poisondmg := applybs(10, NewEffects(map[Effect]int{BrandPoison: 1}), NewEffects(map[Effect]int{})); xdmg != 0 || poisondmg != 5 {
-		t.Errorf(`applybs poisondmg: got (%d, %d) want (0, 5)`, xdmg, poisondmg)
+	for i, test := range tests {
+		ev := &CombatEvent{BaseDmg: 10}
+		applybs(ev, test.atk, test.def)
+		if ev.ExtraDmg != test.xdmg || ev.PoisonDmg != test.poisondmg {
+			t.Errorf(`Test %d: applybs got (%d, %d) want (%d, %d)`, i, ev.ExtraDmg, ev.PoisonDmg, test.xdmg, test.poisondmg)
+		}
+	}
+}
+
+func TestWaterDousesFireBrand(t *testing.T) {
+	testMonSpec := makeTestHitterSpec(NewEffects(map[Effect]int{BrandFire: 1}))
+	g := newTestGame()
+	attacker, defender := g.NewObj(testMonSpec), g.NewObj(testMonSpec)
+	g.Level.At(math.Pt(1, 1)).Feature = FeatShallowWater
+	g.Level.Place(attacker, math.Pt(1, 1))
+
+	// Roll 5 damage, with no fire to roll for.
+	FixRandomDie([]int{7, 1, 5})
+	defer RestoreRandom()
+
+	attacker.Fighter.Hit(defender.Fighter)
+
+	if hp := defender.Sheet.HP(); hp != 15 {
+		t.Errorf(`Defender has %d hp; want 15`, hp)
+	}
+	if ev := nextCombatEvent(g); len(ev.Extras) != 0 {
+		t.Errorf(`Blow from the water did extra damage: %+v`, ev.Extras)
+	}
+}
+
+// Pulls the first CombatEvent out of the game's event queue.
+func nextCombatEvent(g *Game) *CombatEvent {
+	for !g.Events.Empty() {
+		if ev, ok := g.Events.Next().(CombatEvent); ok {
+			return &ev
+		}
+	}
+	return nil
+}
+
+func TestHitCombatEvent(t *testing.T) {
+	testMonSpec := makeTestHitterSpec(NewEffects(map[Effect]int{BrandFire: 1, EffectStun: 1}))
+	g := newTestGame()
+	attacker, defender := g.NewObj(testMonSpec), g.NewObj(testMonSpec)
+
+	// Residual 11 is 1 crit; roll 3 + 2 damage, then 4 fire, then win the stun
+	// skillroll.
+	FixRandomDie([]int{12, 1, 3, 2, 4, 10, 0})
+	defer RestoreRandom()
+
+	attacker.Fighter.Hit(defender.Fighter)
+	ev := nextCombatEvent(g)
+	if ev == nil {
+		t.Fatal(`Hit did not send a CombatEvent`)
+	}
+
+	if !ev.Hit() || ev.Residual != 11 || ev.Crits != 1 {
+		t.Errorf(`Got residual %d with %d crits, want 11 with 1`, ev.Residual, ev.Crits)
+	}
+	if ev.BaseDmg != 5 || ev.ExtraDmg != 4 || ev.Damage != 9 {
+		t.Errorf(`Got %d base + %d extra = %d, want 5 + 4 = 9`, ev.BaseDmg, ev.ExtraDmg, ev.Damage)
+	}
+	if len(ev.Extras) != 1 || ev.Extras[0].Effect != BrandFire {
+		t.Errorf(`ev.Extras was %+v, want only fire`, ev.Extras)
+	}
+	if len(ev.Inflicted) != 1 || ev.Inflicted[0] != EffectStun {
+		t.Errorf(`ev.Inflicted was %v, want only stun`, ev.Inflicted)
+	}
+	if hp, want := defender.Sheet.HP(), 20-ev.Damage; hp != want {
+		t.Errorf(`Defender has %d hp; want %d.`, hp, want)
 	}
-	if xdmg, poisondmg := applybs(10, NewEffects(map[Effect]int{BrandPoison: 1}), NewEffects(map[Effect]int{ResistPoison: 1})); xdmg != 0 || poisondmg != 2 {
-		t.Errorf(`applybs poisondmg: got (%d, %d) want (0, 2)`, xdmg, poisondmg)
+}
+
+func TestMissCombatEvent(t *testing.T) {
+	testMonSpec := makeTestHitterSpec(Effects{})
+	g := newTestGame()
+	attacker, defender := g.NewObj(testMonSpec), g.NewObj(testMonSpec)
+
+	FixRandomDie([]int{1, 1})
+	defer RestoreRandom()
+
+	attacker.Fighter.Hit(defender.Fighter)
+	ev := nextCombatEvent(g)
+	if ev == nil {
+		t.Fatal(`Hit did not send a CombatEvent`)
 	}
-	if xdmg, poisondmg := applybs(10, NewEffects(map[Effect]int{BrandPoison: 1, BrandFire: 1}), NewEffects(map[Effect]int{})); xdmg != 5 || poisondmg != 5 {
-		t.Errorf(`applybs poisondmg: got (%d, %d) want (5, 5)`, xdmg, poisondmg)
+	if ev.Hit() || ev.Damage != 0 {
+		t.Errorf(`Miss was described as a hit for %d`, ev.Damage)
 	}
 }
@@ -19,6 +19,8 @@ type XPGainer interface {
 	XP() int
 	// How much have they accumulated in total?
 	TotalXP() int
+	// How many of each species of monster has this actor killed?
+	Kills() map[Species]int
 }
 
 // Something that can "spend" XP to increase skill points.
@@ -50,10 +52,32 @@ type SkillLearner interface {
 	EndLearning() error
 }
 
+// Something that can "spend" XP to learn abilities. Abilities are bought as
+// part of the same change as skills, so BeginLearning must be called first,
+// and CancelLearning / EndLearning will revert or keep them.
+type AbilityLearner interface {
+	// Does this actor know ability 'a'? Abilities bought in the current change
+	// count as known.
+	HasAbility(a AbilityName) bool
+	// Could ability 'a' be bought right now? Returns nil if so, otherwise
+	// ErrAbilityKnown, ErrAbilityLocked or ErrNotEnoughXP. This doesn't need
+	// BeginLearning to have been called.
+	CanLearnAbility(a AbilityName) error
+	// Buy ability 'a'. Returns ErrAbilityLocked if its skill level or other
+	// required abilities haven't been reached, and ErrNotEnoughXP if it costs
+	// too much.
+	LearnAbility(a AbilityName) (*SkillChange, error)
+	// Refund ability 'a'. Only abilities bought in the current change can be
+	// refunded; otherwise returns ErrAbilityNotLearned. Returns
+	// ErrAbilityRequired if another ability in the change depends on it.
+	UnlearnAbility(a AbilityName) (*SkillChange, error)
+}
+
 // Aggregate learner interface.
 type Learner interface {
 	XPGainer
 	SkillLearner
+	AbilityLearner
 }
 
 // Used by the player to track what they have seen, and how much XP they have.
@@ -64,6 +88,8 @@ type ActorLearner struct {
 	xp      int
 	totalxp int
 	change  *SkillChange
+	// Abilities this actor knows.
+	abilities map[AbilityName]bool
 }
 
 // Stores the state of a player's current request to upgrade their skills by
@@ -71,6 +97,8 @@ type ActorLearner struct {
 type SkillChange struct {
 	TotalCost int
 	Changes   map[SkillName]SkillChangeItem
+	// Abilities bought during this change.
+	Abilities map[AbilityName]bool
 }
 
 type SkillChangeItem struct {
@@ -89,9 +117,10 @@ var (
 // don't gain or spend XP.
 func NewActorLearner(obj *Obj) Learner {
 	return &ActorLearner{
-		Trait:  Trait{obj: obj},
-		seen:   map[Species]int{},
-		killed: map[Species]int{},
+		Trait:     Trait{obj: obj},
+		seen:      map[Species]int{},
+		killed:    map[Species]int{},
+		abilities: map[AbilityName]bool{},
 	}
 }
 
@@ -103,9 +132,17 @@ func (l *ActorLearner) TotalXP() int {
 	return l.totalxp
 }
 
+func (l *ActorLearner) Kills() map[Species]int {
+	kills := make(map[Species]int, len(l.killed))
+	for s, n := range l.killed {
+		kills[s] = n
+	}
+	return kills
+}
+
 func (l *ActorLearner) GainXPKill(mon *Obj) {
 	if genus := mon.Spec.Genus; genus != GenMonster {
-		panic(fmt.Sprintf("Obj *v with genus %v is not monster.", mon, genus))
+		panic(fmt.Sprintf("Obj %v with genus %v is not monster.", mon, genus))
 	}
 
 	s := mon.Spec.Species
@@ -129,7 +166,7 @@ func (l *ActorLearner) GainXPSight(obj *Obj) {
 	case GenEquipment, GenConsumable:
 		xp = itemxp(obj, n)
 	default:
-		panic(fmt.Sprintf("Obj *v with genus %v is not xpable on sight.", obj, genus))
+		panic(fmt.Sprintf("Obj %v with genus %v is not xpable on sight.", obj, genus))
 	}
 
 	l.gainxp(xp)
@@ -144,7 +181,10 @@ func (l *ActorLearner) BeginLearning() (*SkillChange, error) {
 	if l.change != nil {
 		return l.change, ErrAlreadyLearning
 	}
-	l.change = &SkillChange{Changes: map[SkillName]SkillChangeItem{}}
+	l.change = &SkillChange{
+		Changes:   map[SkillName]SkillChangeItem{},
+		Abilities: map[AbilityName]bool{},
+	}
 	return l.change, nil
 }
 
@@ -192,6 +232,14 @@ func (l *ActorLearner) UnlearnSkill(sk SkillName) (*SkillChange, error) {
 	currskill := l.obj.Sheet.UnmodSkill(sk)
 	gain := skillxp(currskill)
 
+	// Don't let the player sneak an ability in and then take back the points
+	// that unlocked it.
+	for a := range l.change.Abilities {
+		if ab := Abilities[a]; ab.Skill == sk && ab.Level > currskill-1 {
+			return l.change, ErrSkillRequired
+		}
+	}
+
 	schange.Points -= 1
 	schange.Cost -= gain
 	l.change.Changes[sk] = schange
@@ -215,6 +263,9 @@ func (l *ActorLearner) CancelLearning() error {
 		curr := l.obj.Sheet.UnmodSkill(sk)
 		l.obj.Sheet.SetSkill(sk, curr-change.Points)
 	}
+	for a := range l.change.Abilities {
+		delete(l.abilities, a)
+	}
 	l.xp += l.change.TotalCost
 
 	l.change = nil
@@ -229,6 +280,74 @@ func (l *ActorLearner) EndLearning() error {
 	return nil
 }
 
+func (l *ActorLearner) HasAbility(a AbilityName) bool {
+	return l.abilities[a]
+}
+
+func (l *ActorLearner) CanLearnAbility(a AbilityName) error {
+	if l.abilities[a] {
+		return ErrAbilityKnown
+	}
+
+	ability := Abilities[a]
+	if l.obj.Sheet.UnmodSkill(ability.Skill) < ability.Level {
+		return ErrAbilityLocked
+	}
+	for _, req := range ability.Requires {
+		if !l.abilities[req] {
+			return ErrAbilityLocked
+		}
+	}
+	if ability.Cost > l.XP() {
+		return ErrNotEnoughXP
+	}
+	return nil
+}
+
+func (l *ActorLearner) LearnAbility(a AbilityName) (*SkillChange, error) {
+	if l.change == nil {
+		return nil, ErrNotLearning
+	}
+	if err := l.CanLearnAbility(a); err != nil {
+		return l.change, err
+	}
+
+	ability := Abilities[a]
+	l.change.Abilities[a] = true
+	l.change.TotalCost += ability.Cost
+
+	l.xp -= ability.Cost
+	l.abilities[a] = true
+
+	return l.change, nil
+}
+
+func (l *ActorLearner) UnlearnAbility(a AbilityName) (*SkillChange, error) {
+	if l.change == nil {
+		return nil, ErrNotLearning
+	}
+	if !l.change.Abilities[a] {
+		return l.change, ErrAbilityNotLearned
+	}
+	for other := range l.change.Abilities {
+		for _, req := range Abilities[other].Requires {
+			if req == a {
+				return l.change, ErrAbilityRequired
+			}
+		}
+	}
+
+	cost := Abilities[a].Cost
+
+	delete(l.change.Abilities, a)
+	l.change.TotalCost -= cost
+
+	l.xp += cost
+	delete(l.abilities, a)
+
+	return l.change, nil
+}
+
 // Gain xp, updating both xp and totalxp.
 func (l *ActorLearner) gainxp(xp int) {
 	l.xp += xp
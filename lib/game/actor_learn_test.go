@@ -157,9 +157,9 @@ func TestLearningScenario(t *testing.T) {
 	s.SetSkill(Melee, 1)
 	s.SetSkill(Evasion, 2)
 	s.SetSkill(Shooting, 3)
-	s.ChangeSkillMod(Melee, 1)
-	s.ChangeSkillMod(Evasion, 2)
-	s.ChangeSkillMod(Shooting, 3)
+	s.ChangeSkillMod(Melee, SourceStim, 1)
+	s.ChangeSkillMod(Evasion, SourceStim, 2)
+	s.ChangeSkillMod(Shooting, SourceStim, 3)
 
 	// Give the player some initial XP to spend.
 	l.(*ActorLearner).gainxp(5000)
@@ -319,3 +319,147 @@ func TestCantOverRefundXP(t *testing.T) {
 		t.Errorf(`c.Changes[Melee] was %+v, want %+v`, change, want)
 	}
 }
+
+func TestLearnAbility(t *testing.T) {
+	g := newTestGame()
+	l, s := g.Player.Learner, g.Player.Sheet
+	s.SetSkill(Melee, 2)
+	l.(*ActorLearner).gainxp(5000)
+
+	l.BeginLearning()
+	c, err := l.LearnAbility(AbilityPower)
+
+	if err != nil {
+		t.Errorf(`LearnAbility(Power) returned %v, want nil`, err)
+	}
+	if !l.HasAbility(AbilityPower) {
+		t.Error(`HasAbility(Power) was false after learning it`)
+	}
+	if cost, want := c.TotalCost, Abilities[AbilityPower].Cost; cost != want {
+		t.Errorf(`c.TotalCost was %d, want %d`, cost, want)
+	}
+	if xp, want := l.XP(), 5000-Abilities[AbilityPower].Cost; xp != want {
+		t.Errorf(`l.XP() was %d, want %d`, xp, want)
+	}
+
+	l.EndLearning()
+	if !l.HasAbility(AbilityPower) {
+		t.Error(`HasAbility(Power) was false after ending learning`)
+	}
+
+	l.BeginLearning()
+	if _, err := l.LearnAbility(AbilityPower); err != ErrAbilityKnown {
+		t.Errorf(`LearnAbility(Power) twice returned %v, want %v`, err, ErrAbilityKnown)
+	}
+	if _, err := l.UnlearnAbility(AbilityPower); err != ErrAbilityNotLearned {
+		t.Errorf(`UnlearnAbility(Power) from an earlier change returned %v, want %v`, err, ErrAbilityNotLearned)
+	}
+}
+
+func TestLearnAbilityNeedsSkillLevel(t *testing.T) {
+	g := newTestGame()
+	l, s := g.Player.Learner, g.Player.Sheet
+	s.SetSkill(Melee, 1)
+	l.(*ActorLearner).gainxp(5000)
+
+	l.BeginLearning()
+	if _, err := l.LearnAbility(AbilityPower); err != ErrAbilityLocked {
+		t.Errorf(`LearnAbility(Power) at Melee 1 returned %v, want %v`, err, ErrAbilityLocked)
+	}
+
+	// Learning the skill in the same change unlocks it.
+	l.LearnSkill(Melee)
+	if _, err := l.LearnAbility(AbilityPower); err != nil {
+		t.Errorf(`LearnAbility(Power) after learning Melee 2 returned %v, want nil`, err)
+	}
+
+	// ... but then the skill point can't be refunded.
+	if _, err := l.UnlearnSkill(Melee); err != ErrSkillRequired {
+		t.Errorf(`UnlearnSkill(Melee) under Power returned %v, want %v`, err, ErrSkillRequired)
+	}
+}
+
+func TestLearnAbilityNeedsRequiredAbilities(t *testing.T) {
+	g := newTestGame()
+	l, s := g.Player.Learner, g.Player.Sheet
+	s.SetSkill(Melee, 10)
+	l.(*ActorLearner).gainxp(5000)
+
+	l.BeginLearning()
+	if _, err := l.LearnAbility(AbilityRapidAttack); err != ErrAbilityLocked {
+		t.Errorf(`LearnAbility(RapidAttack) without Power returned %v, want %v`, err, ErrAbilityLocked)
+	}
+
+	l.LearnAbility(AbilityPower)
+	if _, err := l.LearnAbility(AbilityRapidAttack); err != nil {
+		t.Errorf(`LearnAbility(RapidAttack) with Power returned %v, want nil`, err)
+	}
+	if _, err := l.UnlearnAbility(AbilityPower); err != ErrAbilityRequired {
+		t.Errorf(`UnlearnAbility(Power) under RapidAttack returned %v, want %v`, err, ErrAbilityRequired)
+	}
+}
+
+func TestLearnAbilityCantOverspendXP(t *testing.T) {
+	g := newTestGame()
+	l, s := g.Player.Learner, g.Player.Sheet
+	s.SetSkill(Melee, 2)
+	l.(*ActorLearner).gainxp(Abilities[AbilityPower].Cost - 1)
+
+	l.BeginLearning()
+	if _, err := l.LearnAbility(AbilityPower); err != ErrNotEnoughXP {
+		t.Errorf(`LearnAbility(Power) without XP returned %v, want %v`, err, ErrNotEnoughXP)
+	}
+	if l.HasAbility(AbilityPower) {
+		t.Error(`HasAbility(Power) was true after failing to learn it`)
+	}
+}
+
+func TestCancelLearningRevertsAbilities(t *testing.T) {
+	g := newTestGame()
+	l, s := g.Player.Learner, g.Player.Sheet
+	s.SetSkill(Evasion, 2)
+	l.(*ActorLearner).gainxp(5000)
+
+	l.BeginLearning()
+	l.LearnAbility(AbilityDodging)
+	l.CancelLearning()
+
+	if l.HasAbility(AbilityDodging) {
+		t.Error(`HasAbility(Dodging) was true after cancelling`)
+	}
+	if xp := l.XP(); xp != 5000 {
+		t.Errorf(`l.XP() after cancelling was %d, want 5000`, xp)
+	}
+}
+
+func TestUnlearnAbilityRefundsXP(t *testing.T) {
+	g := newTestGame()
+	l, s := g.Player.Learner, g.Player.Sheet
+	s.SetSkill(Evasion, 2)
+	l.(*ActorLearner).gainxp(5000)
+
+	l.BeginLearning()
+	l.LearnAbility(AbilityDodging)
+	c, err := l.UnlearnAbility(AbilityDodging)
+
+	if err != nil {
+		t.Errorf(`UnlearnAbility(Dodging) returned %v, want nil`, err)
+	}
+	if l.HasAbility(AbilityDodging) {
+		t.Error(`HasAbility(Dodging) was true after unlearning`)
+	}
+	if c.TotalCost != 0 {
+		t.Errorf(`c.TotalCost was %d, want 0`, c.TotalCost)
+	}
+	if xp := l.XP(); xp != 5000 {
+		t.Errorf(`l.XP() after unlearning was %d, want 5000`, xp)
+	}
+}
+
+func TestEveryAbilityIsSpecified(t *testing.T) {
+	for a := AbilityName(0); a < NumAbilities; a++ {
+		if Abilities[a] == nil {
+			t.Errorf(`Ability %d has no entry in Abilities`, a)
+		}
+	}
+}
@@ -13,6 +13,7 @@ type Mover interface {
 	Rest()
 	Ascend() bool
 	Descend() bool
+	Disarm(dir math.Point) bool
 }
 
 // A universally-applicable mover for actors.
@@ -99,16 +100,47 @@ func (p *ActorMover) Move(dir math.Point) (bool, error) {
 		endtile.Feature = FeatOpenDoor
 		return true, ErrMoveOpenedDoor
 	}
+	if endtile.Feature == FeatSecretDoor && obj.Senser != nil && obj.Senser.Knows(endtile) {
+		if endtile.Visible {
+			obj.Game.Events.Message(fmt.Sprintf("%s opens a SECRET DOOR.", actorname(obj)))
+		}
+		endtile.Feature = FeatOpenDoor
+		return true, ErrMoveOpenedDoor
+	}
+
+	if endtile.Feature.Hazard == HazardDeepWater && obj.Spec.Sinks {
+		return conf || false, ErrMoveBlocked
+	}
 
 	moved := obj.Level.Place(obj, endpos)
+	if moved && endtile.Feature.Trap != nil {
+		springtrap(obj, endtile)
+		// The trap may have moved us somewhere else entirely.
+		if obj.Tile != endtile {
+			return true, nil
+		}
+	}
+	if moved && endtile.Feature.Hazard != HazardNone {
+		endure(obj, endtile)
+		if obj.Tile != endtile {
+			return true, nil
+		}
+	}
 	if moved {
+		// Wading through things takes longer than walking.
+		if cost := endtile.Feature.MoveCost; cost > 1 {
+			obj.Level.scheduler.Delay(obj, (cost-1)*GetDelay(obj.Sheet.Speed()))
+		}
+		if cleared := endtile.Feature.Cleared; cleared != nil {
+			endtile.Feature = cleared
+		}
 		if items := endtile.Items; !items.Empty() && obj.IsPlayer() && !obj.Sheet.Blind() {
 			var msg string
-			topname, n := items.Top().Spec.Name, items.Len()
+			topname, n := itemname(items.Top()), items.Len()
 			if n == 1 {
-				msg = fmt.Sprintf("%v sees %v here.", obj.Spec.Name, topname)
+				msg = fmt.Sprintf("%v sees %v here.", actorname(obj), topname)
 			} else {
-				msg = fmt.Sprintf("%v sees %v and %d other items here.", obj.Spec.Name, topname, n-1)
+				msg = fmt.Sprintf("%v sees %v and %d other items here.", actorname(obj), topname, n-1)
 			}
 			obj.Game.Events.Message(msg)
 		}
@@ -103,3 +103,57 @@ func TestMoveOpensClosedDoor(t *testing.T) {
 		t.Errorf(`Door didn't open; got feature %#v, want %#v`, feat, FeatOpenDoor)
 	}
 }
+
+func TestMoveThroughSecretDoor(t *testing.T) {
+	g := newRunTestGame(`
+#####
+#@S #
+#####`)
+	doorpos := math.Pt(2, 1)
+	orc := g.NewObj(Monsters[0])
+	g.Level.Place(orc, math.Pt(3, 1))
+
+	// Nobody knows about the door yet, so it's just a wall.
+	if _, err := g.Player.Mover.Move(math.Pt(1, 0)); err != ErrMoveBlocked {
+		t.Errorf(`Player's move into secret door was %v, want %v`, err, ErrMoveBlocked)
+	}
+	if _, err := orc.Mover.Move(math.Pt(-1, 0)); err != ErrMoveBlocked {
+		t.Errorf(`Orc's move into unknown secret door was %v, want %v`, err, ErrMoveBlocked)
+	}
+
+	orc.Senser.Learn(g.Level.At(doorpos))
+	if _, err := orc.Mover.Move(math.Pt(-1, 0)); err != ErrMoveOpenedDoor {
+		t.Errorf(`Orc's move into known secret door was %v, want %v`, err, ErrMoveOpenedDoor)
+	}
+	if feat := g.Level.At(doorpos).Feature; feat != FeatOpenDoor {
+		t.Errorf(`Secret door didn't open; got feature %v, want %v`, feat, FeatOpenDoor)
+	}
+}
+
+func TestMoveOntoItemsMarksItemName(t *testing.T) {
+	g := newRunTestGame(`
+####
+#@ #
+####`)
+	item := g.NewObj(lTestItem)
+	g.Level.Place(item, math.Pt(2, 1))
+	for !g.Events.Empty() {
+		g.Events.Next()
+	}
+
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	want := Span{Text: item.Describe(), Style: StyleItem}
+	for !g.Events.Empty() {
+		ev, ok := g.Events.Next().(MessageEvent)
+		if !ok {
+			continue
+		}
+		for _, span := range ev.Spans() {
+			if span == want {
+				return
+			}
+		}
+	}
+	t.Errorf(`Moving onto %v didn't mark it as an item`, item.Describe())
+}
@@ -11,14 +11,15 @@ type Packer interface {
 	// will invoke stack menu. Returns true if a turn should pass because the
 	// player picked up a single item below them without switching modes.
 	TryPickup() bool
-	// Pickup the item on the floor stack at given index. Return true if a turn
-	// should pass.
-	Pickup(index int) bool
+	// Pickup n of the item on the floor stack at given index. If n is 0, the
+	// whole stack is picked up. Return true if a turn should pass.
+	Pickup(index, n int) bool
 	// Tries to drop something at current square.
 	TryDrop()
-	// Drop the item at index in inventory to the floor stack. Return true if
-	// an action was taken that requires a turn to pass.
-	Drop(index int) bool
+	// Drop n of the item at index in inventory to the floor stack. If n is 0,
+	// the whole stack is dropped. Return true if an action was taken that
+	// requires a turn to pass.
+	Drop(index, n int) bool
 	// Get this Packer's inventory.
 	Inventory() *Inventory
 }
@@ -46,16 +47,16 @@ func (a *ActorPacker) TryPickup() bool {
 	if ground.Empty() {
 		a.obj.Game.Events.Message("Nothing there.")
 	} else if ground.Len() == 1 {
-		evolve = a.moveFromGround(0)
+		evolve = a.moveFromGround(0, 0)
 	} else {
 		a.obj.Game.SwitchMode(ModePickup)
 	}
 	return evolve
 }
 
-func (a *ActorPacker) Pickup(index int) bool {
+func (a *ActorPacker) Pickup(index, n int) bool {
 	a.obj.Game.SwitchMode(ModeHud)
-	return a.moveFromGround(index)
+	return a.moveFromGround(index, n)
 }
 
 func (a *ActorPacker) TryDrop() {
@@ -73,9 +74,9 @@ func (a *ActorPacker) TryDrop() {
 }
 
 // Returns false if no actual action was taken.
-func (a *ActorPacker) Drop(index int) bool {
+func (a *ActorPacker) Drop(index, n int) bool {
 	a.obj.Game.SwitchMode(ModeHud)
-	item := a.inventory.Take(index)
+	item := a.inventory.TakeN(index, n)
 
 	// Bounds-check the index the player requested.
 	if item == nil {
@@ -83,25 +84,26 @@ func (a *ActorPacker) Drop(index int) bool {
 	}
 
 	a.obj.Tile.Items.Add(item)
-	a.obj.Game.Events.Message(fmt.Sprintf("%v dropped %v.", a.obj.Spec.Name, item.Spec.Name))
+	a.obj.Game.Events.Message(fmt.Sprintf("%v dropped %v.", a.obj.Spec.Name, itemname(item)))
 
 	return true
 }
 
-func (a *ActorPacker) moveFromGround(index int) bool {
+func (a *ActorPacker) moveFromGround(index, n int) bool {
 	// Bounds-check the index the player requested.
 	item := a.obj.Tile.Items.At(index)
 	if item == nil {
 		return false
 	}
 
-	if a.inventory.Full() {
-		a.obj.Game.Events.Message(fmt.Sprintf("%v has no room for %v.", a.obj.Spec.Name, item.Spec.Name))
+	// If this will merge into an existing stack, we don't need a free slot.
+	if a.inventory.Full() && a.inventory.stackFor(item) == nil {
+		a.obj.Game.Events.Message(fmt.Sprintf("%v has no room for %v.", a.obj.Spec.Name, itemname(item)))
 		return false
 	}
 
-	item = a.obj.Tile.Items.Take(index)
+	item = a.obj.Tile.Items.TakeN(index, n)
 	a.inventory.Add(item)
-	a.obj.Game.Events.Message(fmt.Sprintf("%v got %v.", a.obj.Spec.Name, item.Spec.Name))
+	a.obj.Game.Events.Message(fmt.Sprintf("%v got %v.", a.obj.Spec.Name, itemname(item)))
 	return true
 }
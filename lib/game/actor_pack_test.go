@@ -73,7 +73,7 @@ func TestPickupOutOfBounds(t *testing.T) {
 	g.Level.Place(item, math.Pt(1, 1))
 
 	taker.Packer.TryPickup()
-	taker.Packer.Pickup(5)
+	taker.Packer.Pickup(5, 0)
 
 	if mode := g.mode; mode != ModeHud {
 		t.Errorf(`Out-of-bounds Pickup switched to mode %v; want %v`, mode, ModeHud)
@@ -132,7 +132,7 @@ func TestDrop(t *testing.T) {
 	packer.Packer.Inventory().Add(item)
 
 	packer.Packer.TryDrop()
-	packer.Packer.Drop(0)
+	packer.Packer.Drop(0, 0)
 
 	if mode := g.mode; mode != ModeHud {
 		t.Errorf(`Dropping switched mode to %v, want %v`, mode, ModeHud)
@@ -153,9 +153,47 @@ func TestDropOutOfBounds(t *testing.T) {
 	packer.Packer.Inventory().Add(item)
 
 	packer.Packer.TryDrop()
-	packer.Packer.Drop(5)
+	packer.Packer.Drop(5, 0)
 
 	if mode := g.mode; mode != ModeHud {
 		t.Errorf(`Dropping switched mode to %v, want %v`, mode, ModeHud)
 	}
 }
+
+func TestDropPartialStack(t *testing.T) {
+	g := newTestGame()
+
+	packer := g.NewObj(atActorSpec)
+	g.Level.Place(packer, math.Pt(1, 1))
+
+	stack := g.NewObj(atConsumeSpec)
+	stack.Consumable.Count = 4
+	packer.Packer.Inventory().Add(stack)
+
+	packer.Packer.Drop(0, 3)
+
+	if count := packer.Packer.Inventory().At(0).Count(); count != 1 {
+		t.Errorf(`Dropping 3 of 4 left %d in pack, want 1`, count)
+	}
+	if count := packer.Tile.Items.Top().Count(); count != 3 {
+		t.Errorf(`Dropping 3 of 4 put %d on floor, want 3`, count)
+	}
+}
+
+func TestPickupMergesIntoStack(t *testing.T) {
+	g := newTestGame()
+	taker := g.NewObj(atActorSpec)
+	g.Level.Place(taker, math.Pt(1, 1))
+
+	taker.Packer.Inventory().Add(g.NewObj(atConsumeSpec))
+	g.Level.Place(g.NewObj(atConsumeSpec), math.Pt(1, 1))
+
+	taker.Packer.TryPickup()
+
+	if size := taker.Packer.Inventory().Len(); size != 1 {
+		t.Errorf(`Picking up a matching consumable gave %d stacks, want 1`, size)
+	}
+	if count := taker.Packer.Inventory().Top().Count(); count != 2 {
+		t.Errorf(`Picking up a matching consumable gave stack of %d, want 2`, count)
+	}
+}
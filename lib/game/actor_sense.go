@@ -1,6 +1,7 @@
 package game
 
 import (
+	"fmt"
 	"github.com/MichaelDiBernardo/srl/lib/math"
 )
 
@@ -10,6 +11,18 @@ const (
 	ScentRadius  = FOVRadius
 )
 
+const (
+	// How far away a search can find things.
+	SearchRadius = 2
+	// How much easier it is to find things when you take a turn to search.
+	SearchBonus = 5
+	// How far away things can be noticed without searching for them.
+	NoticeRadius = 1
+	// How hard a secret door is to find. Like traps, they get one harder for
+	// every floor.
+	secretDoorDifficulty = 4
+)
+
 type Field []math.Point
 
 // Senses all the things an actor can sense.
@@ -18,11 +31,17 @@ type Senser interface {
 	CalcFields()
 	FOV() Field
 	CanSee(other *Obj) bool
+	Search(radius, bonus int) bool
+	// Does this actor know about the hidden thing on 't'?
+	Knows(t *Tile) bool
+	Learn(t *Tile)
 }
 
 type ActorSenser struct {
 	Trait
 	fov Field
+	// Hidden things that this actor knows about without having found them.
+	known map[*Tile]bool
 }
 
 func NewActorSenser(obj *Obj) Senser {
@@ -42,6 +61,9 @@ func (a *ActorSenser) CalcFields() {
 	sightrad, scentrad := obj.Sheet.Sight(), 0
 	if player {
 		scentrad = ScentRadius
+		if hasability(obj, AbilityLightStep) {
+			scentrad /= 2
+		}
 	}
 
 	// Calculate field.
@@ -66,6 +88,51 @@ func (a *ActorSenser) FOV() Field {
 	return a.fov
 }
 
+// Looks for hidden traps and secret doors within 'radius' that are in view,
+// rolling Sense plus 'bonus' against each. Returns true if anything was found.
+func (a *ActorSenser) Search(radius, bonus int) bool {
+	obj, found := a.obj, false
+	l, g := obj.Level, obj.Game
+	pos := obj.Pos()
+	for y := pos.Y - radius; y <= pos.Y+radius; y++ {
+		for x := pos.X - radius; x <= pos.X+radius; x++ {
+			pt := math.Pt(x, y)
+			if !pt.In(l) {
+				continue
+			}
+			tile := l.At(pt)
+			if !tile.Visible || !tile.Feature.Hidden() {
+				continue
+			}
+
+			name, difficulty := "SECRET DOOR", secretDoorDifficulty+g.Progress.Floor
+			if trap := tile.Feature.Trap; trap != nil {
+				name, difficulty = trap.Name, trap.difficulty(g)
+			}
+			if won, _ := skillcheck(obj.Sheet.Skill(Sense)+bonus, difficulty, 0, obj, nil); won {
+				reveal(tile)
+				g.Events.Message(fmt.Sprintf("%s finds a %s.", actorname(obj), name))
+				found = true
+			}
+		}
+	}
+	if found && obj.IsPlayer() {
+		g.Disturb()
+	}
+	return found
+}
+
+func (a *ActorSenser) Knows(t *Tile) bool {
+	return a.known[t]
+}
+
+func (a *ActorSenser) Learn(t *Tile) {
+	if a.known == nil {
+		a.known = map[*Tile]bool{}
+	}
+	a.known[t] = true
+}
+
 func (a *ActorSenser) CanSee(other *Obj) bool {
 	pos := other.Pos()
 	for _, pt := range a.fov {
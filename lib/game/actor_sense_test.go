@@ -0,0 +1,52 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+func TestNoticingSecretDoors(t *testing.T) {
+	g := newRunTestGame(`
+#####
+#@  #
+#S#S#
+#####`)
+	near, far := g.Level.At(math.Pt(1, 2)), g.Level.At(math.Pt(3, 2))
+
+	// The player's roll to notice the door beats the door's.
+	FixRandomDie([]int{10, 1})
+	defer RestoreRandom()
+	g.Handle(RestCommand{})
+
+	if near.Feature != FeatClosedDoor {
+		t.Errorf(`Secret door next to player was %v after a turn; want %v`, near.Feature, FeatClosedDoor)
+	}
+	if far.Feature != FeatSecretDoor {
+		t.Errorf(`Secret door out of reach was %v after a turn; want it still secret`, far.Feature)
+	}
+}
+
+func TestSearchFindsSecretDoors(t *testing.T) {
+	g := newRunTestGame(`
+#####
+#@  #
+#S#S#
+#####`)
+	for _, row := range g.Level.Map {
+		for _, tile := range row {
+			tile.Visible = true
+		}
+	}
+
+	FixRandomDie([]int{10, 1, 10, 1})
+	defer RestoreRandom()
+	if !g.Player.Senser.Search(SearchRadius, SearchBonus) {
+		t.Error(`Search() found nothing`)
+	}
+
+	for _, pt := range []math.Point{math.Pt(1, 2), math.Pt(3, 2)} {
+		if f := g.Level.At(pt).Feature; f != FeatClosedDoor {
+			t.Errorf(`Secret door at %v was %v after search; want %v`, pt, f, FeatClosedDoor)
+		}
+	}
+}
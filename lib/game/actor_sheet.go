@@ -19,8 +19,10 @@ type Sheet interface {
 	UnmodStat(stat StatName) int
 	// Get the mod for statistic 'stat'.
 	StatMod(stat StatName) int
-	// Change the mod for 'stat' by 'diff'.
-	ChangeStatMod(stat StatName, diff int)
+	// Change the mod that 'src' contributes to 'stat' by 'diff'.
+	ChangeStatMod(stat StatName, src ModSource, diff int)
+	// Explain how Stat(stat) was computed.
+	StatBreakdown(stat StatName) Breakdown
 
 	// Get the total value for skill 'skill', including mods.
 	Skill(skill SkillName) int
@@ -31,14 +33,23 @@ type Sheet interface {
 	UnmodSkill(skill SkillName) int
 	// Get the mod for skill 'skill'.
 	SkillMod(skill SkillName) int
-	// Change the mod for skill 'skill'.
-	ChangeSkillMod(skill SkillName, diff int)
+	// Change the mod that 'src' contributes to skill 'skill' by 'diff'.
+	ChangeSkillMod(skill SkillName, src ModSource, diff int)
+	// Explain how Skill(skill) was computed.
+	SkillBreakdown(skill SkillName) Breakdown
 
 	// Get information about this actor's melee attack capability.
 	Attack() Attack
 	// Get information about this actor's defensive capability.
 	Defense() Defense
 
+	// Explain the melee score used by Attack().
+	MeleeBreakdown() Breakdown
+	// Explain the evasion score used by Defense().
+	EvasionBreakdown() Breakdown
+	// Explain where each of the protection dice in Defense() came from.
+	ProtBreakdown() []DiceMod
+
 	// Get this actor's current HP.
 	HP() int
 	// Set this actor's current HP. This will ignore MaxHP constraints.
@@ -69,6 +80,8 @@ type Sheet interface {
 	// Regen factor. Normal healing is 1; 2 is twice as fast, etc.
 	// 0 means no regen.
 	Regen() int
+	// Explain how Regen() was computed.
+	RegenBreakdown() Breakdown
 
 	// How stunned is this actor? See StunLevel definition to see what each
 	// level means.
@@ -110,6 +123,8 @@ type Sheet interface {
 
 	// Sight radius.
 	Sight() int
+	// Explain how Sight() was computed.
+	SightBreakdown() Breakdown
 
 	// Get this actor's current speed.
 	// 1: Slow (0.5x normal)
@@ -117,6 +132,8 @@ type Sheet interface {
 	// 3: Fast (1.5x normal)
 	// 4: Very fast (2.x normal)
 	Speed() int
+	// Explain how Speed() was computed.
+	SpeedBreakdown() Breakdown
 
 	// Can this actor do stuff right now. This is false if they are paralyzed,
 	// asleep, etc.
@@ -147,6 +164,9 @@ type PlayerSheet struct {
 	cursed    bool
 	blessed   bool
 	petrified bool
+
+	// Effects the player was born with, e.g. racial resistances.
+	innate Effects
 }
 
 func NewPlayerSheet(obj *Obj) Sheet {
@@ -167,15 +187,12 @@ func NewPlayerSheet(obj *Obj) Sheet {
 	}
 	ps.hp = ps.MaxHP()
 	ps.mp = ps.MaxMP()
-	ps.initmods()
 	return ps
 }
 
 // For testing.
 func NewPlayerSheetFromSpec(pspec *PlayerSheet) *PlayerSheet {
-	sheet := pspec.Copy()
-	sheet.initmods()
-	return sheet
+	return pspec.Copy()
 }
 
 func (p *PlayerSheet) Copy() *PlayerSheet {
@@ -205,18 +222,7 @@ func (p *PlayerSheet) Stat(stat StatName) int {
 func (p *PlayerSheet) SetStat(stat StatName, amt int) {
 	oldstat := p.Stat(stat)
 	p.stats.set(stat, amt)
-	newstat := p.Stat(stat)
-
-	switch stat {
-	case Agi:
-		modAgiSkills(p, newstat-oldstat)
-	case Vit:
-		scaleHP(p, oldstat, newstat)
-	case Mnd:
-		modMndSkills(p, newstat-oldstat)
-		scaleMP(p, oldstat, newstat)
-	}
-
+	scaleVital(p, stat, oldstat, p.Stat(stat))
 }
 
 func (p *PlayerSheet) UnmodStat(stat StatName) int {
@@ -227,30 +233,44 @@ func (p *PlayerSheet) StatMod(stat StatName) int {
 	return p.stats.mod(stat)
 }
 
-func (p *PlayerSheet) ChangeStatMod(stat StatName, diff int) {
+func (p *PlayerSheet) ChangeStatMod(stat StatName, src ModSource, diff int) {
 	old := p.Stat(stat)
+	p.stats.changemod(stat, src, diff)
+	scaleVital(p, stat, old, p.Stat(stat))
+}
 
-	switch stat {
-	case Agi:
-		modAgiSkills(p, diff)
-	case Vit:
-		scaleHP(p, old, old+diff)
-	case Mnd:
-		modMndSkills(p, diff)
-		scaleMP(p, old, old+diff)
-	}
-	p.stats.changemod(stat, diff)
+func (p *PlayerSheet) StatBreakdown(stat StatName) Breakdown {
+	return p.stats.breakdown(stat)
 }
 
 func (p *PlayerSheet) Skill(skill SkillName) int {
-	s := p.skills.skill(skill)
+	return p.SkillBreakdown(skill).Total()
+}
+
+func (p *PlayerSheet) SkillBreakdown(skill SkillName) Breakdown {
+	b := p.skillmods(skill)
+	p.penalize(skill, &b)
+	return b
+}
+
+// The given skill with the bonus from its governing stat and all of its mods,
+// but without any penalties from the player's condition.
+func (p *PlayerSheet) skillmods(skill SkillName) Breakdown {
+	stat, src := governedby(skill)
+	b := Breakdown{Base: p.skills.unmodskill(skill)}
+	b.add(src, p.Stat(stat))
+	b.addall(p.skills.mods[skill])
+	return b
+}
+
+// Applies penalties from blindness, paralysis etc. to a breakdown of 'skill'.
+func (p *PlayerSheet) penalize(skill SkillName, b *Breakdown) {
 	if p.blind {
-		s = blindpenalty(skill, s)
+		b.penalize(SourceBlind, func(s int) int { return blindpenalty(skill, s) })
 	}
 	if !p.CanAct() {
-		s = parapenalty(skill, s)
+		b.penalize(SourceHelpless, func(s int) int { return parapenalty(skill, s) })
 	}
-	return s
 }
 
 func (p *PlayerSheet) SetSkill(skill SkillName, amt int) {
@@ -262,18 +282,19 @@ func (p *PlayerSheet) UnmodSkill(skill SkillName) int {
 }
 
 func (p *PlayerSheet) SkillMod(skill SkillName) int {
-	return p.skills.mod(skill)
+	return p.skillmods(skill).Total() - p.skills.unmodskill(skill)
 }
 
-func (p *PlayerSheet) ChangeSkillMod(skill SkillName, diff int) {
-	p.skills.changemod(skill, diff)
+func (p *PlayerSheet) ChangeSkillMod(skill SkillName, src ModSource, diff int) {
+	p.skills.changemod(skill, src, diff)
 }
 
 func (p *PlayerSheet) Speed() int {
-	if p.slow {
-		return slowpenalty(p.speed)
-	}
-	return p.speed
+	return p.SpeedBreakdown().Total()
+}
+
+func (p *PlayerSheet) SpeedBreakdown() Breakdown {
+	return speedbreakdown(p, p.speed)
 }
 
 func (p *PlayerSheet) Dead() bool {
@@ -305,20 +326,37 @@ func (p *PlayerSheet) MaxMP() int {
 }
 
 func (p *PlayerSheet) Regen() int {
+	return p.RegenBreakdown().Total()
+}
+
+func (p *PlayerSheet) RegenBreakdown() Breakdown {
+	b := Breakdown{Base: p.regen}
+	if hasability(p.obj, AbilityInnerStrength) {
+		b.add(abilitysource(AbilityInnerStrength), 1)
+	}
 	if p.Petrified() {
-		return 0
+		b.penalize(SourcePetrify, zeropenalty)
 	}
-	return p.regen
+	return b
 }
 
 func (p *PlayerSheet) Sight() int {
+	return p.SightBreakdown().Total()
+}
+
+func (p *PlayerSheet) SightBreakdown() Breakdown {
+	b := Breakdown{Base: p.sight}
+	if hasability(p.obj, AbilityKeenEyes) {
+		b.add(abilitysource(AbilityKeenEyes), 1)
+	}
 	if p.blind {
-		return 0
+		b.penalize(SourceBlind, zeropenalty)
 	}
-	return p.sight
+	return b
 }
 
 func (p *PlayerSheet) Hurt(dmg int) {
+	p.obj.Game.Disturb()
 	hurt(p, dmg)
 }
 
@@ -429,29 +467,32 @@ func (p *PlayerSheet) CanAct() bool {
 }
 
 func (p *PlayerSheet) Attack() Attack {
-	// We use skills.skill instead of Skill because Skill already applies the
-	// blind penalty. We need to avoid it so we can apply the penalty to the
-	// entire quantity.
-	melee := p.obj.Equipper.Body().Melee() + p.skills.skill(Melee)
-	if p.blind {
-		melee = blindpenalty(Melee, melee)
-	}
+	melee := p.MeleeBreakdown().Total()
 
 	weap := p.weapon()
 	equip := weap.Equipment
 
-	str := p.stats.stat(Str)
-	bonusSides := math.Min(math.Abs(str), weap.Equipment.Weight) * math.Sgn(str)
-
 	return Attack{
 		Melee:   melee,
-		Damroll: equip.Damroll.Add(0, bonusSides),
+		Damroll: equip.Damroll.Add(0, p.sidesbreakdown(weap).Total()),
 		CritDiv: equip.Weight + BaseCritDiv,
 		Effects: equip.Effects,
 		Verb:    "hits",
 	}
 }
 
+// Explains the sides added to the damage dice of 'weap'. Strength adds a side
+// per point, up to the weapon's weight.
+func (p *PlayerSheet) sidesbreakdown(weap *Obj) Breakdown {
+	var b Breakdown
+	str := p.stats.stat(Str)
+	b.add(SourceStr, math.Min(math.Abs(str), weap.Equipment.Weight)*math.Sgn(str))
+	if hasability(p.obj, AbilityPower) {
+		b.add(abilitysource(AbilityPower), 1)
+	}
+	return b
+}
+
 func (p *PlayerSheet) weapon() *Obj {
 	weap := p.obj.Equipper.Body().Weapon()
 	if weap != nil {
@@ -478,27 +519,10 @@ func (p *PlayerSheet) fist() *Obj {
 }
 
 func (p *PlayerSheet) Defense() Defense {
-	body := p.obj.Equipper.Body()
-	// We use skills.skill instead of Skill because Skill already applies the
-	// blind penalty. We need to avoid it so we can apply the penalty to the
-	// entire quantity.
-	evasion := body.Evasion() + p.skills.skill(Evasion)
-	if p.blind {
-		evasion = blindpenalty(Evasion, evasion)
-	}
-	if !p.CanAct() {
-		evasion = parapenalty(Evasion, evasion)
-	}
-
-	var dice []Dice
-	effects := body.ArmorEffects()
-
+	effects := p.obj.Equipper.Body().ArmorEffects().Merge(p.innate)
 	if p.Petrified() {
-		dice = []Dice{petrifyProt}
 		cr := NewEffects(map[Effect]int{ResistCrit: petrifyCritResist})
 		effects = effects.Merge(cr)
-	} else {
-		dice = body.ProtDice()
 	}
 
 	corrdice := []Dice{}
@@ -507,16 +531,38 @@ func (p *PlayerSheet) Defense() Defense {
 	}
 
 	return Defense{
-		Evasion:  evasion,
-		ProtDice: dice,
+		Evasion:  p.EvasionBreakdown().Total(),
+		ProtDice: protdice(p.ProtBreakdown()),
 		CorrDice: corrdice,
 		Effects:  effects,
 	}
 }
 
-func (p *PlayerSheet) initmods() {
-	modAgiSkills(p, p.stats.stat(Agi))
-	modMndSkills(p, p.stats.stat(Mnd))
+// Penalties are applied to the entire melee score, not just the skill, which
+// is why we start with skillmods instead of SkillBreakdown.
+func (p *PlayerSheet) MeleeBreakdown() Breakdown {
+	b := p.skillmods(Melee)
+	b.addall(p.obj.Equipper.Body().meleemods())
+	p.penalize(Melee, &b)
+	return b
+}
+
+// Same deal as MeleeBreakdown.
+func (p *PlayerSheet) EvasionBreakdown() Breakdown {
+	b := p.skillmods(Evasion)
+	b.addall(p.obj.Equipper.Body().evasionmods())
+	if hasability(p.obj, AbilityDodging) {
+		b.add(abilitysource(AbilityDodging), 2)
+	}
+	p.penalize(Evasion, &b)
+	return b
+}
+
+func (p *PlayerSheet) ProtBreakdown() []DiceMod {
+	if p.Petrified() {
+		return []DiceMod{{Source: SourcePetrify, Dice: petrifyProt}}
+	}
+	return p.obj.Equipper.Body().protmods()
 }
 
 // A spec for a monster attack.
@@ -625,22 +671,30 @@ func (m *MonsterSheet) StatMod(stat StatName) int {
 	return m.stats.mod(stat)
 }
 
-func (m *MonsterSheet) ChangeStatMod(stat StatName, diff int) {
-	m.stats.changemod(stat, diff)
+func (m *MonsterSheet) ChangeStatMod(stat StatName, src ModSource, diff int) {
+	m.stats.changemod(stat, src, diff)
+}
+
+func (m *MonsterSheet) StatBreakdown(stat StatName) Breakdown {
+	return m.stats.breakdown(stat)
 }
 
 func (m *MonsterSheet) Skill(skill SkillName) int {
+	return m.SkillBreakdown(skill).Total()
+}
+
+func (m *MonsterSheet) SkillBreakdown(skill SkillName) Breakdown {
 	if skill == Melee {
 		panic("Monster Melee must be checked through individual attacks.")
 	}
 	if skill == Evasion {
 		panic("Monster Evasion must be checked through Defense.")
 	}
-	s := m.skills.skill(skill)
+	b := m.skills.breakdown(skill)
 	if !m.CanAct() {
-		s = parapenalty(skill, s)
+		b.penalize(SourceHelpless, func(s int) int { return parapenalty(skill, s) })
 	}
-	return s
+	return b
 }
 
 func (m *MonsterSheet) SetSkill(skill SkillName, amt int) {
@@ -655,15 +709,16 @@ func (m *MonsterSheet) SkillMod(skill SkillName) int {
 	return m.skills.mod(skill)
 }
 
-func (m *MonsterSheet) ChangeSkillMod(skill SkillName, diff int) {
-	m.skills.changemod(skill, diff)
+func (m *MonsterSheet) ChangeSkillMod(skill SkillName, src ModSource, diff int) {
+	m.skills.changemod(skill, src, diff)
 }
 
 func (m *MonsterSheet) Speed() int {
-	if m.Slow() {
-		return slowpenalty(m.speed)
-	}
-	return m.speed
+	return m.SpeedBreakdown().Total()
+}
+
+func (m *MonsterSheet) SpeedBreakdown() Breakdown {
+	return speedbreakdown(m, m.speed)
 }
 
 func (m *MonsterSheet) Dead() bool {
@@ -695,17 +750,27 @@ func (m *MonsterSheet) MaxMP() int {
 }
 
 func (m *MonsterSheet) Regen() int {
+	return m.RegenBreakdown().Total()
+}
+
+func (m *MonsterSheet) RegenBreakdown() Breakdown {
+	b := Breakdown{Base: m.regen}
 	if m.Petrified() {
-		return 0
+		b.penalize(SourcePetrify, zeropenalty)
 	}
-	return m.regen
+	return b
 }
 
 func (m *MonsterSheet) Sight() int {
+	return m.SightBreakdown().Total()
+}
+
+func (m *MonsterSheet) SightBreakdown() Breakdown {
+	b := Breakdown{Base: m.sight}
 	if m.blind {
-		return 0
+		b.penalize(SourceBlind, zeropenalty)
 	}
-	return m.sight
+	return b
 }
 
 func (m *MonsterSheet) Hurt(dmg int) {
@@ -836,11 +901,12 @@ func (m *MonsterSheet) Attack() Attack {
 
 func (m *MonsterSheet) Defense() Defense {
 	def := m.defense
+	def.Evasion = m.EvasionBreakdown().Total()
+	def.ProtDice = protdice(m.ProtBreakdown())
 
 	if m.Petrified() {
 		cr := NewEffects(map[Effect]int{ResistCrit: petrifyCritResist})
 		def.Effects = def.Effects.Merge(cr)
-		def.ProtDice = []Dice{petrifyProt}
 	}
 
 	def.CorrDice = []Dice{}
@@ -851,33 +917,62 @@ func (m *MonsterSheet) Defense() Defense {
 	return def
 }
 
-func modAgiSkills(s Sheet, diff int) {
+// Monster attacks are fixed by their spec, so there's nothing to explain.
+func (m *MonsterSheet) MeleeBreakdown() Breakdown {
+	panic("Monster Melee must be checked through individual attacks.")
+}
+
+func (m *MonsterSheet) EvasionBreakdown() Breakdown {
+	return Breakdown{Base: m.defense.Evasion}
+}
+
+func (m *MonsterSheet) ProtBreakdown() []DiceMod {
+	if m.Petrified() {
+		return []DiceMod{{Source: SourcePetrify, Dice: petrifyProt}}
+	}
+	mods := make([]DiceMod, 0, len(m.defense.ProtDice))
+	for _, dice := range m.defense.ProtDice {
+		mods = append(mods, DiceMod{Source: SourceBase, Dice: dice})
+	}
+	return mods
+}
+
+func modAgiSkills(s Sheet, src ModSource, diff int) {
 	for sk := Melee; sk <= Stealth; sk++ {
-		s.ChangeSkillMod(sk, diff)
+		s.ChangeSkillMod(sk, src, diff)
 	}
 }
 
-func modMndSkills(s Sheet, diff int) {
+func modMndSkills(s Sheet, src ModSource, diff int) {
 	for sk := Chi; sk < NumSkills; sk++ {
-		s.ChangeSkillMod(sk, diff)
+		s.ChangeSkillMod(sk, src, diff)
 	}
 }
 
-func modAllSkills(s Sheet, diff int) {
-	modAgiSkills(s, diff)
-	modMndSkills(s, diff)
+func modAllSkills(s Sheet, src ModSource, diff int) {
+	modAgiSkills(s, src, diff)
+	modMndSkills(s, src, diff)
 }
 
-func modAllStats(s Sheet, diff int) {
+func modAllStats(s Sheet, src ModSource, diff int) {
 	for stat := Str; stat < NumStats; stat++ {
-		s.ChangeStatMod(stat, diff)
+		s.ChangeStatMod(stat, src, diff)
 	}
 }
 
+// The stat that lends its score to 'skill', and the source its bonus is
+// tagged with.
+func governedby(skill SkillName) (StatName, ModSource) {
+	if skill <= Stealth {
+		return Agi, SourceAgi
+	}
+	return Mnd, SourceMnd
+}
+
 // Maintains stats and modifications for a sheet.
 type stats struct {
 	stats statlist
-	mods  statlist
+	mods  [NumStats]modlist
 }
 
 // An array of statistics.
@@ -885,7 +980,7 @@ type statlist [NumStats]int
 
 // Get the given stat combined with any active modifiers to it.
 func (s *stats) stat(stat StatName) int {
-	return s.stats[stat] + s.mods[stat]
+	return s.stats[stat] + s.mods[stat].total()
 }
 
 // Get the unmodified stat.
@@ -900,12 +995,19 @@ func (s *stats) set(stat StatName, amt int) {
 
 // Get the modifier (mod) for this stat.
 func (s *stats) mod(stat StatName) int {
-	return s.mods[stat]
+	return s.mods[stat].total()
+}
+
+// Change the modifier that 'src' contributes to this stat by 'amt'.
+func (s *stats) changemod(stat StatName, src ModSource, amt int) {
+	s.mods[stat] = s.mods[stat].change(src, amt)
 }
 
-// Change a modifier for this stat by 'amt'.
-func (s *stats) changemod(stat StatName, amt int) {
-	s.mods[stat] += amt
+// Explain how the given stat was computed.
+func (s *stats) breakdown(stat StatName) Breakdown {
+	b := Breakdown{Base: s.stats[stat]}
+	b.addall(s.mods[stat])
+	return b
 }
 
 // Index into stats arrays.
@@ -927,17 +1029,12 @@ const (
 // be strongly typed, which otherwise would have been annoying to enforce.
 type skills struct {
 	skills skilllist
-	mods   skilllist
+	mods   [NumSkills]modlist
 }
 
 // An array of skills
 type skilllist [NumSkills]int
 
-// Get the given skill combined with any active modifiers to it.
-func (s *skills) skill(skill SkillName) int {
-	return s.skills[skill] + s.mods[skill]
-}
-
 // Get the unmodified skill.
 func (s *skills) unmodskill(skill SkillName) int {
 	return s.skills[skill]
@@ -950,12 +1047,19 @@ func (s *skills) set(skill SkillName, amt int) {
 
 // Get the modifier (mod) for this skill.
 func (s *skills) mod(skill SkillName) int {
-	return s.mods[skill]
+	return s.mods[skill].total()
 }
 
-// Change a modifier for this skill by 'amt'.
-func (s *skills) changemod(skill SkillName, amt int) {
-	s.mods[skill] += amt
+// Change the modifier that 'src' contributes to this skill by 'amt'.
+func (s *skills) changemod(skill SkillName, src ModSource, amt int) {
+	s.mods[skill] = s.mods[skill].change(src, amt)
+}
+
+// Explain how the given skill was computed.
+func (s *skills) breakdown(skill SkillName) Breakdown {
+	b := Breakdown{Base: s.skills[skill]}
+	b.addall(s.mods[skill])
+	return b
 }
 
 // Index into stats arrays.
@@ -1041,8 +1145,7 @@ func healmp(s Sheet, amt int) {
 }
 
 func hurtmp(s Sheet, amt int) {
-	s.setMP(math.Min(s.MP()-amt, 0))
-	checkDeath(s)
+	s.setMP(math.Max(s.MP()-amt, 0))
 }
 
 func changestun(s Sheet, newstun StunLevel) {
@@ -1051,7 +1154,7 @@ func changestun(s Sheet, newstun StunLevel) {
 		return
 	}
 
-	modAllSkills(s, int(2*(oldstun-newstun)))
+	modAllSkills(s, SourceStun, int(2*(oldstun-newstun)))
 
 	msg := s.Obj().Spec.Name + " is "
 
@@ -1078,9 +1181,9 @@ func changeconf(s Sheet, newc bool) {
 	if oldc == newc {
 		return
 	} else if oldc == true {
-		modMndSkills(s, 5)
+		modMndSkills(s, SourceConfused, 5)
 	} else {
-		modMndSkills(s, dumpsterEvasion)
+		modMndSkills(s, SourceConfused, dumpsterEvasion)
 	}
 }
 
@@ -1099,7 +1202,7 @@ func checkDeath(s Sheet) {
 		game.Player.Learner.GainXPKill(obj)
 	}
 
-	game.Events.Message(fmt.Sprintf("%s fell.", obj.Spec.Name))
+	game.Events.Message(fmt.Sprintf("%s fell.", actorname(obj)))
 	game.Kill(obj)
 }
 
@@ -1125,6 +1228,28 @@ func slowpenalty(spd int) int {
 	return math.Max(spd-1, 1)
 }
 
+// Blindness takes away sight entirely, and petrification takes away regen.
+func zeropenalty(int) int {
+	return 0
+}
+
+func speedbreakdown(s Sheet, base int) Breakdown {
+	b := Breakdown{Base: base}
+	if s.Slow() {
+		b.penalize(SourceSlow, slowpenalty)
+	}
+	return b
+}
+
+// Just the dice from a protection breakdown.
+func protdice(mods []DiceMod) []Dice {
+	dice := make([]Dice, 0, len(mods))
+	for _, mod := range mods {
+		dice = append(dice, mod.Dice)
+	}
+	return dice
+}
+
 func vital(stat int) int {
 	return 10 * (1 + math.Max(stat, 1))
 }
@@ -1137,6 +1262,16 @@ func scaleMP(sheet Sheet, oldv, newv int) {
 	sheet.setMP(sheet.MP() * vital(newv) / vital(oldv))
 }
 
+// Scales HP or MP if 'stat' is the one that governs it.
+func scaleVital(sheet Sheet, stat StatName, oldv, newv int) {
+	switch stat {
+	case Vit:
+		scaleHP(sheet, oldv, newv)
+	case Mnd:
+		scaleMP(sheet, oldv, newv)
+	}
+}
+
 type StunLevel uint
 
 // Stun status definitions.
@@ -54,6 +54,26 @@ func TestHealPlayerDoesntExceedMaxHP(t *testing.T) {
 	}
 }
 
+func TestHurtPlayerMPDoesntGoBelowZero(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{Trait: Trait{obj: obj}, skills: &skills{}, mp: 5})
+	g.Player = obj
+
+	obj.Sheet.HurtMP(3)
+	if mp := obj.Sheet.MP(); mp != 2 {
+		t.Errorf(`Player hurt to %d mp; want 2.`, mp)
+	}
+
+	obj.Sheet.HurtMP(9999999)
+	if mp := obj.Sheet.MP(); mp != 0 {
+		t.Errorf(`Player hurt to %d mp; want 0.`, mp)
+	}
+	if m := g.mode; m == ModeGameOver {
+		t.Error(`Running out of MP ended the game.`)
+	}
+}
+
 func TestChangingPlayerVitAdjustsHP(t *testing.T) {
 	g := newTestGame()
 	obj := g.Player
@@ -65,7 +85,7 @@ func TestChangingPlayerVitAdjustsHP(t *testing.T) {
 
 	g.Player = obj
 
-	obj.Sheet.ChangeStatMod(Vit, 3)
+	obj.Sheet.ChangeStatMod(Vit, SourceHyper, 3)
 	if hp := obj.Sheet.HP(); hp != 30 {
 		t.Errorf(`Player hp scaled to %d hp; want %d.`, hp, 30)
 	}
@@ -87,7 +107,7 @@ func TestChangingPlayerMndAdjustsMP(t *testing.T) {
 
 	g.Player = obj
 
-	obj.Sheet.ChangeStatMod(Mnd, 3)
+	obj.Sheet.ChangeStatMod(Mnd, SourceHyper, 3)
 	if mp := obj.Sheet.MP(); mp != 30 {
 		t.Errorf(`Player mp scaled to %d mp; want %d.`, mp, 30)
 	}
@@ -138,6 +158,69 @@ func TestPlayerAttackNoBonuses(t *testing.T) {
 	testAtkEq(t, atk, Attack{Melee: 1, Damroll: NewDice(1, 7)})
 }
 
+func TestPlayerAttackPowerAbility(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{Trait: Trait{obj: obj}})
+	obj.Learner.(*ActorLearner).abilities[AbilityPower] = true
+
+	weap := g.NewObj(astKnifeSpec)
+	obj.Equipper.Body().Wear(weap)
+
+	atk := obj.Sheet.Attack()
+	testAtkEq(t, atk, Attack{Melee: 1, Damroll: NewDice(1, 8)})
+}
+
+func TestPlayerDefenseDodgingAbility(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{Trait: Trait{obj: obj}})
+
+	before := obj.Sheet.Defense().Evasion
+	obj.Learner.(*ActorLearner).abilities[AbilityDodging] = true
+
+	if ev, want := obj.Sheet.Defense().Evasion, before+2; ev != want {
+		t.Errorf(`Evasion with Dodging was %d, want %d`, ev, want)
+	}
+}
+
+func TestPlayerAbilityModsAreTagged(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{
+		Trait: Trait{obj: obj},
+		regen: 1,
+		sight: 5,
+	})
+	learned := obj.Learner.(*ActorLearner).abilities
+	learned[AbilityInnerStrength] = true
+	learned[AbilityKeenEyes] = true
+
+	tests := []struct {
+		name    string
+		b       Breakdown
+		ability AbilityName
+		total   int
+	}{
+		{"Regen", obj.Sheet.RegenBreakdown(), AbilityInnerStrength, 2},
+		{"Sight", obj.Sheet.SightBreakdown(), AbilityKeenEyes, 6},
+	}
+	for _, test := range tests {
+		want := []Modifier{{Source: abilitysource(test.ability), Amount: 1}}
+		if len(test.b.Mods) != 1 || test.b.Mods[0] != want[0] {
+			t.Errorf(`%s mods were %+v, want %+v`, test.name, test.b.Mods, want)
+		}
+		if total := test.b.Total(); total != test.total {
+			t.Errorf(`%s was %d, want %d`, test.name, total, test.total)
+		}
+	}
+
+	obj.Sheet.SetBlind(true)
+	if sight := obj.Sheet.Sight(); sight != 0 {
+		t.Errorf(`Blind Sight() with Keen Eyes was %d, want 0`, sight)
+	}
+}
+
 func TestPlayerAttackStrBonusBelowCap(t *testing.T) {
 	g := newTestGame()
 	obj := g.Player
@@ -466,3 +549,89 @@ func TestPlayerPetrify(t *testing.T) {
 		t.Error(`Petrify CanAct() was true, want false`)
 	}
 }
+
+func TestPlayerDefenseWeaponHasNoProt(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{Trait: Trait{obj: obj}})
+
+	obj.Equipper.Body().Wear(g.NewObj(astKnifeSpec))
+
+	def := obj.Sheet.Defense()
+	testDefEq(t, def, Defense{Evasion: 1})
+	if d, w := def.Describe(), "[+1]"; d != w {
+		t.Errorf(`def.Describe() was "%s", want "%s"`, d, w)
+	}
+}
+
+func TestPlayerMeleeBreakdown(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{
+		Trait:  Trait{obj: obj},
+		stats:  &stats{stats: statlist{Agi: 3}},
+		skills: &skills{skills: skilllist{Melee: 4}},
+		blind:  true,
+	})
+	obj.Equipper.Body().Wear(g.NewObj(astKnifeSpec))
+
+	b := obj.Sheet.MeleeBreakdown()
+	want := []Modifier{
+		{Source: SourceAgi, Amount: 3},
+		{Source: ModSource{Kind: ModEquipment, Name: "KNIFE"}, Amount: 1},
+		{Source: SourceBlind, Amount: -4},
+	}
+
+	if base := b.Base; base != 4 {
+		t.Errorf(`b.Base was %d, want 4`, base)
+	}
+	if len(b.Mods) != len(want) {
+		t.Fatalf(`b.Mods was %+v, want %+v`, b.Mods, want)
+	}
+	for i, mod := range want {
+		if b.Mods[i] != mod {
+			t.Errorf(`b.Mods[%d] was %+v, want %+v`, i, b.Mods[i], mod)
+		}
+	}
+	if total, atk := b.Total(), obj.Sheet.Attack().Melee; total != atk {
+		t.Errorf(`b.Total() was %d, but Attack().Melee was %d`, total, atk)
+	}
+}
+
+func TestPlayerStatModsAreTagged(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{
+		Trait: Trait{obj: obj},
+		stats: &stats{stats: statlist{Agi: 3}},
+	})
+
+	obj.Sheet.ChangeStatMod(Agi, SourceDrain, -1)
+	obj.Sheet.ChangeStatMod(Agi, SourceHyper, 2)
+	obj.Sheet.ChangeStatMod(Agi, SourceDrain, -1)
+
+	b := obj.Sheet.StatBreakdown(Agi)
+	if n := len(b.Mods); n != 2 {
+		t.Errorf(`len(b.Mods) was %d, want 2`, n)
+	}
+	if agi := obj.Sheet.Stat(Agi); agi != 3 {
+		t.Errorf(`Stat(Agi) was %d, want 3`, agi)
+	}
+	if sk := obj.Sheet.Skill(Stealth); sk != 3 {
+		t.Errorf(`Skill(Stealth) was %d, want 3`, sk)
+	}
+}
+
+func TestPlayerSpeedBreakdown(t *testing.T) {
+	g := newTestGame()
+	obj := g.Player
+	obj.Sheet = NewPlayerSheetFromSpec(&PlayerSheet{Trait: Trait{obj: obj}, speed: 2, slow: true})
+
+	b := obj.Sheet.SpeedBreakdown()
+	if base, total := b.Base, b.Total(); base != 2 || total != 1 {
+		t.Errorf(`SpeedBreakdown() was %d base, %d total; want 2, 1`, base, total)
+	}
+	if mod := b.Mods[0]; mod.Source != SourceSlow {
+		t.Errorf(`Speed modifier source was %+v, want %+v`, mod.Source, SourceSlow)
+	}
+}
@@ -0,0 +1,219 @@
+package game
+
+import (
+	"fmt"
+
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+// A thing that can sing songs. Songs are sustained: once started, they keep
+// going every turn until the singer stops, runs out of MP, or is silenced or
+// stunned.
+type Singer interface {
+	Objgetter
+	// Bring up the 'sing' screen if this actor knows any songs.
+	TrySing()
+	// Start singing the song at index 'index' in Songs(), stopping whatever
+	// song is currently being sung. Return true if a turn should pass.
+	Sing(index int) bool
+	// Stop singing the current song. Return true if a turn should pass.
+	StopSinging() bool
+	// The song currently being sung, or nil if there isn't one.
+	Singing() *Melody
+	// The songs that this actor is currently skilled enough to sing.
+	Songs() []*Melody
+	// How much MP it costs per turn for this actor to keep singing 'song'.
+	Cost(song *Melody) int
+
+	// Cleans up after the current song once its effect has ended.
+	finish()
+}
+
+// A song that can be sung by an actor. Called a Melody since Song is already
+// the name of the skill.
+type Melody struct {
+	Name string
+	// The Song skill needed to be able to sing this.
+	Level int
+	// How much MP this costs to sustain each turn, before the singer's Song
+	// skill is taken into account.
+	Cost int
+	// The active effect that sustains this song while it's being sung.
+	Effect Effect
+	// The skill that is boosted while this is being sung, if any.
+	Boosts SkillName
+	// How much 'Boosts' is raised by, given the singer's Song skill. Leave nil
+	// if this song doesn't boost a skill.
+	Boost func(power int) int
+	// Something to do every turn that this song is sustained. Can be nil.
+	Each func(singer *Obj, power int)
+}
+
+// Skill boosts from this song are tagged with its name.
+func (m *Melody) source() ModSource {
+	return ModSource{Kind: ModEffect, Name: m.Name}
+}
+
+type ActorSinger struct {
+	Trait
+	// All of the songs this actor could know.
+	songs []*Melody
+	// The song currently being sung.
+	current *Melody
+	// How much the current song boosted its skill by when it began.
+	boost int
+}
+
+// Given a copy of an ActorSinger literal, this will return a function that
+// will bind the owner of the singer to it at object creation time.
+func NewActorSinger(spec *ActorSinger) func(*Obj) Singer {
+	return func(o *Obj) Singer {
+		s := &ActorSinger{}
+		*s = *spec
+		s.obj = o
+		return s
+	}
+}
+
+func (s *ActorSinger) Songs() []*Melody {
+	skill := s.obj.Sheet.Skill(Song)
+	known := make([]*Melody, 0, len(s.songs))
+	for _, song := range s.songs {
+		if song.Level <= skill {
+			known = append(known, song)
+		}
+	}
+	return known
+}
+
+func (s *ActorSinger) Singing() *Melody {
+	return s.current
+}
+
+func (s *ActorSinger) Cost(song *Melody) int {
+	cost := song.Cost - s.obj.Sheet.Skill(Song)/5
+	if hasability(s.obj, AbilitySustain) {
+		cost--
+	}
+	return math.Max(1, cost)
+}
+
+func (s *ActorSinger) TrySing() {
+	if len(s.Songs()) == 0 {
+		s.obj.Game.Events.Message(fmt.Sprintf("%s doesn't know any songs.", actorname(s.obj)))
+	} else if s.obj.Sheet.Silenced() {
+		s.obj.Game.Events.Message(fmt.Sprintf("%s can't sing!", s.obj.Spec.Name))
+	} else {
+		s.obj.Game.SwitchMode(ModeSing)
+	}
+}
+
+func (s *ActorSinger) Sing(index int) bool {
+	obj := s.obj
+	obj.Game.SwitchMode(ModeHud)
+
+	songs := s.Songs()
+
+	// Bounds-check the index the player requested.
+	if index < 0 || index >= len(songs) {
+		return false
+	}
+
+	song := songs[index]
+	if song == s.current {
+		return false
+	}
+	if obj.Sheet.Silenced() || obj.Sheet.Stun() != NotStunned {
+		obj.Game.Events.Message(fmt.Sprintf("%s can't sing!", obj.Spec.Name))
+		return false
+	}
+	if s.Cost(song) > obj.Sheet.MP() {
+		obj.Game.Events.Message(fmt.Sprintf("%s doesn't have enough MP to sing.", obj.Spec.Name))
+		return false
+	}
+
+	s.StopSinging()
+
+	s.current = song
+	if song.Boost != nil {
+		s.boost = song.Boost(obj.Sheet.Skill(Song))
+		obj.Sheet.ChangeSkillMod(song.Boosts, song.source(), s.boost)
+	}
+	obj.Game.Events.Message(fmt.Sprintf("%s begins a song of %s.", obj.Spec.Name, song.Name))
+	obj.Ticker.AddEffect(song.Effect, 0)
+	return true
+}
+
+func (s *ActorSinger) StopSinging() bool {
+	if s.current == nil {
+		return false
+	}
+	return s.obj.Ticker.RemoveEffect(s.current.Effect)
+}
+
+func (s *ActorSinger) finish() {
+	song := s.current
+	if song == nil {
+		return
+	}
+	if song.Boost != nil {
+		s.obj.Sheet.ChangeSkillMod(song.Boosts, song.source(), -s.boost)
+	}
+	s.current, s.boost = nil, 0
+	s.obj.Game.Events.Message(fmt.Sprintf("%s stops singing.", s.obj.Spec.Name))
+}
+
+// Tick function shared by all songs. Every turn, the singer pays for the song
+// and the song does its thing. Ends the song if the singer can no longer keep
+// it up.
+func songtick(e *ActiveEffect, t Ticker, diff int) bool {
+	obj := t.Obj()
+	sheet, singer := obj.Sheet, obj.Singer
+
+	if sheet.Silenced() || sheet.Stun() != NotStunned {
+		return true
+	}
+
+	song := singer.Singing()
+	if song == nil {
+		return true
+	}
+
+	turn := GetDelay(2)
+	for e.Counter += diff; e.Counter >= turn; e.Counter -= turn {
+		cost := singer.Cost(song)
+		if cost > sheet.MP() {
+			obj.Game.Events.Message(fmt.Sprintf("%s runs out of breath.", obj.Spec.Name))
+			return true
+		}
+		sheet.HurtMP(cost)
+
+		if song.Each != nil {
+			song.Each(obj, sheet.Skill(Song))
+		}
+	}
+	return false
+}
+
+// Ends whatever song the actor with ticker 't' is singing.
+func songend(_ *ActiveEffect, t Ticker) {
+	t.Obj().Singer.finish()
+}
+
+// Every monster in sight of 'singer' must beat the singer's Song skill with
+// their will, or be silenced for a little while.
+func silencenearby(singer *Obj, power int) {
+	if singer.Senser == nil {
+		return
+	}
+	for _, pt := range singer.Senser.FOV() {
+		other := singer.Level.At(pt).Actor
+		if other == nil || other == singer || other.Ticker == nil || other.Sheet.Silenced() {
+			continue
+		}
+		resists := other.Sheet.Defense().Effects.Resists(EffectSilence)
+		if won, by := skillcheck(power, other.Sheet.Skill(Chi), resists, singer, other); won {
+			other.Ticker.AddEffect(EffectSilence, math.Max(2, by))
+		}
+	}
+}
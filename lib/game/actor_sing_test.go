@@ -0,0 +1,174 @@
+package game
+
+import (
+	"testing"
+)
+
+var (
+	astTestSongs = []*Melody{
+		{
+			Name:   "TEST",
+			Level:  1,
+			Cost:   2,
+			Effect: EffectSongStaying,
+			Boosts: Chi,
+			Boost:  func(int) int { return 3 },
+		},
+		{
+			Name:   "HARD",
+			Level:  5,
+			Cost:   2,
+			Effect: EffectSongSharpness,
+		},
+	}
+
+	astSingerSpec = &Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: "TestSpecies",
+		Name:    "Singer",
+		Traits: &Traits{
+			Sheet:  NewPlayerSheet,
+			Ticker: NewActorTicker,
+			Singer: NewActorSinger(&ActorSinger{songs: astTestSongs}),
+		},
+	}
+)
+
+// Sets the Song skill of 'obj' so that it will be 'n' after mods are applied.
+func astSetSong(obj *Obj, n int) {
+	obj.Sheet.SetSkill(Song, n-obj.Sheet.SkillMod(Song))
+}
+
+func TestSongsAreGatedBySongSkill(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+
+	astSetSong(singer, 0)
+	if n := len(singer.Singer.Songs()); n != 0 {
+		t.Errorf(`Songs() with Song 0 had %d songs, want 0`, n)
+	}
+
+	astSetSong(singer, 1)
+	if n := len(singer.Singer.Songs()); n != 1 {
+		t.Errorf(`Songs() with Song 1 had %d songs, want 1`, n)
+	}
+}
+
+func TestSongCostScalesWithSkill(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	song := &Melody{Cost: 3}
+
+	astSetSong(singer, 4)
+	if c := singer.Singer.Cost(song); c != 3 {
+		t.Errorf(`Cost() with Song 4 was %d, want 3`, c)
+	}
+	astSetSong(singer, 10)
+	if c := singer.Singer.Cost(song); c != 1 {
+		t.Errorf(`Cost() with Song 10 was %d, want 1`, c)
+	}
+	astSetSong(singer, 50)
+	if c := singer.Singer.Cost(song); c != 1 {
+		t.Errorf(`Cost() with Song 50 was %d, want 1`, c)
+	}
+}
+
+func TestSingingBoostsSkillUntilStopped(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	astSetSong(singer, 1)
+	chi := singer.Sheet.Skill(Chi)
+
+	if !singer.Singer.Sing(0) {
+		t.Error(`Sing() returned false, want true`)
+	}
+	if s := singer.Singer.Singing(); s != astTestSongs[0] {
+		t.Errorf(`Singing() was %v, want %v`, s, astTestSongs[0])
+	}
+	if now, want := singer.Sheet.Skill(Chi), chi+3; now != want {
+		t.Errorf(`Chi while singing was %d, want %d`, now, want)
+	}
+
+	singer.Singer.StopSinging()
+
+	if s := singer.Singer.Singing(); s != nil {
+		t.Errorf(`Singing() after stopping was %v, want nil`, s)
+	}
+	if now := singer.Sheet.Skill(Chi); now != chi {
+		t.Errorf(`Chi after singing was %d, want %d`, now, chi)
+	}
+	if c := singer.Ticker.Counter(EffectSongStaying); c != 0 {
+		t.Errorf(`Song effect still active after stopping; counter was %d`, c)
+	}
+}
+
+func TestSingingSpendsMPEachTurn(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	astSetSong(singer, 1)
+	mp := singer.Sheet.MP()
+
+	singer.Singer.Sing(0)
+	singer.Ticker.Tick(GetDelay(2))
+
+	if now, want := singer.Sheet.MP(), mp-2; now != want {
+		t.Errorf(`Singing for a turn left %d MP, want %d`, now, want)
+	}
+}
+
+func TestSongEndsWhenOutOfMP(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	astSetSong(singer, 1)
+
+	singer.Singer.Sing(0)
+	singer.Sheet.HurtMP(singer.Sheet.MP() - 1)
+	singer.Ticker.Tick(GetDelay(2))
+
+	if s := singer.Singer.Singing(); s != nil {
+		t.Errorf(`Singing() after running out of MP was %v, want nil`, s)
+	}
+}
+
+func TestSongEndsWhenSilenced(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	astSetSong(singer, 1)
+
+	singer.Singer.Sing(0)
+	singer.Sheet.SetSilenced(true)
+	singer.Ticker.Tick(GetDelay(2))
+
+	if s := singer.Singer.Singing(); s != nil {
+		t.Errorf(`Singing() after being silenced was %v, want nil`, s)
+	}
+}
+
+func TestSongEndsWhenStunned(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	astSetSong(singer, 1)
+
+	singer.Singer.Sing(0)
+	singer.Ticker.AddEffect(EffectStun, 10)
+	singer.Ticker.Tick(GetDelay(2))
+
+	if s := singer.Singer.Singing(); s != nil {
+		t.Errorf(`Singing() after being stunned was %v, want nil`, s)
+	}
+}
+
+func TestCantStartSongWhileSilenced(t *testing.T) {
+	g := newTestGame()
+	singer := g.NewObj(astSingerSpec)
+	astSetSong(singer, 1)
+	singer.Sheet.SetSilenced(true)
+
+	if singer.Singer.Sing(0) {
+		t.Error(`Sing() while silenced returned true, want false`)
+	}
+	if s := singer.Singer.Singing(); s != nil {
+		t.Errorf(`Singing() after singing while silenced was %v, want nil`, s)
+	}
+}
@@ -2,11 +2,19 @@ package game
 
 const (
 	// Player species.
-	SpecHuman = "human"
+	SpecHuman   = "human"
+	SpecNoldor  = "noldor"
+	SpecSindar  = "sindar"
+	SpecNaugrim = "naugrim"
+	SpecEdain   = "edain"
 
 	// Monster species.
-	SpecOrc = "orc"
-	SpecAnt = "ant"
+	SpecOrc       = "orc"
+	SpecOrcShaman = "orcshaman"
+	SpecAnt       = "ant"
+
+	// Unique monster species.
+	SpecGorbag = "gorbag"
 )
 
 var PlayerSpec = &Spec{
@@ -24,6 +32,12 @@ var PlayerSpec = &Spec{
 		Senser:   NewActorSenser,
 		Ticker:   NewActorTicker,
 		Learner:  NewActorLearner,
+		Caster: NewActorCaster(&ActorCaster{
+			spells: PlayerSpells,
+		}),
+		Singer: NewActorSinger(&ActorSinger{
+			songs: PlayerSongs,
+		}),
 	},
 }
 
@@ -33,6 +47,8 @@ var Monsters = []*Spec{
 		Genus:   GenMonster,
 		Species: SpecOrc,
 		Name:    "ORC",
+		// Orcs are too heavily armoured to swim.
+		Sinks: true,
 		Gen: Gen{
 			Floors:    []int{1},
 			GroupSize: 2,
@@ -101,6 +117,75 @@ var Monsters = []*Spec{
 			}),
 		},
 	},
+	&Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: SpecOrcShaman,
+		Name:    "ORC SHAMAN",
+		Sinks:   true,
+		Gen: Gen{
+			Floors:    []int{2},
+			GroupSize: 1,
+		},
+		Traits: &Traits{
+			Mover: NewActorMover,
+			AI: NewSMAI(SMAI{
+				Brain: SMAIWanderer,
+				Personality: &Personality{
+					Fear:        40,
+					Persistence: 500,
+				},
+			}),
+			Fighter: NewActorFighter,
+			Packer:  NewActorPacker,
+			Senser:  NewActorSenser,
+			Ticker:  NewActorTicker,
+			Dropper: NewItemDropper(&ItemDropper{
+				num: 2,
+			}),
+			Caster: NewActorCaster(&ActorCaster{
+				spells: []*Spell{SpellMonsterBolt, SpellScare, SpellMonsterHeal},
+				freq:   3,
+			}),
+			Sheet: NewMonsterSheet(&MonsterSheet{
+				stats: &stats{
+					stats: statlist{
+						Str: 0,
+						Agi: 1,
+						Vit: 0,
+						Mnd: 3,
+					},
+				},
+				skills: &skills{
+					skills: skilllist{
+						Chi:   8,
+						Magic: 4,
+					},
+				},
+				speed: 2,
+				maxhp: 15,
+				maxmp: 20,
+
+				attacks: []*MonsterAttack{
+					{
+						Attack: Attack{
+							Melee:   0,
+							Damroll: NewDice(1, 8),
+							CritDiv: 4,
+							Effects: Effects{},
+							Verb:    "hits",
+						},
+						P: 1,
+					},
+				},
+				defense: Defense{
+					Evasion:  2,
+					ProtDice: []Dice{NewDice(1, 3)},
+					Effects:  NewEffects(map[Effect]int{}),
+				},
+			}),
+		},
+	},
 	&Spec{
 		Family:  FamActor,
 		Genus:   GenMonster,
@@ -173,4 +258,82 @@ var Monsters = []*Spec{
 			}),
 		},
 	},
+	&Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: SpecGorbag,
+		Name:    "GORBAG",
+		Lore:    "A captain of the orcs of Minas Morgul, cruel and cunning in equal measure.",
+		Sinks:   true,
+		Gen: Gen{
+			Floors:    []int{3},
+			GroupSize: 1,
+			Unique:    true,
+		},
+		Traits: &Traits{
+			Mover: NewActorMover,
+			AI: NewSMAI(SMAI{
+				Brain: SMAITerritorial,
+				Personality: &Personality{
+					Fear:        10,
+					Persistence: 0,
+				},
+			}),
+			Fighter: NewActorFighter,
+			Packer:  NewActorPacker,
+			Senser:  NewActorSenser,
+			Ticker:  NewActorTicker,
+			Dropper: NewItemDropper(&ItemDropper{
+				num:    2,
+				boost:  2,
+				always: []*Spec{itemspec(SpecAngrist)},
+			}),
+			Sheet: NewMonsterSheet(&MonsterSheet{
+				stats: &stats{
+					stats: statlist{
+						Str: 4,
+						Agi: 2,
+						Vit: 3,
+						Mnd: 1,
+					},
+				},
+				skills: &skills{
+					skills: skilllist{
+						Chi: 12,
+					},
+				},
+				speed: 2,
+				maxhp: 45,
+				maxmp: 10,
+
+				attacks: []*MonsterAttack{
+					{
+						Attack: Attack{
+							Melee:   5,
+							Damroll: NewDice(3, 7),
+							CritDiv: 4,
+							Effects: NewEffects(map[Effect]int{BrandPoison: 1}),
+							Verb:    "slashes",
+						},
+						P: 2,
+					},
+					{
+						Attack: Attack{
+							Melee:   4,
+							Damroll: NewDice(1, 11),
+							CritDiv: 2,
+							Effects: NewEffects(map[Effect]int{EffectCut: 1}),
+							Verb:    "stabs",
+						},
+						P: 1,
+					},
+				},
+				defense: Defense{
+					Evasion:  4,
+					ProtDice: []Dice{NewDice(2, 4)},
+					Effects:  NewEffects(map[Effect]int{ResistPoison: 1}),
+				},
+			}),
+		},
+	},
 }
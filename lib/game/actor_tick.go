@@ -31,6 +31,7 @@ func NewActorTicker(obj *Obj) Ticker {
 		Effects: map[Effect]*ActiveEffect{},
 	}
 	t.AddEffect(EffectBaseRegen, 0)
+	t.AddEffect(EffectBaseRegenMP, 0)
 	return t
 }
 
@@ -74,6 +75,11 @@ func (t *ActorTicker) AddEffect(e Effect, counter int) {
 	if ae == nil {
 		ae = NewActiveEffect(e, counter)
 		t.Effects[e] = ae
+		// A new effect on the player stops them running. (Actors get their
+		// base effects before they've joined a game.)
+		if g := t.obj.Game; g != nil && t.obj.IsPlayer() {
+			g.Disturb()
+		}
 	} else {
 		prev = ae.Counter
 		switch ae.Stacks {
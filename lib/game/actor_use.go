@@ -42,11 +42,12 @@ func (a *ActorUser) Use(index int) bool {
 	}
 
 	if item.Spec.Genus != GenConsumable {
-		a.obj.Game.Events.Message(fmt.Sprintf("Cannot use %v.", item.Spec.Name))
+		a.obj.Game.Events.Message(fmt.Sprintf("Cannot use %v.", itemname(item)))
 		return false
 	}
 
-	item = inv.Take(index)
+	// Only one item from a stack gets used up.
+	item = inv.TakeN(index, 1)
 	item.Consumable.Consume(a)
 	return true
 }
@@ -114,3 +114,17 @@ func TestUseBadItem(t *testing.T) {
 		t.Error(`Use() consumed nonitem.`)
 	}
 }
+
+func TestUseConsumesOneFromStack(t *testing.T) {
+	g := newTestGame()
+	user := g.NewObj(atActorSpec)
+
+	stack := g.NewObj(atConsumeSpec)
+	stack.Consumable.Count = 3
+	user.Packer.Inventory().Add(stack)
+	user.User.Use(0)
+
+	if count := user.Packer.Inventory().At(0).Count(); count != 2 {
+		t.Errorf(`Use() on stack of 3 left %d, want 2`, count)
+	}
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+// How "good" a generated piece of equipment is.
+type Quality int
+
+const (
+	// Plain old equipment, straight from the spec.
+	QualityNormal Quality = iota
+	// Well-made, but not magical.
+	QualityFine
+	// Has a single magical affix.
+	QualityEgo
+	// Has both a magical prefix and suffix.
+	QualityGreat
+)
+
+// A property that can be rolled onto equipment when it is generated, e.g.
+// "Elven" or "of Fire". Affixes change the name of the item they are on.
+type Affix struct {
+	Name string
+	// Does this go before the item's name ("Elven SWORD") or after it ("SWORD
+	// of Fire")?
+	Prefix bool
+	// The slots of equipment this can be rolled on.
+	Slots []Slot
+	// The shallowest floor this can be found on.
+	Floor int
+	// How relatively frequently should we roll this affix?
+	P int
+	// What this does to the equipment it's on. Dice are added to the item's
+	// dice, the numeric fields are added to the item's, and Effects are
+	// merged. Slot is ignored.
+	Mods Equipment
+}
+
+func (a *Affix) Weight() int {
+	return a.P
+}
+
+// Can this affix be rolled onto equipment in slot 'slot' at generation level
+// 'level'?
+func (a *Affix) fits(slot Slot, level int) bool {
+	if a.Floor > level {
+		return false
+	}
+	for _, s := range a.Slots {
+		if s == slot {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply this affix's mods to 'equip'.
+func (a *Affix) apply(equip *Equipment) {
+	mods := a.Mods
+	if mods.Damroll != ZeroDice && equip.Damroll != ZeroDice {
+		equip.Damroll = equip.Damroll.Add(mods.Damroll.Dice, mods.Damroll.Sides)
+	}
+	if mods.Protroll != ZeroDice && equip.Protroll != ZeroDice {
+		equip.Protroll = equip.Protroll.Add(mods.Protroll.Dice, mods.Protroll.Sides)
+	}
+	equip.Melee += mods.Melee
+	equip.Evasion += mods.Evasion
+	equip.Weight = math.Max(0, equip.Weight+mods.Weight)
+	if mods.Effects != nil {
+		equip.Effects = equip.Effects.Merge(mods.Effects)
+	}
+	equip.Affixes = append(equip.Affixes, a)
+}
+
+// Rolls a quality tier for 'item' and applies affixes to it to match. 'level'
+// is the level that the item is being generated at -- the deeper, the better
+// the odds. Uniques and things that aren't equipment are left alone.
+func enchant(item *Obj, level int) {
+	equip := item.Equipment
+	if equip == nil || item.Spec.Gen.Unique {
+		return
+	}
+
+	equip.Quality = rollquality(level)
+	slot := equip.Slot
+
+	switch equip.Quality {
+	case QualityFine:
+		if slot == SlotHand {
+			AffixFineWeapon.apply(equip)
+		} else {
+			AffixFineArmor.apply(equip)
+		}
+	case QualityEgo:
+		if affix := chooseaffix(slot, level, Coinflip()); affix != nil {
+			affix.apply(equip)
+		}
+	case QualityGreat:
+		if affix := chooseaffix(slot, level, true); affix != nil {
+			affix.apply(equip)
+		}
+		if affix := chooseaffix(slot, level, false); affix != nil {
+			affix.apply(equip)
+		}
+	}
+}
+
+// Figure out what quality tier something generated at 'level' should be.
+func rollquality(level int) Quality {
+	level = math.Max(level, 1)
+	r := RandInt(0, 100)
+	switch {
+	case r < level:
+		return QualityGreat
+	case r < 4*level:
+		return QualityEgo
+	case r < 10+8*level:
+		return QualityFine
+	default:
+		return QualityNormal
+	}
+}
+
+// Pick a magical prefix or suffix that can go onto equipment in 'slot' at
+// 'level'. Returns nil if nothing fits.
+func chooseaffix(slot Slot, level int, prefix bool) *Affix {
+	candidates := make([]Weighter, 0)
+	for _, affix := range Affixes {
+		if affix.Prefix == prefix && affix.fits(slot, level) {
+			candidates = append(candidates, affix)
+		}
+	}
+
+	_, chosen := WChoose(candidates)
+	if chosen == nil {
+		return nil
+	}
+	return chosen.(*Affix)
+}
@@ -0,0 +1,154 @@
+package game
+
+var (
+	weaponSlots = []Slot{SlotHand}
+	armorSlots  = []Slot{SlotHead, SlotBody, SlotArms, SlotLegs}
+	anySlots    = []Slot{SlotHand, SlotHead, SlotBody, SlotArms, SlotLegs}
+)
+
+// Non-magical improvements that "fine" equipment gets.
+var (
+	AffixFineWeapon = &Affix{
+		Name:   "Fine",
+		Prefix: true,
+		Slots:  weaponSlots,
+		Mods:   Equipment{Damroll: NewDice(0, 1), Melee: 1},
+	}
+	AffixFineArmor = &Affix{
+		Name:   "Fine",
+		Prefix: true,
+		Slots:  armorSlots,
+		Mods:   Equipment{Protroll: NewDice(0, 1), Evasion: 1},
+	}
+)
+
+// All of the magical affixes that can be rolled on equipment.
+var Affixes = []*Affix{
+	// Prefixes.
+	{
+		Name:   "Elven",
+		Prefix: true,
+		Slots:  anySlots,
+		Floor:  1,
+		P:      3,
+		Mods:   Equipment{Evasion: 2, Weight: -1},
+	},
+	{
+		Name:   "Dwarven",
+		Prefix: true,
+		Slots:  armorSlots,
+		Floor:  2,
+		P:      2,
+		Mods:   Equipment{Protroll: NewDice(1, 0), Weight: 1},
+	},
+	{
+		Name:   "Dwarven",
+		Prefix: true,
+		Slots:  weaponSlots,
+		Floor:  2,
+		P:      2,
+		Mods:   Equipment{Damroll: NewDice(0, 2), Weight: 1},
+	},
+	{
+		Name:   "Keen",
+		Prefix: true,
+		Slots:  weaponSlots,
+		Floor:  1,
+		P:      3,
+		Mods:   Equipment{Melee: 2, Effects: NewEffects(map[Effect]int{EffectCut: 1})},
+	},
+	{
+		Name:   "Blessed",
+		Prefix: true,
+		Slots:  anySlots,
+		Floor:  3,
+		P:      1,
+		Mods:   Equipment{Melee: 1, Evasion: 1, Effects: NewEffects(map[Effect]int{ResistCurse: 1})},
+	},
+
+	// Suffixes.
+	{
+		Name:  "of Fire",
+		Slots: weaponSlots,
+		Floor: 1,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{BrandFire: 1})},
+	},
+	{
+		Name:  "of Frost",
+		Slots: weaponSlots,
+		Floor: 1,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{BrandIce: 1})},
+	},
+	{
+		Name:  "of Lightning",
+		Slots: weaponSlots,
+		Floor: 2,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{BrandElec: 1})},
+	},
+	{
+		Name:  "of Venom",
+		Slots: weaponSlots,
+		Floor: 3,
+		P:     1,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{BrandPoison: 1})},
+	},
+	{
+		Name:  "of Slaying Pearl",
+		Slots: weaponSlots,
+		Floor: 1,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{SlayPearl: 1})},
+	},
+	{
+		Name:  "of Slaying Hunter",
+		Slots: weaponSlots,
+		Floor: 2,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{SlayHunter: 1})},
+	},
+	{
+		Name:  "of Slaying Battle",
+		Slots: weaponSlots,
+		Floor: 3,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{SlayBattle: 1})},
+	},
+	{
+		Name:  "of Resist Fire",
+		Slots: armorSlots,
+		Floor: 1,
+		P:     3,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{ResistFire: 1})},
+	},
+	{
+		Name:  "of Resist Cold",
+		Slots: armorSlots,
+		Floor: 1,
+		P:     3,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{ResistIce: 1})},
+	},
+	{
+		Name:  "of Free Action",
+		Slots: armorSlots,
+		Floor: 2,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{ResistPara: 1})},
+	},
+	{
+		Name:  "of Courage",
+		Slots: armorSlots,
+		Floor: 2,
+		P:     2,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{ResistFear: 1})},
+	},
+	{
+		Name:  "of Warding",
+		Slots: armorSlots,
+		Floor: 3,
+		P:     1,
+		Mods:  Equipment{Protroll: NewDice(0, 2), Effects: NewEffects(map[Effect]int{ResistCrit: 1})},
+	},
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"testing"
+)
+
+var affixTestWeapon = &Spec{
+	Family:  FamItem,
+	Genus:   GenEquipment,
+	Species: "testweapon",
+	Name:    "SWORD",
+	Traits: &Traits{
+		Equipment: NewEquipment(Equipment{
+			Damroll: NewDice(2, 5),
+			Melee:   1,
+			Evasion: 0,
+			Weight:  3,
+			Slot:    SlotHand,
+			Effects: NewEffects(map[Effect]int{SlayPearl: 1}),
+		}),
+	},
+}
+
+func TestAffixApply(t *testing.T) {
+	g := newTestGame()
+	sword := g.NewObj(affixTestWeapon)
+
+	affix := &Affix{
+		Name:  "of Testing",
+		Slots: weaponSlots,
+		Mods: Equipment{
+			Damroll: NewDice(1, 1),
+			Melee:   2,
+			Evasion: -1,
+			Weight:  -5,
+			Effects: NewEffects(map[Effect]int{BrandFire: 1, SlayPearl: 1}),
+		},
+	}
+	affix.apply(sword.Equipment)
+
+	equip := sword.Equipment
+	if want := NewDice(3, 6); equip.Damroll != want {
+		t.Errorf(`Affixed Damroll was %v, want %v`, equip.Damroll, want)
+	}
+	if equip.Melee != 3 {
+		t.Errorf(`Affixed Melee was %d, want 3`, equip.Melee)
+	}
+	if equip.Evasion != -1 {
+		t.Errorf(`Affixed Evasion was %d, want -1`, equip.Evasion)
+	}
+	if equip.Weight != 0 {
+		t.Errorf(`Affixed Weight was %d, want 0`, equip.Weight)
+	}
+	if n := equip.Effects.Has(BrandFire); n != 1 {
+		t.Errorf(`Affixed BrandFire was %d, want 1`, n)
+	}
+	if n := equip.Effects.Has(SlayPearl); n != 2 {
+		t.Errorf(`Affixed SlayPearl was %d, want 2`, n)
+	}
+}
+
+func TestAffixApplyDoesNotChangeSpec(t *testing.T) {
+	g := newTestGame()
+	sword := g.NewObj(affixTestWeapon)
+
+	affix := &Affix{
+		Name:  "of Testing",
+		Slots: weaponSlots,
+		Mods:  Equipment{Effects: NewEffects(map[Effect]int{BrandFire: 1})},
+	}
+	affix.apply(sword.Equipment)
+
+	other := g.NewObj(affixTestWeapon)
+	if n := other.Equipment.Effects.Has(BrandFire); n != 0 {
+		t.Errorf(`Fresh SWORD had BrandFire %d after affixing another, want 0`, n)
+	}
+}
+
+func TestAffixNames(t *testing.T) {
+	g := newTestGame()
+	sword := g.NewObj(affixTestWeapon)
+
+	prefix := &Affix{Name: "Elven", Prefix: true, Slots: weaponSlots}
+	suffix := &Affix{Name: "of Fire", Slots: weaponSlots}
+
+	prefix.apply(sword.Equipment)
+	suffix.apply(sword.Equipment)
+
+	if name, want := sword.Describe(), "Elven SWORD of Fire"; name != want {
+		t.Errorf(`Affixed name was %q, want %q`, name, want)
+	}
+}
+
+func TestRollQuality(t *testing.T) {
+	tests := []struct {
+		roll  int
+		level int
+		want  Quality
+	}{
+		{0, 1, QualityGreat},
+		{1, 1, QualityEgo},
+		{4, 1, QualityFine},
+		{18, 1, QualityNormal},
+		{4, 5, QualityGreat},
+		{19, 5, QualityEgo},
+		{49, 5, QualityFine},
+		{50, 5, QualityNormal},
+	}
+
+	for _, test := range tests {
+		FixRandomSource([]int{test.roll})
+		if q := rollquality(test.level); q != test.want {
+			t.Errorf(`rollquality(%d) with roll %d was %v, want %v`, test.level, test.roll, q, test.want)
+		}
+		RestoreRandom()
+	}
+}
+
+func TestEnchantIgnoresUniques(t *testing.T) {
+	g := newTestGame()
+	spec := *affixTestWeapon
+	spec.Gen = Gen{Unique: true}
+	art := g.NewObj(&spec)
+
+	enchant(art, 100)
+
+	if q := art.Equipment.Quality; q != QualityNormal {
+		t.Errorf(`Enchanted unique had quality %v, want %v`, q, QualityNormal)
+	}
+}
+
+func TestChooseAffixRespectsSlotAndFloor(t *testing.T) {
+	for _, prefix := range []bool{true, false} {
+		affix := chooseaffix(SlotHand, 1, prefix)
+		if affix == nil {
+			t.Fatalf(`chooseaffix(SlotHand, 1, %v) was nil`, prefix)
+		}
+		if !affix.fits(SlotHand, 1) {
+			t.Errorf(`chooseaffix(SlotHand, 1, %v) gave %v, which doesn't fit`, prefix, affix.Name)
+		}
+		if affix.Prefix != prefix {
+			t.Errorf(`chooseaffix(SlotHand, 1, %v) gave %v, with wrong prefix`, prefix, affix.Name)
+		}
+	}
+}
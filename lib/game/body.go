@@ -48,28 +48,48 @@ func (b *Body) Naked() bool {
 
 // Get the total bonus/malus to melee from equipment worn on this body.
 func (b *Body) Melee() int {
-	melee := 0
-	for _, equip := range b.all() {
-		melee += equip.Equipment.Melee
-	}
-	return melee
+	return b.meleemods().total()
 }
 
 // Get the total bonus/malus to evasion from equipment worn on this body.
 func (b *Body) Evasion() int {
-	evasion := 0
-	for _, equip := range b.all() {
-		evasion += equip.Equipment.Evasion
-	}
-	return evasion
+	return b.evasionmods().total()
 }
 
 func (b *Body) ProtDice() []Dice {
-	dice := make([]Dice, 0, numSlots)
-	for _, equip := range b.all() {
-		dice = append(dice, equip.Equipment.Protroll)
+	return protdice(b.protmods())
+}
+
+// The melee bonus/malus from each piece of equipment on this body.
+func (b *Body) meleemods() modlist {
+	mods := modlist{}
+	for _, equip := range b.worn() {
+		mods = mods.change(equipsource(equip), equip.Equipment.Melee)
 	}
-	return dice
+	return mods
+}
+
+// The evasion bonus/malus from each piece of equipment on this body.
+func (b *Body) evasionmods() modlist {
+	mods := modlist{}
+	for _, equip := range b.worn() {
+		mods = mods.change(equipsource(equip), equip.Equipment.Evasion)
+	}
+	return mods
+}
+
+// The protection dice from each piece of equipment on this body. Equipment
+// that doesn't protect, like most weapons, is left out.
+func (b *Body) protmods() []DiceMod {
+	mods := []DiceMod{}
+	for _, equip := range b.worn() {
+		prot := equip.Equipment.Protroll
+		if prot.Dice == 0 || prot.Sides == 0 {
+			continue
+		}
+		mods = append(mods, DiceMod{Source: equipsource(equip), Dice: prot})
+	}
+	return mods
 }
 
 func (b *Body) Weapon() *Obj {
@@ -89,6 +109,18 @@ func (b *Body) ArmorEffects() Effects {
 	return effects
 }
 
+// Return all the equipped stuff on this body in slot order, without all the
+// nil slots.
+func (b *Body) worn() []*Obj {
+	equips := make([]*Obj, 0, numSlots)
+	for _, equip := range b.Slots {
+		if equip != nil {
+			equips = append(equips, equip)
+		}
+	}
+	return equips
+}
+
 // Return a collection of all the equipped stuff on this body, without all the
 // nil slots.
 func (b *Body) all() map[Slot]*Obj {
@@ -100,3 +132,8 @@ func (b *Body) all() map[Slot]*Obj {
 	}
 	return equips
 }
+
+// Modifiers from equipment are tagged with the name of the item.
+func equipsource(equip *Obj) ModSource {
+	return ModSource{Kind: ModEquipment, Name: equip.Describe()}
+}
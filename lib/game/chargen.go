@@ -0,0 +1,199 @@
+package game
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// How many points the player gets to spend on stats when creating a
+	// character.
+	StatPoints = 13
+	// The highest a stat can be bought to, before racial modifiers.
+	MaxBoughtStat = 4
+	// How much XP a new character starts with. This is meant to be spent on
+	// skills from the character sheet before heading into the dungeon.
+	StartingXP = 5000
+	// Longest allowed character name.
+	MaxNameLen = 16
+)
+
+var (
+	ErrNoName      = errors.New("NoName")
+	ErrNoSuchRace  = errors.New("NoSuchRace")
+	ErrNoPoints    = errors.New("NoPoints")
+	ErrStatMaxed   = errors.New("StatMaxed")
+	ErrStatMinned  = errors.New("StatMinned")
+	ErrNotCreating = errors.New("NotCreating")
+)
+
+// What to tell the player when they try something during character creation
+// that they can't do.
+var chargenMessages = map[error]string{
+	ErrNoName:      "A hero needs a name.",
+	ErrNoSuchRace:  "There is no such people.",
+	ErrNoPoints:    "There aren't enough points left for that.",
+	ErrStatMaxed:   "That stat can't be raised any further.",
+	ErrStatMinned:  "That stat can't be lowered any further.",
+	ErrNotCreating: "No hero is being created.",
+}
+
+// A race or house that the player can be born into.
+type Race struct {
+	Name    string
+	Species Species
+	// A line of flavour text shown during character creation.
+	Desc string
+	// Added to the stats the player buys.
+	Stats statlist
+	// Skills this race has a knack for. These are applied as permanent skill
+	// mods.
+	Skills skilllist
+	// Innate resistances and other effects.
+	Effects Effects
+	// What a character of this race starts out carrying.
+	Kit []KitItem
+}
+
+// An item in a starting kit. 'Count' only matters for things that stack.
+type KitItem struct {
+	Spec  *Spec
+	Count int
+}
+
+// A character that is still being created.
+type Chargen struct {
+	Name   string
+	Race   *Race
+	bought statlist
+}
+
+func newChargen() *Chargen {
+	return &Chargen{Race: Races[0]}
+}
+
+// Sets the character's name. Surrounding whitespace is removed, and the name
+// is shouted to match the rest of the game's text.
+func (c *Chargen) SetName(name string) error {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if name == "" {
+		return ErrNoName
+	}
+	if runes := []rune(name); len(runes) > MaxNameLen {
+		name = string(runes[:MaxNameLen])
+	}
+	c.Name = name
+	return nil
+}
+
+// Picks the race at 'index' in Races.
+func (c *Chargen) SetRace(index int) error {
+	if index < 0 || index >= len(Races) {
+		return ErrNoSuchRace
+	}
+	c.Race = Races[index]
+	return nil
+}
+
+// How many points have been put into 'stat'.
+func (c *Chargen) Bought(stat StatName) int {
+	return c.bought[stat]
+}
+
+// What 'stat' will be once the character is created, i.e. the bought amount
+// plus racial modifiers.
+func (c *Chargen) Stat(stat StatName) int {
+	return c.bought[stat] + c.Race.Stats[stat]
+}
+
+// How many stat points are left to spend.
+func (c *Chargen) PointsLeft() int {
+	spent := 0
+	for _, v := range c.bought {
+		spent += statcost(v)
+	}
+	return StatPoints - spent
+}
+
+// How many points it costs to raise 'stat' by one.
+func (c *Chargen) NextCost(stat StatName) int {
+	cur := c.bought[stat]
+	return statcost(cur+1) - statcost(cur)
+}
+
+// Raise 'stat' by one point, if there's enough points left.
+func (c *Chargen) BuyStat(stat StatName) error {
+	if c.bought[stat] >= MaxBoughtStat {
+		return ErrStatMaxed
+	}
+	if c.NextCost(stat) > c.PointsLeft() {
+		return ErrNoPoints
+	}
+	c.bought[stat]++
+	return nil
+}
+
+// Lower 'stat' by one point, refunding its cost.
+func (c *Chargen) SellStat(stat StatName) error {
+	if c.bought[stat] <= 0 {
+		return ErrStatMinned
+	}
+	c.bought[stat]--
+	return nil
+}
+
+// The total cost of buying a stat up to 'level'. Each point costs one more
+// than the last.
+func statcost(level int) int {
+	return level * (level + 1) / 2
+}
+
+// Creates the player described by 'c', hands them their kit, and starts the
+// game proper on the first floor.
+func (g *Game) InitPlayer(c *Chargen) error {
+	if c == nil {
+		return ErrNotCreating
+	}
+	if c.Name == "" {
+		return ErrNoName
+	}
+
+	race := c.Race
+	spec := *PlayerSpec
+	spec.Name, spec.Species = c.Name, race.Species
+
+	player := g.NewObj(&spec)
+	sheet := player.Sheet.(*PlayerSheet)
+
+	racesrc := ModSource{Kind: ModRace, Name: race.Name}
+	for stat := Str; stat < NumStats; stat++ {
+		sheet.SetStat(stat, c.bought[stat])
+		sheet.ChangeStatMod(stat, racesrc, race.Stats[stat])
+	}
+	for skill := Melee; skill < NumSkills; skill++ {
+		sheet.ChangeSkillMod(skill, racesrc, race.Skills[skill])
+	}
+	sheet.innate = race.Effects
+	sheet.setHP(sheet.MaxHP())
+	sheet.setMP(sheet.MaxMP())
+
+	for _, kit := range race.Kit {
+		item := g.NewObj(kit.Spec)
+		if item.Stacks() {
+			item.Consumable.Count = kit.Count
+		}
+		if item.Equipment != nil {
+			player.Equipper.Body().Wear(item)
+		} else {
+			player.Packer.Inventory().Add(item)
+		}
+	}
+
+	player.Learner.(*ActorLearner).gainxp(StartingXP)
+
+	g.Player = player
+	g.Chargen = nil
+	g.Level = NewDungeon(g)
+	g.SwitchMode(ModeHud)
+	return nil
+}
@@ -0,0 +1,206 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestStartBeginsCharacterCreation(t *testing.T) {
+	g := NewGame()
+	g.Start()
+
+	if m := g.mode; m != ModeCreate {
+		t.Errorf(`Start() switched to mode %v, want %v`, m, ModeCreate)
+	}
+	if g.Chargen == nil {
+		t.Error(`Start() did not begin a Chargen`)
+	}
+}
+
+func TestChargenSetName(t *testing.T) {
+	c := newChargen()
+
+	if err := c.SetName("   "); err != ErrNoName {
+		t.Errorf(`SetName(blank) returned %v, want %v`, err, ErrNoName)
+	}
+	if err := c.SetName("  beren "); err != nil {
+		t.Errorf(`SetName("beren") returned %v, want nil`, err)
+	}
+	if n := c.Name; n != "BEREN" {
+		t.Errorf(`SetName("beren") set name %q, want %q`, n, "BEREN")
+	}
+	c.SetName("abcdefghijklmnopqrstuvwxyz")
+	if l := len(c.Name); l != MaxNameLen {
+		t.Errorf(`SetName(long) set name of length %d, want %d`, l, MaxNameLen)
+	}
+	c.SetName("éowyn of rohan, lady of ithilien")
+	if n, want := c.Name, "ÉOWYN OF ROHAN, "; n != want {
+		t.Errorf(`SetName(long non-ASCII) set name %q, want %q`, n, want)
+	}
+}
+
+func TestChargenSetRace(t *testing.T) {
+	c := newChargen()
+
+	if err := c.SetRace(len(Races)); err != ErrNoSuchRace {
+		t.Errorf(`SetRace(out of bounds) returned %v, want %v`, err, ErrNoSuchRace)
+	}
+	if err := c.SetRace(1); err != nil {
+		t.Errorf(`SetRace(1) returned %v, want nil`, err)
+	}
+	if r := c.Race; r != Races[1] {
+		t.Errorf(`SetRace(1) set race %v, want %v`, r.Name, Races[1].Name)
+	}
+}
+
+func TestChargenBuyStatCostsIncrease(t *testing.T) {
+	c := newChargen()
+
+	for i, want := range []int{1, 2, 3, 4} {
+		if cost := c.NextCost(Str); cost != want {
+			t.Errorf(`NextCost(Str) at %d was %d, want %d`, i, cost, want)
+		}
+		c.BuyStat(Str)
+	}
+	if left, want := c.PointsLeft(), StatPoints-10; left != want {
+		t.Errorf(`PointsLeft() after buying Str 4 was %d, want %d`, left, want)
+	}
+	if err := c.BuyStat(Str); err != ErrStatMaxed {
+		t.Errorf(`BuyStat(Str) past max returned %v, want %v`, err, ErrStatMaxed)
+	}
+}
+
+func TestChargenBuyStatWithoutPoints(t *testing.T) {
+	c := newChargen()
+
+	// 6 + 3 + 3 + 1 spends all 13 points.
+	buys := map[StatName]int{Str: 3, Agi: 2, Vit: 2, Mnd: 1}
+	for stat, n := range buys {
+		for i := 0; i < n; i++ {
+			c.BuyStat(stat)
+		}
+	}
+
+	if left := c.PointsLeft(); left != 0 {
+		t.Errorf(`PointsLeft() after spending everything was %d, want 0`, left)
+	}
+	if err := c.BuyStat(Mnd); err != ErrNoPoints {
+		t.Errorf(`BuyStat(Mnd) without points returned %v, want %v`, err, ErrNoPoints)
+	}
+}
+
+func TestChargenSellStat(t *testing.T) {
+	c := newChargen()
+
+	if err := c.SellStat(Agi); err != ErrStatMinned {
+		t.Errorf(`SellStat(Agi) at 0 returned %v, want %v`, err, ErrStatMinned)
+	}
+
+	c.BuyStat(Agi)
+	c.BuyStat(Agi)
+	c.SellStat(Agi)
+
+	if b := c.Bought(Agi); b != 1 {
+		t.Errorf(`Bought(Agi) after buying 2 and selling 1 was %d, want 1`, b)
+	}
+	if left, want := c.PointsLeft(), StatPoints-1; left != want {
+		t.Errorf(`PointsLeft() after selling was %d, want %d`, left, want)
+	}
+}
+
+func TestInitPlayerNeedsName(t *testing.T) {
+	g := NewGame()
+	g.Start()
+
+	if err := g.InitPlayer(g.Chargen); err != ErrNoName {
+		t.Errorf(`InitPlayer() without name returned %v, want %v`, err, ErrNoName)
+	}
+	if m := g.mode; m != ModeCreate {
+		t.Errorf(`InitPlayer() without name switched to mode %v, want %v`, m, ModeCreate)
+	}
+}
+
+func TestInitPlayer(t *testing.T) {
+	g := NewGame()
+	g.Start()
+
+	c := g.Chargen
+	c.SetName("Thorin")
+	c.SetRace(2)
+	c.BuyStat(Vit)
+	race := c.Race
+
+	if err := g.InitPlayer(c); err != nil {
+		t.Fatalf(`InitPlayer() returned %v, want nil`, err)
+	}
+
+	p := g.Player
+	if n := p.Spec.Name; n != "THORIN" {
+		t.Errorf(`Player was named %q, want %q`, n, "THORIN")
+	}
+	if s := p.Spec.Species; s != race.Species {
+		t.Errorf(`Player species was %v, want %v`, s, race.Species)
+	}
+	if PlayerSpec.Name == "THORIN" {
+		t.Error(`InitPlayer() changed PlayerSpec`)
+	}
+	if v, want := p.Sheet.Stat(Vit), 1+race.Stats[Vit]; v != want {
+		t.Errorf(`Player Vit was %d, want %d`, v, want)
+	}
+	if v := p.Sheet.UnmodStat(Vit); v != 1 {
+		t.Errorf(`Player base Vit was %d, want 1`, v)
+	}
+	racesrc := ModSource{Kind: ModRace, Name: race.Name}
+	for stat := Str; stat < NumStats; stat++ {
+		found := race.Stats[stat] == 0
+		for _, mod := range p.Sheet.StatBreakdown(stat).Mods {
+			if mod.Source == racesrc && mod.Amount == race.Stats[stat] {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf(`StatBreakdown(%v) has no %s modifier`, stat, race.Name)
+		}
+	}
+	if hp, maxhp := p.Sheet.HP(), p.Sheet.MaxHP(); hp != maxhp {
+		t.Errorf(`Player started with %d/%d HP, want full`, hp, maxhp)
+	}
+	if r := p.Sheet.Defense().Effects.Resists(EffectBlind); r != 1 {
+		t.Errorf(`Player resists blindness %d, want 1`, r)
+	}
+	if p.Equipper.Body().Weapon() == nil {
+		t.Error(`Player did not start with a weapon equipped`)
+	}
+	if p.Packer.Inventory().Empty() {
+		t.Error(`Player started with an empty pack`)
+	}
+	if xp := p.Learner.XP(); xp != StartingXP {
+		t.Errorf(`Player started with %d XP, want %d`, xp, StartingXP)
+	}
+	if m := g.mode; m != ModeHud {
+		t.Errorf(`InitPlayer() switched to mode %v, want %v`, m, ModeHud)
+	}
+	if g.Chargen != nil {
+		t.Error(`InitPlayer() did not clear Chargen`)
+	}
+	if g.Level == nil {
+		t.Error(`InitPlayer() did not create a level`)
+	}
+}
+
+func TestCreateControllerReportsErrors(t *testing.T) {
+	g := NewGame()
+	g.Start()
+	for !g.Events.Empty() {
+		g.Events.Next()
+	}
+
+	g.Handle(SellStatCommand{Stat: Str})
+
+	want := chargenMessages[ErrStatMinned]
+	for !g.Events.Empty() {
+		if ev, ok := g.Events.Next().(MessageEvent); ok && ev.Text == want {
+			return
+		}
+	}
+	t.Errorf(`Selling an unbought stat didn't say %q`, want)
+}
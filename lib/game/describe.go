@@ -3,17 +3,158 @@ package game
 import (
 	"fmt"
 	"github.com/MichaelDiBernardo/srl/lib/math"
+	"strings"
 )
 
 func (species Species) Describe() string {
 	switch species {
 	case SpecHuman:
 		return "Human"
+	case SpecNoldor:
+		return "Noldor"
+	case SpecSindar:
+		return "Sindar"
+	case SpecNaugrim:
+		return "Naugrim"
+	case SpecEdain:
+		return "Edain"
 	default:
 		return "???"
 	}
 }
 
+// The short name of a stat, e.g. "STR".
+func (s StatName) Describe() string {
+	switch s {
+	case Str:
+		return "STR"
+	case Agi:
+		return "AGI"
+	case Vit:
+		return "VIT"
+	case Mnd:
+		return "MND"
+	default:
+		return "???"
+	}
+}
+
+// The name that the game uses for a skill, e.g. "FIGHT".
+func (s SkillName) Describe() string {
+	switch s {
+	case Melee:
+		return "FIGHT"
+	case Evasion:
+		return "DODGE"
+	case Shooting:
+		return "SHOOT"
+	case Stealth:
+		return "SNEAK"
+	case Chi:
+		return "CHI"
+	case Sense:
+		return "SENSE"
+	case Magic:
+		return "MAGIC"
+	case Song:
+		return "SONG"
+	default:
+		return "???"
+	}
+}
+
+// Describes an item for use in menus and messages. Stacks are prefixed with
+// their size, e.g. "3 CURE", and equipment includes its affixes.
+func (o *Obj) Describe() string {
+	if o.Equipment != nil {
+		return o.Equipment.Name()
+	}
+	if n := o.Count(); n > 1 {
+		return fmt.Sprintf("%d %s", n, o.Spec.Name)
+	}
+	return o.Spec.Name
+}
+
+// A short name for an effect, for use in breakdowns.
+func (e Effect) Describe() string {
+	switch e {
+	case BrandFire:
+		return "FIRE"
+	case BrandElec:
+		return "ELEC"
+	case BrandIce:
+		return "ICE"
+	case BrandPoison:
+		return "POISON"
+	case BrandAcid:
+		return "ACID"
+	case SlayPearl:
+		return "SLAY PEARL"
+	case SlayHunter:
+		return "SLAY HUNTER"
+	case SlayBattle:
+		return "SLAY BATTLE"
+	case SlayDispel:
+		return "SLAY DISPEL"
+	case EffectStun:
+		return "STUN"
+	case EffectPoison:
+		return "POISON"
+	case EffectCut:
+		return "CUT"
+	case EffectBlind:
+		return "BLIND"
+	case EffectConfuse:
+		return "CONFUSE"
+	case EffectPara:
+		return "PARALYZE"
+	case EffectPetrify:
+		return "PETRIFY"
+	case EffectVamp:
+		return "VAMP"
+	case EffectShatter:
+		return "SHATTER"
+	case EffectDrainStr:
+		return "DRAIN STR"
+	case EffectDrainAgi:
+		return "DRAIN AGI"
+	case EffectDrainVit:
+		return "DRAIN VIT"
+	case EffectDrainMnd:
+		return "DRAIN MND"
+	default:
+		return "???"
+	}
+}
+
+// Explains a blow in a single line, e.g.
+// "ORC 14 vs 9, 1 crit: 7-2 +3 FIRE = 8 [STUN]".
+func (ev CombatEvent) Describe() string {
+	desc := fmt.Sprintf("%s %d vs %d", ev.Attacker, ev.AtkRoll, ev.DefRoll)
+	if !ev.Hit() {
+		return desc + ": miss"
+	}
+	if ev.Crits > 0 {
+		desc += fmt.Sprintf(", %d crit", ev.Crits)
+	}
+	desc += fmt.Sprintf(": %d-%d", ev.DmgRoll, ev.ProtRoll)
+	for _, extra := range ev.Extras {
+		desc += fmt.Sprintf(" +%d %s", extra.Dealt, extra.Effect.Describe())
+		if extra.Dealt != extra.Raw {
+			desc += fmt.Sprintf("(%d)", extra.Raw)
+		}
+	}
+	desc += fmt.Sprintf(" = %d", ev.Damage)
+	if len(ev.Inflicted) > 0 {
+		names := make([]string, len(ev.Inflicted))
+		for i, effect := range ev.Inflicted {
+			names[i] = effect.Describe()
+		}
+		desc += " [" + strings.Join(names, ",") + "]"
+	}
+	return desc
+}
+
 func (atk Attack) Describe() string {
 	melee := fmt.Sprintf("%s%d", extrasign(atk.Melee), atk.Melee)
 	dam := ""
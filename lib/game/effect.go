@@ -118,6 +118,18 @@ func (e1 Effects) Merge(e2 Effects) Effects {
 	return merged
 }
 
+// Produces a new Effects that has everything in 'effects' except for 'effect'.
+// This does not mutate 'effects'.
+func (effects Effects) Without(effect Effect) Effects {
+	without := Effects{}
+	for k, v := range effects {
+		if k != effect {
+			without[k] = v
+		}
+	}
+	return without
+}
+
 // Given the amount of raw damage done by an effect 'effect', this figures out
 // how much damage should actually be done after resistances or vulnerabilities
 // to 'effect' are taken into account.
@@ -203,6 +215,26 @@ var (
 		},
 		Stacks: AEStackIgnore,
 	}
+	// Base MP regen that actors get every turn.
+	AEBaseRegenMP = ActiveEffect{
+		OnTick: func(e *ActiveEffect, t Ticker, diff int) bool {
+			sheet := t.Obj().Sheet
+			if sheet.MaxMP() <= 0 {
+				return false
+			}
+
+			e.Counter += sheet.Regen() * diff
+			delayPerMp := RegenPeriod * GetDelay(2) / sheet.MaxMP()
+			heal := e.Counter / delayPerMp
+
+			if heal > 0 {
+				sheet.HealMP(heal)
+				e.Counter -= heal * delayPerMp
+			}
+			return false
+		},
+		Stacks: AEStackIgnore,
+	}
 	AEPoison = ActiveEffect{
 		OnBegin: func(_ *ActiveEffect, t Ticker, prev int) {
 			var msg string
@@ -213,7 +245,7 @@ var (
 			}
 			t.Obj().Game.Events.Message(fmt.Sprintf(msg, t.Obj().Spec.Name))
 		},
-		OnTick: hpdecay,
+		OnTick: hpdecay("poison"),
 		OnEnd: func(_ *ActiveEffect, t Ticker) {
 			t.Obj().Game.Events.Message(fmt.Sprintf("%s recovers from poison.", t.Obj().Spec.Name))
 		},
@@ -229,7 +261,7 @@ var (
 			}
 			t.Obj().Game.Events.Message(fmt.Sprintf(msg, t.Obj().Spec.Name))
 		},
-		OnTick: hpdecay,
+		OnTick: hpdecay("bleeding"),
 		OnEnd: func(_ *ActiveEffect, t Ticker) {
 			t.Obj().Game.Events.Message(fmt.Sprintf("%s is healed from wounds.", t.Obj().Spec.Name))
 		},
@@ -389,14 +421,14 @@ var (
 			msg := fmt.Sprintf("%s feels a rush!", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
 			if prev == 0 {
-				modAllSkills(t.Obj().Sheet, 2)
+				modAllSkills(t.Obj().Sheet, SourceStim, 2)
 			}
 		},
 		OnTick: basictick,
 		OnEnd: func(_ *ActiveEffect, t Ticker) {
 			msg := fmt.Sprintf("%s feels the rush wear off.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			modAllSkills(t.Obj().Sheet, -2)
+			modAllSkills(t.Obj().Sheet, SourceStim, -2)
 		},
 		Stacks: AEStackReplace,
 	}
@@ -405,14 +437,14 @@ var (
 			msg := fmt.Sprintf("%s is supercharged!", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
 			if prev == 0 {
-				modAllStats(t.Obj().Sheet, 2)
+				modAllStats(t.Obj().Sheet, SourceHyper, 2)
 			}
 		},
 		OnTick: basictick,
 		OnEnd: func(_ *ActiveEffect, t Ticker) {
 			msg := fmt.Sprintf("%s feels the supercharge wear off.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			modAllStats(t.Obj().Sheet, -2)
+			modAllStats(t.Obj().Sheet, SourceHyper, -2)
 		},
 		Stacks: AEStackReplace,
 	}
@@ -420,7 +452,7 @@ var (
 		OnBegin: func(_ *ActiveEffect, t Ticker, prev int) {
 			msg := fmt.Sprintf("%s loses strength.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Str, -1)
+			t.Obj().Sheet.ChangeStatMod(Str, SourceDrain, -1)
 		},
 		OnTick: func(_ *ActiveEffect, _ Ticker, _ int) bool {
 			return false
@@ -428,7 +460,7 @@ var (
 		OnEnd: func(ae *ActiveEffect, t Ticker) {
 			msg := fmt.Sprintf("%s regains strength.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Str, ae.Counter)
+			t.Obj().Sheet.ChangeStatMod(Str, SourceDrain, ae.Counter)
 		},
 		Stacks: AEStackAdd,
 	}
@@ -436,7 +468,7 @@ var (
 		OnBegin: func(_ *ActiveEffect, t Ticker, prev int) {
 			msg := fmt.Sprintf("%s loses agility.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Agi, -1)
+			t.Obj().Sheet.ChangeStatMod(Agi, SourceDrain, -1)
 		},
 		OnTick: func(_ *ActiveEffect, _ Ticker, _ int) bool {
 			return false
@@ -444,7 +476,7 @@ var (
 		OnEnd: func(ae *ActiveEffect, t Ticker) {
 			msg := fmt.Sprintf("%s regains agility.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Agi, ae.Counter)
+			t.Obj().Sheet.ChangeStatMod(Agi, SourceDrain, ae.Counter)
 		},
 		Stacks: AEStackAdd,
 	}
@@ -452,7 +484,7 @@ var (
 		OnBegin: func(_ *ActiveEffect, t Ticker, prev int) {
 			msg := fmt.Sprintf("%s loses vitality.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Vit, -1)
+			t.Obj().Sheet.ChangeStatMod(Vit, SourceDrain, -1)
 		},
 		OnTick: func(_ *ActiveEffect, _ Ticker, _ int) bool {
 			return false
@@ -460,7 +492,7 @@ var (
 		OnEnd: func(ae *ActiveEffect, t Ticker) {
 			msg := fmt.Sprintf("%s regains vitality.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Vit, ae.Counter)
+			t.Obj().Sheet.ChangeStatMod(Vit, SourceDrain, ae.Counter)
 		},
 		Stacks: AEStackAdd,
 	}
@@ -468,7 +500,7 @@ var (
 		OnBegin: func(_ *ActiveEffect, t Ticker, prev int) {
 			msg := fmt.Sprintf("%s loses mind.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Mnd, -1)
+			t.Obj().Sheet.ChangeStatMod(Mnd, SourceDrain, -1)
 		},
 		OnTick: func(_ *ActiveEffect, _ Ticker, _ int) bool {
 			return false
@@ -476,10 +508,16 @@ var (
 		OnEnd: func(ae *ActiveEffect, t Ticker) {
 			msg := fmt.Sprintf("%s regains mind.", t.Obj().Spec.Name)
 			t.Obj().Game.Events.Message(msg)
-			t.Obj().Sheet.ChangeStatMod(Mnd, ae.Counter)
+			t.Obj().Sheet.ChangeStatMod(Mnd, SourceDrain, ae.Counter)
 		},
 		Stacks: AEStackAdd,
 	}
+	// Sustains whatever song the actor is singing. Every song uses this.
+	AESong = ActiveEffect{
+		OnTick: songtick,
+		OnEnd:  songend,
+		Stacks: AEStackIgnore,
+	}
 )
 
 // An actor's stun level depends on how many turns of stun they've accumulated.
@@ -494,12 +532,16 @@ func getstunlevel(cstun int) StunLevel {
 	}
 }
 
-// Hurt a poisoned / cut actor.
-func hpdecay(e *ActiveEffect, t Ticker, _ int) bool {
-	dmg := math.Max(20*e.Counter/100, 1)
-	t.Obj().Sheet.Hurt(dmg)
-	e.Counter -= dmg
-	return e.Counter <= 0
+// Hurt a poisoned / cut actor. 'cause' is blamed if this kills the player.
+func hpdecay(cause string) func(*ActiveEffect, Ticker, int) bool {
+	return func(e *ActiveEffect, t Ticker, _ int) bool {
+		obj := t.Obj()
+		dmg := math.Max(20*e.Counter/100, 1)
+		obj.Game.blame(obj, cause)
+		obj.Sheet.Hurt(dmg)
+		e.Counter -= dmg
+		return e.Counter <= 0
+	}
 }
 
 func basictick(e *ActiveEffect, t Ticker, _ int) bool {
@@ -19,7 +19,8 @@ const (
 	SlayDispel
 
 	// Effects
-	EffectBaseRegen // Regen that is applied every tick to every actor.
+	EffectBaseRegen   // Regen that is applied every tick to every actor.
+	EffectBaseRegenMP // Same, but for MP.
 	EffectStun
 	EffectPoison
 	EffectCut
@@ -41,6 +42,11 @@ const (
 	EffectDrainVit
 	EffectDrainMnd
 
+	// Songs
+	EffectSongStaying
+	EffectSongSharpness
+	EffectSongSilence
+
 	// Resists
 	ResistFire
 	ResistElec
@@ -92,27 +98,32 @@ var EffectsSpecs = EffectsSpec{
 	SlayBattle: {Type: EffectTypeSlay, Slays: WeakBattle},
 	SlayDispel: {Type: EffectTypeSlay, Slays: WeakDispel},
 
-	EffectBaseRegen: {Type: EffectTypeStatus},
-	EffectStun:      {Type: EffectTypeStatus, ResistedBy: ResistStun},
-	EffectPoison:    {Type: EffectTypeStatus, ResistedBy: ResistPoison},
-	EffectCut:       {Type: EffectTypeStatus},
-	EffectBlind:     {Type: EffectTypeStatus, ResistedBy: ResistBlind},
-	EffectSlow:      {Type: EffectTypeStatus, ResistedBy: ResistSlow},
-	EffectConfuse:   {Type: EffectTypeStatus, ResistedBy: ResistConfuse},
-	EffectFear:      {Type: EffectTypeStatus, ResistedBy: ResistFear},
-	EffectPara:      {Type: EffectTypeStatus, ResistedBy: ResistPara},
-	EffectSilence:   {Type: EffectTypeStatus, ResistedBy: ResistSilence},
-	EffectCurse:     {Type: EffectTypeStatus, ResistedBy: ResistCurse},
-	EffectPetrify:   {Type: EffectTypeStatus, ResistedBy: ResistPetrify},
-	EffectBless:     {Type: EffectTypeStatus},
-	EffectStim:      {Type: EffectTypeStatus},
-	EffectHyper:     {Type: EffectTypeStatus},
-	EffectVamp:      {Type: EffectTypeStatus, ResistedBy: ResistVamp},
-	EffectShatter:   {Type: EffectTypeStatus},
-	EffectDrainStr:  {Type: EffectTypeStatus},
-	EffectDrainAgi:  {Type: EffectTypeStatus},
-	EffectDrainVit:  {Type: EffectTypeStatus},
-	EffectDrainMnd:  {Type: EffectTypeStatus},
+	EffectBaseRegen:   {Type: EffectTypeStatus},
+	EffectBaseRegenMP: {Type: EffectTypeStatus},
+	EffectStun:        {Type: EffectTypeStatus, ResistedBy: ResistStun},
+	EffectPoison:      {Type: EffectTypeStatus, ResistedBy: ResistPoison},
+	EffectCut:         {Type: EffectTypeStatus},
+	EffectBlind:       {Type: EffectTypeStatus, ResistedBy: ResistBlind},
+	EffectSlow:        {Type: EffectTypeStatus, ResistedBy: ResistSlow},
+	EffectConfuse:     {Type: EffectTypeStatus, ResistedBy: ResistConfuse},
+	EffectFear:        {Type: EffectTypeStatus, ResistedBy: ResistFear},
+	EffectPara:        {Type: EffectTypeStatus, ResistedBy: ResistPara},
+	EffectSilence:     {Type: EffectTypeStatus, ResistedBy: ResistSilence},
+	EffectCurse:       {Type: EffectTypeStatus, ResistedBy: ResistCurse},
+	EffectPetrify:     {Type: EffectTypeStatus, ResistedBy: ResistPetrify},
+	EffectBless:       {Type: EffectTypeStatus},
+	EffectStim:        {Type: EffectTypeStatus},
+	EffectHyper:       {Type: EffectTypeStatus},
+	EffectVamp:        {Type: EffectTypeStatus, ResistedBy: ResistVamp},
+	EffectShatter:     {Type: EffectTypeStatus},
+	EffectDrainStr:    {Type: EffectTypeStatus},
+	EffectDrainAgi:    {Type: EffectTypeStatus},
+	EffectDrainVit:    {Type: EffectTypeStatus},
+	EffectDrainMnd:    {Type: EffectTypeStatus},
+
+	EffectSongStaying:   {Type: EffectTypeStatus},
+	EffectSongSharpness: {Type: EffectTypeStatus},
+	EffectSongSilence:   {Type: EffectTypeStatus},
 
 	WeakPearl:  {Type: EffectTypeFlag},
 	WeakHunter: {Type: EffectTypeFlag},
@@ -139,23 +150,27 @@ var EffectsSpecs = EffectsSpec{
 
 // Prototype map for effects that are applied every tick.
 var ActiveEffects = map[Effect]ActiveEffect{
-	EffectBaseRegen: AEBaseRegen,
-	EffectPoison:    AEPoison,
-	EffectStun:      AEStun,
-	EffectCut:       AECut,
-	EffectBlind:     AEBlind,
-	EffectSlow:      AESlow,
-	EffectConfuse:   AEConfuse,
-	EffectFear:      AEFear,
-	EffectPara:      AEPara,
-	EffectSilence:   AESilence,
-	EffectCurse:     AECurse,
-	EffectStim:      AEStim,
-	EffectHyper:     AEHyper,
-	EffectPetrify:   AEPetrify,
-	EffectShatter:   AECorrode,
-	EffectDrainStr:  AEDrainStr,
-	EffectDrainAgi:  AEDrainAgi,
-	EffectDrainVit:  AEDrainVit,
-	EffectDrainMnd:  AEDrainMnd,
+	EffectBaseRegen:     AEBaseRegen,
+	EffectBaseRegenMP:   AEBaseRegenMP,
+	EffectPoison:        AEPoison,
+	EffectStun:          AEStun,
+	EffectCut:           AECut,
+	EffectBlind:         AEBlind,
+	EffectSlow:          AESlow,
+	EffectConfuse:       AEConfuse,
+	EffectFear:          AEFear,
+	EffectPara:          AEPara,
+	EffectSilence:       AESilence,
+	EffectCurse:         AECurse,
+	EffectStim:          AEStim,
+	EffectHyper:         AEHyper,
+	EffectPetrify:       AEPetrify,
+	EffectShatter:       AECorrode,
+	EffectDrainStr:      AEDrainStr,
+	EffectDrainAgi:      AEDrainAgi,
+	EffectDrainVit:      AEDrainVit,
+	EffectDrainMnd:      AEDrainMnd,
+	EffectSongStaying:   AESong,
+	EffectSongSharpness: AESong,
+	EffectSongSilence:   AESong,
 }
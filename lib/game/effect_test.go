@@ -527,13 +527,13 @@ func TestActiveCorrode(t *testing.T) {
 	obj.Ticker.AddEffect(EffectShatter, 1)
 
 	if corr, want := obj.Sheet.Corrosion(), 1; corr != want {
-		t.Error(`obj.Sheet.Corrosion() was %d, want %d`, corr, want)
+		t.Errorf(`obj.Sheet.Corrosion() was %d, want %d`, corr, want)
 	}
 
 	obj.Ticker.AddEffect(EffectShatter, 1)
 
 	if corr, want := obj.Sheet.Corrosion(), 2; corr != want {
-		t.Error(`obj.Sheet.Corrosion() was %d, want %d`, corr, want)
+		t.Errorf(`obj.Sheet.Corrosion() was %d, want %d`, corr, want)
 	}
 
 	obj.Ticker.Tick(0)
@@ -0,0 +1,94 @@
+package game
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+// Starts the player exploring the level. If 'pickup' is set, they'll pick up
+// any items they find along the way. No time passes until Handle takes the
+// first step.
+func (g *Game) explore(pickup bool) {
+	p := g.Player
+	if !g.canAutoMove() {
+		return
+	}
+	if p.Sheet.Blind() {
+		g.Events.Message(fmt.Sprintf("%v can't see to explore.", p.Spec.Name))
+		return
+	}
+	seen := visibleMonsters(p)
+	if len(seen) != 0 {
+		g.Events.Message(fmt.Sprintf("%v can't explore with enemies in view.", p.Spec.Name))
+		return
+	}
+
+	g.auto = &autoMove{
+		kind:    autoExplore,
+		seen:    seen,
+		pickup:  pickup,
+		visited: map[math.Point]bool{},
+		// Anything the player is standing on, they've already chosen to leave.
+		tried: map[math.Point]int{p.Pos(): p.Tile.Items.Len()},
+	}
+}
+
+// Takes the next step towards the nearest part of the level the player hasn't
+// seen yet, picking up whatever's here first if we're doing that.
+func (a *autoMove) explore(g *Game) (Command, bool) {
+	p := g.Player
+	l, pos := p.Level, p.Pos()
+
+	if n := l.At(pos).Items.Len(); a.pickup && n != 0 && a.tried[pos] != n {
+		a.tried[pos] = n
+		return autoPickupCommand{}, true
+	}
+	a.visited[pos] = true
+
+	flow := l.FlowMap(a.targets(l), PathCost, explorable)
+	if flow.At(pos) == FlowUnreachable {
+		g.Events.Message("Everything within reach is explored.")
+		return nil, false
+	}
+
+	// Head downhill towards the nearest target.
+	next, ok := flow.Downhill(l, pos)
+	if !ok || l.At(next).Actor != nil {
+		return nil, false
+	}
+	return MoveCommand{Dir: next.Sub(pos)}, true
+}
+
+// Where exploring wants to go: the edges of what the player has seen, and any
+// items they've seen if we're picking things up. Anywhere we've already been
+// is left out, so that an edge we can't see past doesn't hold us forever.
+func (a *autoMove) targets(l *Level) []math.Point {
+	targets := make([]math.Point, 0)
+	for _, row := range l.Map {
+		for _, tile := range row {
+			if a.visited[tile.Pos] || !explorable(tile) {
+				continue
+			}
+			items := a.pickup && !tile.Items.Empty() && tile.Items.Top().Seen
+			if items || frontier(l, tile) {
+				targets = append(targets, tile.Pos)
+			}
+		}
+	}
+	return targets
+}
+
+// Can exploring walk over 'tile'? Only if the player has seen it.
+func explorable(tile *Tile) bool {
+	return tile.Seen && patheligible(tile)
+}
+
+// Is 'tile' next to somewhere the player hasn't seen?
+func frontier(l *Level, tile *Tile) bool {
+	for _, n := range l.Around(tile.Pos) {
+		if !n.Seen {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+const exploreTestPic = `
+##############################
+#@                           #
+############################ #
+#                            #
+##############################`
+
+func TestExploreSeesEverything(t *testing.T) {
+	g := newRunTestGame(exploreTestPic)
+
+	g.Handle(ExploreCommand{})
+
+	for _, row := range g.Level.Map {
+		for _, tile := range row {
+			if !tile.Feature.Solid && !tile.Seen {
+				t.Errorf(`Tile %v was never seen`, tile.Pos)
+			}
+		}
+	}
+	if g.History.Search("explored", g.History.Len()) < 0 {
+		t.Error(`Exploring didn't say when everything was explored`)
+	}
+}
+
+func TestExplorePicksUpItems(t *testing.T) {
+	for _, pickup := range []bool{true, false} {
+		g := newRunTestGame(exploreTestPic)
+		g.Level.Place(g.NewObj(lTestItem), math.Pt(20, 3))
+		g.Handle(ExploreCommand{Pickup: pickup})
+
+		if got := g.Level.At(math.Pt(20, 3)).Items.Empty(); got != pickup {
+			t.Errorf(`Exploring with pickup=%v left floor empty=%v`, pickup, got)
+		}
+	}
+}
+
+func TestExploreStopsForMonsters(t *testing.T) {
+	g := newRunTestGame(exploreTestPic)
+	mon := g.NewObj(runTestMonster)
+	g.Level.Place(mon, math.Pt(10, 3))
+
+	g.Handle(ExploreCommand{})
+
+	if !g.Player.Senser.CanSee(mon) {
+		t.Errorf(`Exploring stopped at %v without seeing the monster`, g.Player.Pos())
+	}
+	if g.Level.At(math.Pt(1, 3)).Seen {
+		t.Error(`Exploring kept going after seeing a monster`)
+	}
+
+	// Now that it's in view, we shouldn't start exploring again.
+	pos := g.Player.Pos()
+	g.Handle(ExploreCommand{})
+	if g.Player.Pos() != pos {
+		t.Error(`Started exploring with a monster in view`)
+	}
+}
@@ -7,4 +7,42 @@ var (
 	FeatOpenDoor   = &Feature{Type: "FeatOpenDoor", Solid: false, Opaque: false}
 	FeatStairsUp   = &Feature{Type: "FeatStairsUp", Solid: false, Opaque: false}
 	FeatStairsDown = &Feature{Type: "FeatStairsDown", Solid: false, Opaque: false}
+	// Looks and acts just like a wall until someone finds it, unless they
+	// already know it's there.
+	FeatSecretDoor = &Feature{Type: "FeatSecretDoor", Solid: true, Opaque: true, Looks: FeatWall, Found: FeatClosedDoor}
 )
+
+// Terrain that's harder or more dangerous to cross than floor. See Hazard for
+// what each one does to whoever moves onto it. Rubble is cleared away by the
+// first one to climb over it.
+var (
+	FeatShallowWater = &Feature{Type: "FeatShallowWater", Solid: false, Opaque: false, MoveCost: 2, Hazard: HazardShallowWater}
+	FeatDeepWater    = &Feature{Type: "FeatDeepWater", Solid: false, Opaque: false, MoveCost: 2, Hazard: HazardDeepWater}
+	FeatRubble       = &Feature{Type: "FeatRubble", Solid: false, Opaque: false, MoveCost: 3, Cleared: FeatFloor}
+	FeatChasm        = &Feature{Type: "FeatChasm", Solid: false, Opaque: false, Hazard: HazardChasm}
+	FeatLava         = &Feature{Type: "FeatLava", Solid: false, Opaque: false, Hazard: HazardLava}
+)
+
+// Traps, once they've been found.
+var (
+	FeatPit          = &Feature{Type: "FeatPit", Solid: false, Opaque: false, Trap: TrapPit}
+	FeatTrapDoor     = &Feature{Type: "FeatTrapDoor", Solid: false, Opaque: false, Trap: TrapTrapDoor}
+	FeatSiren        = &Feature{Type: "FeatSiren", Solid: false, Opaque: false, Trap: TrapSiren}
+	FeatTeleportRune = &Feature{Type: "FeatTeleportRune", Solid: false, Opaque: false, Trap: TrapTeleport}
+	FeatDartTrap     = &Feature{Type: "FeatDartTrap", Solid: false, Opaque: false, Trap: TrapDart}
+	FeatWeb          = &Feature{Type: "FeatWeb", Solid: false, Opaque: false, Trap: TrapWeb}
+)
+
+// Traps that haven't been found yet. They look just like floor.
+var (
+	FeatHiddenPit          = hiddentrap("FeatHiddenPit", FeatPit)
+	FeatHiddenTrapDoor     = hiddentrap("FeatHiddenTrapDoor", FeatTrapDoor)
+	FeatHiddenSiren        = hiddentrap("FeatHiddenSiren", FeatSiren)
+	FeatHiddenTeleportRune = hiddentrap("FeatHiddenTeleportRune", FeatTeleportRune)
+	FeatHiddenDartTrap     = hiddentrap("FeatHiddenDartTrap", FeatDartTrap)
+	FeatHiddenWeb          = hiddentrap("FeatHiddenWeb", FeatWeb)
+)
+
+func hiddentrap(t FeatureType, found *Feature) *Feature {
+	return &Feature{Type: t, Solid: false, Opaque: false, Trap: found.Trap, Looks: FeatFloor, Found: found}
+}
@@ -0,0 +1,285 @@
+package game
+
+import (
+	"container/heap"
+	num "math"
+
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+// The distance a flow map gives to tiles that can't reach any of its sources.
+const FlowUnreachable = num.MaxInt32
+
+// How much fleeing monsters care about ending up far from the player, compared
+// to getting away from them quickly, in tenths. Anything over 10 means they'll
+// sometimes run past the player to get somewhere further away instead of into
+// a dead end.
+const fleeFactor = 12
+
+// A flow map (also known as a "Dijkstra map") says how far every tile on a
+// level is from the nearest of some set of sources. Each tile's distance
+// includes the cost of moving onto it, so following the map downhill takes
+// the cheapest way to the nearest source. Since one map works from anywhere,
+// everyone heading for the same places can share it.
+type FlowMap struct {
+	bounds math.Rectangle
+	// Distances for every tile, row by row.
+	dist []int
+	// The turn this map was built on.
+	turn int
+}
+
+// A tile that a flow map starts from, and how far it is from itself. This is
+// normally 0, but can be anything as long as it's not FlowUnreachable.
+type flowSeed struct {
+	pos  math.Point
+	dist int
+}
+
+// How far 'p' is from the nearest source, or FlowUnreachable if it can't get
+// to any of them.
+func (f *FlowMap) At(p math.Point) int {
+	if !f.bounds.HasPoint(p) {
+		return FlowUnreachable
+	}
+	return f.dist[f.index(p)]
+}
+
+// The neighbour of 'from' that's furthest downhill, or false if there's
+// nowhere lower to go.
+func (f *FlowMap) Downhill(l *Level, from math.Point) (math.Point, bool) {
+	best, bestdist := from, f.At(from)
+	for _, tile := range l.Around(from) {
+		if d := f.At(tile.Pos); d < bestdist {
+			best, bestdist = tile.Pos, d
+		}
+	}
+	return best, best != from
+}
+
+// Follows the map downhill from 'from' until there's nowhere lower to go.
+// Returns false if 'from' can't reach any source.
+func (f *FlowMap) PathFrom(l *Level, from math.Point) (Path, bool) {
+	path := make(Path, 0)
+	if f.At(from) == FlowUnreachable {
+		return path, false
+	}
+	for cur, ok := f.Downhill(l, from); ok; cur, ok = f.Downhill(l, cur) {
+		path = append(path, cur)
+	}
+	return path, true
+}
+
+func (f *FlowMap) index(p math.Point) int {
+	return (p.Y-f.bounds.Min.Y)*f.bounds.Width() + p.X - f.bounds.Min.X
+}
+
+// Builds a flow map from 'sources' over the tiles of 'l' that 'passable'
+// allows. Moving onto a tile costs whatever 'cost' says, just like FindPath.
+func (l *Level) FlowMap(sources []math.Point, cost func(*Level, math.Point) int, passable func(*Tile) bool) *FlowMap {
+	seeds := make([]flowSeed, 0, len(sources))
+	for _, src := range sources {
+		seeds = append(seeds, flowSeed{pos: src})
+	}
+	return l.flowFrom(seeds, cost, passable)
+}
+
+// Builds a flow map from seeds that may already have distances. This is
+// Dijkstra's algorithm started from every seed at once.
+func (l *Level) flowFrom(seeds []flowSeed, cost func(*Level, math.Point) int, passable func(*Tile) bool) *FlowMap {
+	f := &FlowMap{
+		bounds: l.Bounds,
+		dist:   make([]int, l.Bounds.Width()*l.Bounds.Height()),
+		turn:   l.turn(),
+	}
+	for i := range f.dist {
+		f.dist[i] = FlowUnreachable
+	}
+
+	todo := make(flowQ, 0, len(seeds))
+	for _, seed := range seeds {
+		if !seed.pos.In(l) || !passable(l.At(seed.pos)) {
+			continue
+		}
+		if i := f.index(seed.pos); seed.dist < f.dist[i] {
+			f.dist[i] = seed.dist
+			todo = append(todo, flowEntry{pos: seed.pos, dist: seed.dist})
+		}
+	}
+	heap.Init(&todo)
+
+	for todo.Len() != 0 {
+		cur := heap.Pop(&todo).(flowEntry)
+		// We may have found a shorter way here after this entry was queued.
+		if cur.dist > f.dist[f.index(cur.pos)] {
+			continue
+		}
+
+		for _, n := range l.Around(cur.pos) {
+			if !passable(n) {
+				continue
+			}
+			altdist := cur.dist + cost(l, n.Pos)
+			if i := f.index(n.Pos); altdist < f.dist[i] {
+				f.dist[i] = altdist
+				heap.Push(&todo, flowEntry{pos: n.Pos, dist: altdist})
+			}
+		}
+	}
+	return f
+}
+
+// How far everywhere is from the player, for 'obj'.
+func (l *Level) PlayerFlow(obj *Obj) *FlowMap {
+	return l.flowFor(obj, flowPlayer, func(passable func(*Tile) bool) *FlowMap {
+		return l.FlowMap([]math.Point{l.game.Player.Pos()}, PathCost, passable)
+	})
+}
+
+// How far everywhere is from the nearest stairs, for 'obj'.
+func (l *Level) StairsFlow(obj *Obj) *FlowMap {
+	return l.flowFor(obj, flowStairs, func(passable func(*Tile) bool) *FlowMap {
+		stairs := make([]math.Point, 0)
+		for _, row := range l.Map {
+			for _, tile := range row {
+				if tile.Feature == FeatStairsUp || tile.Feature == FeatStairsDown {
+					stairs = append(stairs, tile.Pos)
+				}
+			}
+		}
+		return l.FlowMap(stairs, PathCost, passable)
+	})
+}
+
+// A map that leads 'obj' away from the player. Each tile starts out as far
+// downhill as it is far from the player, and then the whole thing is rolled
+// out again so that the way to get far away flows around walls.
+func (l *Level) FleeFlow(obj *Obj) *FlowMap {
+	return l.flowFor(obj, flowFlee, func(passable func(*Tile) bool) *FlowMap {
+		toplayer := l.PlayerFlow(obj)
+		seeds := make([]flowSeed, 0, len(toplayer.dist))
+		for _, row := range l.Map {
+			for _, tile := range row {
+				if d := toplayer.At(tile.Pos); d != FlowUnreachable {
+					seeds = append(seeds, flowSeed{pos: tile.Pos, dist: -d * fleeFactor / 10})
+				}
+			}
+		}
+		return l.flowFrom(seeds, fleecost, passable)
+	})
+}
+
+// The flow maps that are shared by everyone on a level.
+type flowName int
+
+const (
+	flowPlayer flowName = iota
+	flowStairs
+	flowFlee
+)
+
+// How an actor gets around, as far as flow maps are concerned. Actors that
+// move by the same rules can share the same maps.
+type moveRules struct {
+	// Deep water is out of bounds.
+	sinks bool
+	// Every secret door on the level can be used.
+	doors bool
+}
+
+// The rules 'obj' moves by. This is false if 'obj' knows about some of the
+// level's secret doors but not others, since no shared map fits it then.
+func (l *Level) rulesfor(obj *Obj) (moveRules, bool) {
+	secrets, known := l.secretdoors(), 0
+	for _, door := range secrets {
+		if obj.Senser != nil && obj.Senser.Knows(door) {
+			known++
+		}
+	}
+	if known != 0 && known != len(secrets) {
+		return moveRules{}, false
+	}
+	return moveRules{sinks: obj.Spec.Sinks, doors: known != 0}, true
+}
+
+// Can someone who moves by 'r' go onto 't'? This is pathableby for everyone
+// with the same rules.
+func (r moveRules) passable(t *Tile) bool {
+	switch {
+	case t.Feature == FeatSecretDoor:
+		return r.doors
+	case t.Feature.Hazard == HazardDeepWater:
+		return !r.sinks
+	default:
+		return patheligible(t)
+	}
+}
+
+// What a shared map is cached under.
+type flowKey struct {
+	name  flowName
+	rules moveRules
+}
+
+// Gets the map called 'name' for 'obj'. Everyone who moves by the same rules
+// shares one map; anyone whose rules don't fit a shared map gets one of their
+// own. 'build' makes the map over the tiles that 'passable' allows.
+func (l *Level) flowFor(obj *Obj, name flowName, build func(passable func(*Tile) bool) *FlowMap) *FlowMap {
+	rules, ok := l.rulesfor(obj)
+	if !ok {
+		return build(pathableby(obj))
+	}
+	return l.cachedFlow(flowKey{name: name, rules: rules}, func() *FlowMap {
+		return build(rules.passable)
+	})
+}
+
+// Gets the shared map cached under 'key', building it if it hasn't been built
+// yet this turn. Everyone who asks during a turn gets the same map.
+func (l *Level) cachedFlow(key flowKey, build func() *FlowMap) *FlowMap {
+	if f := l.flows[key]; f != nil && f.turn == l.turn() {
+		return f
+	}
+	f := build()
+	l.flows[key] = f
+	return f
+}
+
+// The current turn of the game this level is in.
+func (l *Level) turn() int {
+	return l.game.Progress.Turns
+}
+
+// An entry in the flow map PQ. Entries carry their own distance, so a tile can
+// be in here more than once; stale entries are skipped when they come out.
+type flowEntry struct {
+	pos  math.Point
+	dist int
+}
+
+type flowQ []flowEntry
+
+// Implementing heap.Interface
+func (q flowQ) Len() int {
+	return len(q)
+}
+
+func (q flowQ) Less(i, j int) bool {
+	return q[i].dist < q[j].dist
+}
+
+func (q flowQ) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *flowQ) Push(x interface{}) {
+	*q = append(*q, x.(flowEntry))
+}
+
+func (q *flowQ) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[0 : n-1]
+	return x
+}
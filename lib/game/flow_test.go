@@ -0,0 +1,270 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+func TestFlowMapDistances(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#   #   #
+#   #####
+#########`)
+	l := g.Level
+
+	flow := l.FlowMap([]math.Point{math.Pt(1, 1), math.Pt(3, 2)}, PathCost, patheligible)
+
+	tests := []struct {
+		pos  math.Point
+		want int
+	}{
+		{math.Pt(1, 1), 0},
+		{math.Pt(3, 2), 0},
+		{math.Pt(2, 1), 1},
+		{math.Pt(3, 1), 1},
+		{math.Pt(1, 2), 1},
+		{math.Pt(5, 1), FlowUnreachable},
+		{math.Pt(0, 0), FlowUnreachable},
+		{math.Pt(-1, 5), FlowUnreachable},
+	}
+	for _, test := range tests {
+		if d := flow.At(test.pos); d != test.want {
+			t.Errorf(`flow.At(%v) was %d; want %d`, test.pos, d, test.want)
+		}
+	}
+}
+
+func TestFlowMapPathAvoidsDoors(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#  +  #
+## # ##
+##   ##
+#######`)
+	l := g.Level
+
+	flow := l.FlowMap([]math.Point{math.Pt(5, 1)}, PathCost, patheligible)
+	path, ok := flow.PathFrom(l, math.Pt(1, 1))
+
+	if !ok {
+		t.Fatal(`flow.PathFrom found no path`)
+	}
+	want := Path{math.Pt(2, 2), math.Pt(3, 3), math.Pt(4, 2), math.Pt(5, 1)}
+	if len(path) != len(want) {
+		t.Fatalf(`flow.PathFrom was %v; want %v`, path, want)
+	}
+	for i, pt := range want {
+		if path[i] != pt {
+			t.Errorf(`flow.PathFrom was %v; want %v`, path, want)
+			break
+		}
+	}
+}
+
+func TestSharedFlowsAreRebuiltEachTurn(t *testing.T) {
+	g := newRunTestGame(`
+######
+#@   #
+######`)
+	l := g.Level
+
+	mon := flowTestMonster(l, math.Pt(4, 1), false)
+
+	first := l.PlayerFlow(mon)
+	if again := l.PlayerFlow(mon); again != first {
+		t.Error(`PlayerFlow was rebuilt within a turn`)
+	}
+
+	g.Player.Mover.Move(math.Pt(1, 0))
+	g.Progress.Turns++
+
+	next := l.PlayerFlow(mon)
+	if next == first {
+		t.Fatal(`PlayerFlow was not rebuilt on a new turn`)
+	}
+	if d := next.At(math.Pt(2, 1)); d != 0 {
+		t.Errorf(`PlayerFlow at player was %d; want 0`, d)
+	}
+}
+
+func TestStairsFlow(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#@      #
+#########`)
+	l := g.Level
+	l.At(math.Pt(7, 1)).Feature = FeatStairsDown
+	mon := flowTestMonster(l, math.Pt(4, 1), false)
+
+	if d := l.StairsFlow(mon).At(math.Pt(1, 1)); d != 6 {
+		t.Errorf(`StairsFlow at player was %d; want 6`, d)
+	}
+}
+
+func TestFleeFlowLeadsAwayFromPlayer(t *testing.T) {
+	g := newRunTestGame(`
+###########
+#@        #
+###########`)
+	l := g.Level
+	flee := l.FleeFlow(flowTestMonster(l, math.Pt(4, 1), false))
+
+	next, ok := flee.Downhill(l, math.Pt(4, 1))
+	if want := math.Pt(5, 1); !ok || next != want {
+		t.Errorf(`Fleeing from %v went to %v; want %v`, math.Pt(4, 1), next, want)
+	}
+	if _, ok := flee.Downhill(l, math.Pt(9, 1)); ok {
+		t.Error(`Fleeing into a dead end should have nowhere further to go`)
+	}
+}
+
+func TestFleeFlowRunsPastPlayerToEscape(t *testing.T) {
+	// Running right gets stuck in a dead end, but running left past the player
+	// leads a lot further away.
+	g := newRunTestGame(`
+####################
+#               @  #
+#                  #
+####################`)
+	l := g.Level
+	from := math.Pt(17, 2)
+
+	next, _ := l.FleeFlow(flowTestMonster(l, from, false)).Downhill(l, from)
+	if next.X >= from.X {
+		t.Errorf(`Fleeing from %v went to %v; want towards the long way out`, from, next)
+	}
+}
+
+func TestFleeFlowFollowsMoveRules(t *testing.T) {
+	// The only way out of the dead end on the left is through the water.
+	g := newRunTestGame(`
+#########
+#   W  @#
+#########`)
+	l := g.Level
+	from := math.Pt(4, 1)
+	swimmer := flowTestMonster(l, from, false)
+	sinker := flowTestMonster(l, math.Pt(5, 1), true)
+
+	if next, _ := l.FleeFlow(swimmer).Downhill(l, from); next != math.Pt(3, 1) {
+		t.Errorf(`Swimmer fled from %v to %v; want %v`, from, next, math.Pt(3, 1))
+	}
+	if l.FleeFlow(sinker) == l.FleeFlow(swimmer) {
+		t.Error(`Sinker shares a flee map with a swimmer`)
+	}
+	if d := l.FleeFlow(sinker).At(math.Pt(2, 1)); d != FlowUnreachable {
+		t.Errorf(`Sinker's flee map reaches past deep water with %d`, d)
+	}
+}
+
+func TestFleeFlowFollowsKnownSecretDoors(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#@  S #
+### ###
+### S #
+#######`)
+	l := g.Level
+	top, bottom := l.At(math.Pt(4, 1)), l.At(math.Pt(4, 3))
+	native := flowTestMonster(l, math.Pt(3, 2), false)
+	native.Senser.Learn(top)
+	native.Senser.Learn(bottom)
+	outsider := flowTestMonster(l, math.Pt(2, 1), false)
+	halfway := flowTestMonster(l, math.Pt(3, 3), false)
+	halfway.Senser.Learn(bottom)
+
+	if d := l.FleeFlow(native).At(math.Pt(5, 1)); d == FlowUnreachable {
+		t.Error(`Monster that knows every secret door can't flee through them`)
+	}
+	if d := l.FleeFlow(outsider).At(math.Pt(5, 1)); d != FlowUnreachable {
+		t.Error(`Monster that knows no secret doors can flee through them`)
+	}
+	flee := l.FleeFlow(halfway)
+	if flee == l.FleeFlow(native) || flee == l.FleeFlow(outsider) {
+		t.Error(`Monster that knows some secret doors shares a flee map`)
+	}
+	if flee.At(math.Pt(5, 3)) == FlowUnreachable || flee.At(math.Pt(5, 1)) != FlowUnreachable {
+		t.Error(`Monster that knows one secret door doesn't flee through just that one`)
+	}
+}
+
+// Puts a monster at 'pos' on 'l' that sinks in deep water if 'sinks' is set.
+func flowTestMonster(l *Level, pos math.Point, sinks bool) *Obj {
+	spec := terrainTestMonster(false)
+	spec.Sinks = sinks
+	spec.Traits.Senser = NewActorSenser
+	mon := l.game.NewObj(spec)
+	l.Place(mon, pos)
+	return mon
+}
+
+// How many monsters each flow benchmark moves per turn.
+const flowBenchMonsters = 20
+
+// Sets up a full-size dungeon with the player and some monsters on it.
+func flowBenchSetup() (*Level, []*Obj) {
+	g := newTestGame()
+	g.Level = NewDungeon(g)
+	l := g.Level
+	l.Place(g.Player, l.RandomClearTile().Pos)
+
+	monsters := make([]*Obj, flowBenchMonsters)
+	for i := range monsters {
+		for monsters[i] == nil || monsters[i].Tile == nil {
+			monsters[i] = flowTestMonster(l, l.RandomClearTile().Pos, false)
+		}
+	}
+	return l, monsters
+}
+
+// The old way for every fleeing monster to find somewhere to run to.
+func BenchmarkFleeFindPath(b *testing.B) {
+	l, monsters := flowBenchSetup()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, mon := range monsters {
+			l.FindPathFor(mon, l.RandomClearTile().Pos, fleecost)
+		}
+	}
+}
+
+// Every fleeing monster sharing one flee map.
+func BenchmarkFleeFlow(b *testing.B) {
+	l, monsters := flowBenchSetup()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.game.Progress.Turns++
+		for _, mon := range monsters {
+			l.FleeFlow(mon).Downhill(l, mon.Pos())
+		}
+	}
+}
+
+// Every monster finding its own way to the player.
+func BenchmarkChaseFindPath(b *testing.B) {
+	l, monsters := flowBenchSetup()
+	player := l.game.Player.Pos()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, mon := range monsters {
+			l.FindPathFor(mon, player, PathCost)
+		}
+	}
+}
+
+// Every monster sharing one map to the player.
+func BenchmarkChaseFlow(b *testing.B) {
+	l, monsters := flowBenchSetup()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.game.Progress.Turns++
+		for _, mon := range monsters {
+			l.PlayerFlow(mon).Downhill(l, mon.Pos())
+		}
+	}
+}
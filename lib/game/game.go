@@ -13,7 +13,23 @@ type Game struct {
 	Level    *Level
 	Events   *EventQueue
 	Progress *Progress
-	mode     Mode
+	Uniques  *Uniques
+	// Every message sent to the player.
+	History *History
+	// The character being created. Only set while in ModeCreate.
+	Chargen *Chargen
+	// How the game ended. Nil until the player dies or wins.
+	Ending *Ending
+	// Every recorded game, including this one. Only set once the game is
+	// over and RecordScore has been called.
+	Scores *Scores
+	// Where this game ranks in Scores.Ranked(), from 0.
+	Placing int
+	mode    Mode
+	// Whatever last hurt the player, in case it kills them.
+	cause string
+	// The run, travel or exploration the player is in the middle of, if any.
+	auto *autoMove
 }
 
 type Progress struct {
@@ -43,24 +59,29 @@ func (p *Progress) ChangeFloor(dir int) bool {
 
 // Create a new game.
 func NewGame() *Game {
+	progress := &Progress{
+		Floor:     1,
+		PrevFloor: 1,
+		MaxFloor:  1,
+		Turns:     0,
+	}
+	history := newHistory(MaxHistory, progress)
+	events := newEventQueue()
+	events.history = history
+
 	return &Game{
-		Events: newEventQueue(),
-		Progress: &Progress{
-			Floor:     1,
-			PrevFloor: 1,
-			MaxFloor:  1,
-			Turns:     0,
-		},
+		Events:   events,
+		Progress: progress,
+		Uniques:  newUniques(),
+		History:  history,
 	}
 }
 
-// Temp convenience method to init the game before playing.
+// Start a new game by creating a character. The game proper starts once
+// InitPlayer is called.
 func (g *Game) Start() {
-	g.mode = ModeHud
-	g.Player = g.NewObj(PlayerSpec)
-	// TODO: We need an InitPlayer
-	g.Player.Learner.(*ActorLearner).gainxp(5000)
-	g.Level = NewDungeon(g)
+	g.Chargen = newChargen()
+	g.SwitchMode(ModeCreate)
 }
 
 // Create a new object for use in this game.
@@ -70,8 +91,26 @@ func (g *Game) NewObj(spec *Spec) *Obj {
 	return obj
 }
 
-// Handle a command from the client, and then evolve the world.
+// Handle a command from the client, and then evolve the world. If the command
+// starts a run, travel or exploration, the player keeps moving one step at a
+// time, with everyone else getting their turns in between, until they stop or
+// are disturbed.
 func (g *Game) Handle(c Command) {
+	g.step(c)
+	for g.auto != nil && g.mode == ModeHud {
+		next, ok := g.auto.next(g)
+		// If a step doesn't take any time, something got in the way, and
+		// trying again won't help.
+		if !ok || !g.step(next) {
+			break
+		}
+	}
+	g.Disturb()
+}
+
+// Handle a single command, and evolve the world if it took the player's turn.
+// Returns true if it did.
+func (g *Game) step(c Command) bool {
 	evolve := controllers[g.mode](g, c)
 	if evolve {
 		for {
@@ -84,6 +123,7 @@ func (g *Game) Handle(c Command) {
 			}
 		}
 	}
+	return evolve
 }
 
 func (g *Game) SwitchMode(m Mode) {
@@ -94,16 +134,22 @@ func (g *Game) SwitchMode(m Mode) {
 
 func (g *Game) Kill(actor *Obj) {
 	if actor.IsPlayer() {
+		g.Ending = &Ending{Cause: g.cause}
 		g.Events.Message("The quest for the TOWER ends...")
 		g.Events.More()
 		g.SwitchMode(ModeGameOver)
 	} else {
+		g.Uniques.markKilled(actor.Spec)
 		g.Level.Remove(actor)
 	}
 }
 
-// Switch floors on the player.
+// Switch floors on the player. Climbing past the top floor wins the game.
 func (g *Game) ChangeFloor(dir int) {
+	if g.Progress.Floor+dir > MaxFloor {
+		g.win()
+		return
+	}
 	isNewMax := g.Progress.ChangeFloor(dir)
 	if isNewMax {
 		g.Player.Learner.GainXPFloor(g.Progress.Floor)
@@ -111,6 +157,22 @@ func (g *Game) ChangeFloor(dir int) {
 	g.Level = NewDungeon(g)
 }
 
+// End the game with the player victorious.
+func (g *Game) win() {
+	g.Ending = &Ending{Won: true}
+	g.Events.Message("The TOWER is conquered!")
+	g.Events.More()
+	g.SwitchMode(ModeGameOver)
+}
+
+// Remember that 'cause' just hurt 'victim', so that we can say what killed the
+// player if it comes to that. 'cause' is a noun like "ORC" or "poison".
+func (g *Game) blame(victim *Obj, cause string) {
+	if victim.IsPlayer() {
+		g.cause = cause
+	}
+}
+
 // A command given _to_ the game.
 type Command interface{}
 
@@ -118,8 +180,27 @@ type QuitCommand struct{}
 
 type MoveCommand struct{ Dir math.Point }
 
+type RunCommand struct{ Dir math.Point }
+
+type TravelCommand struct{ Dest math.Point }
+
+type TravelStairsCommand struct{}
+
+// Explores the level until there's nothing left or something interesting
+// happens. If Pickup is set, items found along the way are picked up.
+type ExploreCommand struct{ Pickup bool }
+
+// Picks up the top item on the floor. Only exploring sends this.
+type autoPickupCommand struct{}
+
 type RestCommand struct{}
 
+// Takes a turn to look around for hidden traps and secret doors.
+type SearchCommand struct{}
+
+// Tries to disarm a trap next to the player.
+type DisarmCommand struct{ Dir math.Point }
+
 type TryPickupCommand struct{}
 
 type TryDropCommand struct{}
@@ -130,9 +211,20 @@ type TryRemoveCommand struct{}
 
 type TryUseCommand struct{}
 
+type TryCastCommand struct{}
+
+type TrySingCommand struct{}
+
+type StopSingingCommand struct{}
+
 type ModeCommand struct{ Mode Mode }
 
-type MenuCommand struct{ Option int }
+// Selects an option from a menu. If the option is a stack of items, Quantity
+// says how many of them to act on; 0 means the whole stack.
+type MenuCommand struct {
+	Option   int
+	Quantity int
+}
 
 type AscendCommand struct{}
 
@@ -148,6 +240,20 @@ type LearnSkillCommand struct{ Skill SkillName }
 
 type UnlearnSkillCommand struct{ Skill SkillName }
 
+type LearnAbilityCommand struct{ Ability AbilityName }
+
+type UnlearnAbilityCommand struct{ Ability AbilityName }
+
+type SetNameCommand struct{ Name string }
+
+type ChooseRaceCommand struct{ Race int }
+
+type BuyStatCommand struct{ Stat StatName }
+
+type SellStatCommand struct{ Stat StatName }
+
+type FinishCreationCommand struct{}
+
 type NoCommand struct{}
 
 // A controller is a function that handles 'command' using 'game', and returns
@@ -167,6 +273,11 @@ var controllers = map[Mode]controller{
 	ModeRemove:    removeController,
 	ModeDrop:      dropController,
 	ModeSheet:     sheetController,
+	ModeCast:      castController,
+	ModeSing:      singController,
+	ModeHistory:   historyController,
+	ModeOptions:   optionsController,
+	ModeCreate:    createController,
 }
 
 // Do stuff when player is actually playing the game.
@@ -176,9 +287,24 @@ func hudController(g *Game, com Command) bool {
 	case MoveCommand:
 		ok, _ := g.Player.Mover.Move(c.Dir)
 		evolve = ok
+	case RunCommand:
+		evolve = g.run(c.Dir)
+	case TravelCommand:
+		g.travel(c.Dest)
+	case TravelStairsCommand:
+		g.travelStairs()
+	case ExploreCommand:
+		g.explore(c.Pickup)
+	case autoPickupCommand:
+		evolve = g.Player.Packer.Pickup(0, 0)
 	case RestCommand:
 		g.Player.Mover.Rest()
 		evolve = true
+	case SearchCommand:
+		g.Player.Senser.Search(SearchRadius, SearchBonus)
+		evolve = true
+	case DisarmCommand:
+		evolve = g.Player.Mover.Disarm(c.Dir)
 	case TryPickupCommand:
 		evolve = g.Player.Packer.TryPickup()
 	case TryDropCommand:
@@ -189,6 +315,12 @@ func hudController(g *Game, com Command) bool {
 		g.Player.Equipper.TryRemove()
 	case TryUseCommand:
 		g.Player.User.TryUse()
+	case TryCastCommand:
+		g.Player.Caster.TryCast()
+	case TrySingCommand:
+		g.Player.Singer.TrySing()
+	case StopSingingCommand:
+		evolve = g.Player.Singer.StopSinging()
 	case AscendCommand:
 		g.Player.Mover.Ascend()
 		evolve = true
@@ -217,7 +349,7 @@ func pickupController(g *Game, com Command) bool {
 	case ModeCommand:
 		g.SwitchMode(c.Mode)
 	case MenuCommand:
-		evolve = g.Player.Packer.Pickup(c.Option)
+		evolve = g.Player.Packer.Pickup(c.Option, c.Quantity)
 	}
 	return evolve
 }
@@ -246,6 +378,49 @@ func useController(g *Game, com Command) bool {
 	return evolve
 }
 
+// Do stuff when player is choosing a spell to cast.
+func castController(g *Game, com Command) bool {
+	evolve := false
+	switch c := com.(type) {
+	case ModeCommand:
+		g.SwitchMode(c.Mode)
+	case MenuCommand:
+		evolve = g.Player.Caster.Cast(c.Option)
+	}
+	return evolve
+}
+
+// Do stuff when player is choosing a song to sing.
+func singController(g *Game, com Command) bool {
+	evolve := false
+	switch c := com.(type) {
+	case ModeCommand:
+		g.SwitchMode(c.Mode)
+	case MenuCommand:
+		evolve = g.Player.Singer.Sing(c.Option)
+	}
+	return evolve
+}
+
+// Do stuff when player is reading old messages.
+func historyController(g *Game, com Command) bool {
+	switch c := com.(type) {
+	case ModeCommand:
+		g.SwitchMode(c.Mode)
+	}
+	return false
+}
+
+// Do stuff when player is changing options. The client looks after the options
+// themselves, so all we need to do is leave.
+func optionsController(g *Game, com Command) bool {
+	switch c := com.(type) {
+	case ModeCommand:
+		g.SwitchMode(c.Mode)
+	}
+	return false
+}
+
 // Do stuff when player is looking at body.
 func removeController(g *Game, com Command) bool {
 	evolve := false
@@ -270,7 +445,7 @@ func dropController(g *Game, com Command) bool {
 	case ModeCommand:
 		g.SwitchMode(c.Mode)
 	case MenuCommand:
-		evolve = g.Player.Packer.Drop(c.Option)
+		evolve = g.Player.Packer.Drop(c.Option, c.Quantity)
 	}
 	return evolve
 }
@@ -300,21 +475,60 @@ func sheetController(g *Game, com Command) bool {
 	case UnlearnSkillCommand:
 		change, _ := g.Player.Learner.UnlearnSkill(c.Skill)
 		g.Events.SkillChange(change)
+	case LearnAbilityCommand:
+		change, _ := g.Player.Learner.LearnAbility(c.Ability)
+		g.Events.SkillChange(change)
+	case UnlearnAbilityCommand:
+		change, _ := g.Player.Learner.UnlearnAbility(c.Ability)
+		g.Events.SkillChange(change)
 	case ModeCommand:
 		g.SwitchMode(c.Mode)
 	}
 	return false
 }
 
+// Do stuff when player is creating a character.
+func createController(g *Game, com Command) bool {
+	c := g.Chargen
+	var err error
+	switch com := com.(type) {
+	case SetNameCommand:
+		err = c.SetName(com.Name)
+	case ChooseRaceCommand:
+		err = c.SetRace(com.Race)
+	case BuyStatCommand:
+		err = c.BuyStat(com.Stat)
+	case SellStatCommand:
+		err = c.SellStat(com.Stat)
+	case FinishCreationCommand:
+		err = g.InitPlayer(c)
+	}
+	if err != nil {
+		g.Events.Message(chargenMessages[err])
+	}
+	return false
+}
+
 // Events are complex objects (unlike commands); you have to type-assert them
 // to their concrete types to get at their payloads.
 type Event interface{}
 
-// A message that we want to show up in the message console.
+// A message that we want to show up in the message console. 'Text' may contain
+// markup; see markup.go.
 type MessageEvent struct {
 	Text string
 }
 
+// The message split up into styled spans.
+func (ev MessageEvent) Spans() []Span {
+	return ParseMarkup(ev.Text)
+}
+
+// The message with all of its markup stripped.
+func (ev MessageEvent) Plain() string {
+	return PlainText(ev.Text)
+}
+
 // Force the player to --more--.
 type MoreEvent struct {
 }
@@ -336,6 +550,11 @@ const (
 	ModeDrop
 	ModeUse
 	ModeSheet
+	ModeCast
+	ModeSing
+	ModeHistory
+	ModeOptions
+	ModeCreate
 	ModeGameOver
 )
 
@@ -349,6 +568,8 @@ type ModeEvent struct {
 // the game needs to send; nothing pushes directly to the queue.
 type EventQueue struct {
 	q *list.List
+	// If set, every message sent is also recorded here.
+	history *History
 }
 
 // Create a new event queue.
@@ -376,6 +597,9 @@ func (eq *EventQueue) Next() Event {
 
 // Send a message to be rendered in the message console.
 func (eq *EventQueue) Message(msg string) {
+	if eq.history != nil {
+		eq.history.add(msg)
+	}
 	eq.push(MessageEvent{Text: msg})
 }
 
@@ -388,6 +612,11 @@ func (eq *EventQueue) SkillChange(c *SkillChange) {
 	eq.push(SkillChangeEvent{Change: c})
 }
 
+// Explain a blow that was just struck in melee.
+func (eq *EventQueue) Combat(ev *CombatEvent) {
+	eq.push(*ev)
+}
+
 // Tell client to force a --more-- confirm.
 func (eq *EventQueue) More() {
 	eq.push(MoreEvent{})
@@ -101,3 +101,31 @@ func TestMessage(t *testing.T) {
 		t.Errorf(`Message(msg): Text was %v, want %v`, actual, msg)
 	}
 }
+
+func TestKillingUniqueMarksIt(t *testing.T) {
+	uniqueSpec := &Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: "TestUnique",
+		Name:    "Boss",
+		Gen:     Gen{Unique: true},
+		Traits:  &Traits{Sheet: NewPlayerSheet},
+	}
+
+	g := newTestGame()
+	obj := g.NewObj(uniqueSpec)
+	if !g.Uniques.Available(uniqueSpec) {
+		t.Error(`Unique was unavailable before being placed`)
+	}
+
+	g.Level.Place(obj, math.Pt(1, 1))
+	if g.Uniques.Available(uniqueSpec) {
+		t.Error(`Unique was still available after being placed`)
+	}
+
+	g.Kill(obj)
+
+	if !g.Uniques.Killed(uniqueSpec.Species) {
+		t.Error(`Killing a unique did not mark it as killed`)
+	}
+}
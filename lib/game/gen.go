@@ -10,36 +10,60 @@ import (
 // that are guaranteed not to be outside [floor - wiggle, floor + wiggle] based
 // on its given floor. Group sizes are taken from the GroupSize entry for each
 // spec -- if this is a monster, it's intended to be the pack size, and if it's
-// an item it is intended to be the stack size.
+// an item it is intended to be the stack size. Items that stack are generated
+// as a group containing a single obj whose count is the stack size. Unique
+// specs that have already been created in this game are never selected, and
+// are only ever generated alone. Equipment has a chance of being generated
+// with affixes; this improves with 'floor'.
 func Generate(n, floor, wiggle int, specs []*Spec, g *Game) [][]*Obj {
+	return generateBoosted(n, floor, wiggle, 0, specs, g)
+}
+
+// Like Generate, but equipment is enchanted as if it were found 'boost' floors
+// deeper. Which specs can be picked doesn't change.
+func generateBoosted(n, floor, wiggle, boost int, specs []*Spec, g *Game) [][]*Obj {
 	low, high := floor-wiggle, floor+wiggle
 	log.Printf("Generate: %d groups, %d specs, floors %d-%d", n, len(specs), low, high)
 	candidates := make([]*Spec, 0)
 
 	for _, spec := range specs {
-		if spec.Gen.Findable(low, high) {
+		if spec.Gen.Findable(low, high) && g.Uniques.Available(spec) {
 			candidates = append(candidates, spec)
 		}
 	}
 
-	ncandidates := len(candidates)
 	generated := make([][]*Obj, 0)
 
-	log.Printf("Generate: %d candidates at floor %d", ncandidates, floor)
+	log.Printf("Generate: %d candidates at floor %d", len(candidates), floor)
 
 	// RIP :(
-	if ncandidates == 0 {
+	if len(candidates) == 0 {
 		log.Print("Generate: No candidates! Returning no groups.")
 		return generated
 	}
 
-	for i := 0; i < n; i++ {
-		selected := candidates[RandInt(0, ncandidates)]
+	for i := 0; i < n && len(candidates) > 0; i++ {
+		pos := RandInt(0, len(candidates))
+		selected := candidates[pos]
 		gsize := math.Max(1, selected.Gen.GroupSize)
+
+		if selected.Gen.Unique {
+			// There can be only one.
+			candidates = append(candidates[:pos], candidates[pos+1:]...)
+			gsize = 1
+		}
 		group := make([]*Obj, 0, gsize)
 
 		for j := 0; j < gsize; j++ {
-			group = append(group, g.NewObj(selected))
+			obj := g.NewObj(selected)
+			enchant(obj, floor+boost)
+			group = append(group, obj)
+
+			// Stackable items are generated as a single stack of 'gsize'.
+			if obj.Stacks() {
+				obj.Consumable.Count = gsize
+				break
+			}
 		}
 		generated = append(generated, group)
 	}
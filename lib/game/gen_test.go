@@ -1,6 +1,7 @@
 package game
 
 import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
 	"testing"
 )
 
@@ -140,3 +141,66 @@ func TestGroupSize(t *testing.T) {
 		t.Errorf(`Generate() made group 0 of size %d, want 3`, l)
 	}
 }
+
+func TestUniqueOnlyGeneratedOnce(t *testing.T) {
+	g := newTestGame()
+	specs := []*Spec{
+		{
+			Name:    "1",
+			Family:  FamItem,
+			Species: "unique",
+			Traits:  &Traits{},
+			Gen: Gen{
+				Floors:    []int{1},
+				GroupSize: 3,
+				Unique:    true,
+			},
+		},
+	}
+
+	FixRandomSource([]int{0})
+	defer RestoreRandom()
+
+	groups := Generate(3, 1, 0, specs, g)
+
+	if l := len(groups); l != 1 {
+		t.Errorf(`Generate() made %d groups of a unique, want 1`, l)
+	}
+	if l := len(groups[0]); l != 1 {
+		t.Errorf(`Generate() made unique group of size %d, want 1`, l)
+	}
+	if g.Uniques.Generated("unique") {
+		t.Error(`Generating a unique marked it as generated before it was placed`)
+	}
+
+	g.Level.Place(groups[0][0], math.Pt(1, 1))
+	if !g.Uniques.Generated("unique") {
+		t.Error(`Placing a unique did not mark it as generated`)
+	}
+
+	if l := len(Generate(1, 1, 0, specs, g)); l != 0 {
+		t.Errorf(`Generate() made %d groups of an already-generated unique, want 0`, l)
+	}
+}
+
+func TestBoostOnlyAffectsEnchantment(t *testing.T) {
+	g := newTestGame()
+	shallow, deep := *affixTestWeapon, *affixTestWeapon
+	shallow.Name, shallow.Gen = "SHALLOW", Gen{Floors: []int{1}}
+	deep.Name, deep.Gen = "DEEP", Gen{Floors: []int{30}}
+
+	groups := generateBoosted(5, 1, 0, 100, []*Spec{&shallow, &deep}, g)
+
+	if l := len(groups); l != 5 {
+		t.Fatalf(`generateBoosted() made %d groups, want 5`, l)
+	}
+	for i, group := range groups {
+		item := group[0]
+		if n := item.Spec.Name; n != "SHALLOW" {
+			t.Errorf(`Boosted group %d was %s; want SHALLOW`, i, n)
+		}
+		if q := item.Equipment.Quality; q != QualityGreat {
+			t.Errorf(`Boosted group %d had quality %v; want %v`, i, q, QualityGreat)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// How many messages a game remembers.
+const MaxHistory = 1000
+
+// A message that was sent during the game. The same message sent several times
+// in a row is only recorded once, with a count.
+type HistoryEntry struct {
+	// The turn this message was first sent on.
+	Turn int
+	// The text of the message, including any markup.
+	Text string
+	// How many times in a row this message was sent.
+	Count int
+}
+
+// The entry's text, with the number of repeats if there were any, e.g.
+// "ORC misses DEBO. (x3)".
+func (e HistoryEntry) Describe() string {
+	if e.Count > 1 {
+		return fmt.Sprintf("%s (x%d)", e.Text, e.Count)
+	}
+	return e.Text
+}
+
+// Every message sent in a game, oldest first, up to a limit. Once the limit is
+// reached, the oldest messages are forgotten.
+type History struct {
+	Entries []HistoryEntry
+	max     int
+	// Where we get the current turn from.
+	progress *Progress
+}
+
+func newHistory(max int, progress *Progress) *History {
+	return &History{
+		Entries:  make([]HistoryEntry, 0, max),
+		max:      max,
+		progress: progress,
+	}
+}
+
+// How many entries are remembered.
+func (h *History) Len() int {
+	return len(h.Entries)
+}
+
+// Get the entry at 'i', where 0 is the oldest remembered.
+func (h *History) At(i int) HistoryEntry {
+	return h.Entries[i]
+}
+
+// Records a message, collapsing it into the last entry if it's a repeat.
+func (h *History) add(text string) {
+	if n := len(h.Entries); n > 0 && h.Entries[n-1].Text == text {
+		h.Entries[n-1].Count++
+		return
+	}
+
+	turn := 0
+	if h.progress != nil {
+		turn = h.progress.Turns
+	}
+	if len(h.Entries) >= h.max {
+		copy(h.Entries, h.Entries[1:])
+		h.Entries = h.Entries[:len(h.Entries)-1]
+	}
+	h.Entries = append(h.Entries, HistoryEntry{Turn: turn, Text: text, Count: 1})
+}
+
+// Finds the newest entry at or before 'from' whose text contains 'term',
+// ignoring case and markup. Returns -1 if there isn't one.
+func (h *History) Search(term string, from int) int {
+	term = strings.ToLower(term)
+	if from >= len(h.Entries) {
+		from = len(h.Entries) - 1
+	}
+	for i := from; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(PlainText(h.Entries[i].Text)), term) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,75 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestHistoryCollapsesRepeats(t *testing.T) {
+	progress := &Progress{Turns: 7}
+	h := newHistory(10, progress)
+
+	h.add("ORC misses DEBO.")
+	progress.Turns++
+	h.add("ORC misses DEBO.")
+	h.add("ORC misses DEBO.")
+	h.add("DEBO hits ORC (3).")
+
+	if n := h.Len(); n != 2 {
+		t.Fatalf(`h.Len() was %d, want 2`, n)
+	}
+	first := h.At(0)
+	if first.Turn != 7 || first.Count != 3 {
+		t.Errorf(`First entry was %+v, want turn 7 with count 3`, first)
+	}
+	if d, w := first.Describe(), "ORC misses DEBO. (x3)"; d != w {
+		t.Errorf(`first.Describe() was "%s", want "%s"`, d, w)
+	}
+	if d, w := h.At(1).Describe(), "DEBO hits ORC (3)."; d != w {
+		t.Errorf(`h.At(1).Describe() was "%s", want "%s"`, d, w)
+	}
+}
+
+func TestHistoryIsBounded(t *testing.T) {
+	h := newHistory(2, nil)
+	h.add("one")
+	h.add("two")
+	h.add("three")
+
+	if n := h.Len(); n != 2 {
+		t.Fatalf(`h.Len() was %d, want 2`, n)
+	}
+	if text := h.At(0).Text; text != "two" {
+		t.Errorf(`Oldest entry was "%s", want "two"`, text)
+	}
+}
+
+func TestHistorySearch(t *testing.T) {
+	h := newHistory(10, nil)
+	h.add("<monster>ORC</monster> shouts!")
+	h.add("You feel better.")
+	h.add("<monster>ORC</monster> flees!")
+
+	if i := h.Search("orc", h.Len()); i != 2 {
+		t.Errorf(`Search("orc") from the end was %d, want 2`, i)
+	}
+	if i := h.Search("orc", 1); i != 0 {
+		t.Errorf(`Search("orc") from 1 was %d, want 0`, i)
+	}
+	// Markup isn't searchable.
+	if i := h.Search("monster", h.Len()); i != -1 {
+		t.Errorf(`Search("monster") was %d, want -1`, i)
+	}
+}
+
+func TestGameRecordsMessages(t *testing.T) {
+	g := NewGame()
+	g.Progress.Turns = 12
+	g.Events.Message("Hello.")
+
+	if n := g.History.Len(); n != 1 {
+		t.Fatalf(`g.History.Len() was %d, want 1`, n)
+	}
+	if e := g.History.At(0); e.Text != "Hello." || e.Turn != 12 {
+		t.Errorf(`Recorded entry was %+v`, e)
+	}
+}
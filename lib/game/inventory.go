@@ -38,10 +38,17 @@ func (inv *Inventory) Full() bool {
 }
 
 // Tries to add item to this inventory. Returns false if the item doesn't fit.
+// If the item stacks with something that's already in here, it is merged into
+// that stack instead of taking up a new slot; this works even if the inventory
+// is full.
 func (inv *Inventory) Add(item *Obj) bool {
 	if fam := item.Spec.Family; fam != FamItem {
 		panic(fmt.Sprintf("Tried to add obj of family %v to inventory.", fam))
 	}
+	if stack := inv.stackFor(item); stack != nil {
+		stack.Consumable.Count += item.Consumable.Count
+		return true
+	}
 	if inv.Full() {
 		return false
 	}
@@ -80,6 +87,25 @@ func (inv *Inventory) Take(index int) *Obj {
 	return itemElem.Value.(*Obj)
 }
 
+// Like Take, but only removes 'n' items from the stack at 'index'. If n is 0
+// or is at least the size of the stack, the whole stack is taken. Otherwise,
+// the stack is split and a new stack of size 'n' is returned.
+// Returns nil if there was no item at the given index.
+func (inv *Inventory) TakeN(index, n int) *Obj {
+	item := inv.At(index)
+	if item == nil {
+		return nil
+	}
+	if n <= 0 || n >= item.Count() {
+		return inv.Take(index)
+	}
+
+	split := item.Game.NewObj(item.Spec)
+	split.Consumable.Count = n
+	item.Consumable.Count -= n
+	return split
+}
+
 // Does this inventory have anything to equip in it?
 func (inv *Inventory) HasEquipment() bool {
 	for e := inv.Items.Front(); e != nil; e = e.Next() {
@@ -110,6 +136,21 @@ func (inv *Inventory) EachItem(f func(o *Obj)) {
 	}
 }
 
+// Finds the stack in this inventory that 'item' can be merged into. Returns
+// nil if there is no such stack.
+func (inv *Inventory) stackFor(item *Obj) *Obj {
+	if !item.Stacks() {
+		return nil
+	}
+	for e := inv.Items.Front(); e != nil; e = e.Next() {
+		other := e.Value.(*Obj)
+		if other != item && other.Spec == item.Spec {
+			return other
+		}
+	}
+	return nil
+}
+
 func (inv *Inventory) itemElemAt(index int) *list.Element {
 	itemElem := inv.Items.Back()
 	for i := 0; i != index; i++ {
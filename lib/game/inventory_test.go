@@ -114,3 +114,82 @@ func TestHasUsables(t *testing.T) {
 		t.Errorf(`inv.HasUsables() was false, want true`)
 	}
 }
+
+var invTestStackable = &Spec{
+	Family:  FamItem,
+	Genus:   GenConsumable,
+	Species: "teststack",
+	Name:    "Potion",
+	Traits: &Traits{
+		Consumable: NewConsumable(func(u User) {}),
+	},
+}
+
+func TestAddStacksConsumables(t *testing.T) {
+	g := newTestGame()
+	inv := NewInventoryWithCap(1)
+
+	inv.Add(g.NewObj(invTestStackable))
+
+	if !inv.Add(g.NewObj(invTestStackable)) {
+		t.Error(`inv.Add(stackable) into full inventory with a matching stack was false, want true`)
+	}
+	if size := inv.Len(); size != 1 {
+		t.Errorf(`inv.Len() was %d after stacking, want 1`, size)
+	}
+	if count := inv.Top().Count(); count != 2 {
+		t.Errorf(`Stack count was %d, want 2`, count)
+	}
+}
+
+func TestAddDoesNotStackEquipment(t *testing.T) {
+	g := newTestGame()
+	inv := NewInventory()
+
+	inv.Add(g.NewObj(atItemSpec))
+	inv.Add(g.NewObj(atItemSpec))
+
+	if size := inv.Len(); size != 2 {
+		t.Errorf(`inv.Len() was %d after adding 2 equipment, want 2`, size)
+	}
+}
+
+func TestTakeNSplitsStack(t *testing.T) {
+	g := newTestGame()
+	inv := NewInventory()
+
+	stack := g.NewObj(invTestStackable)
+	stack.Consumable.Count = 5
+	inv.Add(stack)
+
+	taken := inv.TakeN(0, 2)
+
+	if taken == stack {
+		t.Error(`inv.TakeN(0, 2) returned the original stack, want a new one`)
+	}
+	if count := taken.Count(); count != 2 {
+		t.Errorf(`Taken stack had count %d, want 2`, count)
+	}
+	if count := stack.Count(); count != 3 {
+		t.Errorf(`Remaining stack had count %d, want 3`, count)
+	}
+	if size := inv.Len(); size != 1 {
+		t.Errorf(`inv.Len() was %d after split, want 1`, size)
+	}
+}
+
+func TestTakeNWholeStack(t *testing.T) {
+	g := newTestGame()
+	inv := NewInventory()
+
+	stack := g.NewObj(invTestStackable)
+	stack.Consumable.Count = 3
+	inv.Add(stack)
+
+	if taken := inv.TakeN(0, 0); taken != stack {
+		t.Errorf(`inv.TakeN(0, 0) gave %v, want whole stack %v`, taken, stack)
+	}
+	if !inv.Empty() {
+		t.Error(`inv.TakeN(0, 0) did not empty inventory`)
+	}
+}
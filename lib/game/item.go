@@ -20,6 +20,9 @@ type Equipment struct {
 	Weight   int
 	Slot     Slot
 	Effects  Effects
+	// Set when this is generated; see enchant().
+	Quality Quality
+	Affixes []*Affix
 }
 
 // See NewSheet in actor.go to understand why this is written this way.
@@ -32,6 +35,20 @@ func NewEquipment(equipspec Equipment) func(*Obj) *Equipment {
 	}
 }
 
+// The name of this piece of equipment, including any affixes it has, e.g.
+// "Elven SWORD of Fire".
+func (e *Equipment) Name() string {
+	name := e.obj.Spec.Name
+	for _, affix := range e.Affixes {
+		if affix.Prefix {
+			name = affix.Name + " " + name
+		} else {
+			name = name + " " + affix.Name
+		}
+	}
+	return name
+}
+
 // Function that actually does something when this item gets used.
 type ConsumeFunc func(user User)
 
@@ -39,6 +56,8 @@ type ConsumeFunc func(user User)
 type Consumable struct {
 	Trait
 	Consume ConsumeFunc
+	// How many of these are in this stack.
+	Count int
 }
 
 // Given a consumefunc, this creates a factory function for consumables with
@@ -48,19 +67,36 @@ func NewConsumable(cf ConsumeFunc) func(*Obj) *Consumable {
 		return &Consumable{
 			Trait:   Trait{obj: obj},
 			Consume: cf,
+			Count:   1,
 		}
 	}
 }
 
+// Can this item be merged with others of the same spec into a single stack?
+// Only consumables stack.
+func (o *Obj) Stacks() bool {
+	return o.Spec.Genus == GenConsumable && o.Consumable != nil
+}
+
+// How many items are in the stack represented by this obj. Things that don't
+// stack are always a stack of 1.
+func (o *Obj) Count() int {
+	if !o.Stacks() {
+		return 1
+	}
+	return o.Consumable.Count
+}
+
 func curefunc(user User) {
 	u := user.Obj()
 	u.Sheet.Heal(40)
-	u.Game.Events.Message(fmt.Sprintf("%s recovers.", u.Spec.Name))
+	u.Game.Events.Message(fmt.Sprintf("%s %s.", u.Spec.Name, mark(StyleGood, "recovers")))
 }
 
 func stimfunc(user User) {
 	u := user.Obj()
-	u.Game.Events.Message(fmt.Sprintf("%s is wracked with pain.", u.Spec.Name))
+	u.Game.Events.Message(fmt.Sprintf("%s is %s.", u.Spec.Name, mark(StyleBad, "wracked with pain")))
+	u.Game.blame(u, "a STIM")
 	u.Sheet.Hurt(DieRoll(4, 4))
 	u.Ticker.AddEffect(EffectStim, DieRoll(20, 4))
 }
@@ -1,10 +1,18 @@
 package game
 
+import (
+	"fmt"
+)
+
 const (
 	SpecFist         = "fist"
 	SpecSword        = "sword"
 	SpecLeatherArmor = "leatherarmor"
 
+	SpecAngrist  = "angrist"
+	SpecArvedui  = "arvedui"
+	SpecEarendil = "earendil"
+
 	SpecCure    = "cure"
 	SpecStim    = "stim"
 	SpecHyper   = "hyper"
@@ -52,6 +60,71 @@ var Items = []*Spec{
 			}),
 		},
 	},
+	&Spec{
+		Family:  FamItem,
+		Genus:   GenEquipment,
+		Species: SpecAngrist,
+		Name:    "ANGRIST",
+		Lore:    "A knife forged by Telchar of Nogrod, that cuts iron as if it were green wood.",
+		Gen: Gen{
+			Floors:    []int{3},
+			GroupSize: 1,
+			Unique:    true,
+		},
+		Traits: &Traits{
+			Equipment: NewEquipment(Equipment{
+				Damroll: NewDice(2, 6),
+				Melee:   3,
+				Evasion: 2,
+				Weight:  1,
+				Slot:    SlotHand,
+				Effects: NewEffects(map[Effect]int{SlayBattle: 1, BrandElec: 1, EffectCut: 1}),
+			}),
+		},
+	},
+	&Spec{
+		Family:  FamItem,
+		Genus:   GenEquipment,
+		Species: SpecArvedui,
+		Name:    "ARVEDUI",
+		Lore:    "The mail of the last king of Arthedain, hardened against cold and fire alike.",
+		Gen: Gen{
+			Floors:    []int{4},
+			GroupSize: 1,
+			Unique:    true,
+		},
+		Traits: &Traits{
+			Equipment: NewEquipment(Equipment{
+				Protroll: NewDice(2, 5),
+				Melee:    0,
+				Evasion:  0,
+				Weight:   6,
+				Slot:     SlotBody,
+				Effects:  NewEffects(map[Effect]int{ResistFire: 1, ResistIce: 1, ResistCrit: 2}),
+			}),
+		},
+	},
+	&Spec{
+		Family:  FamItem,
+		Genus:   GenEquipment,
+		Species: SpecEarendil,
+		Name:    "EARENDIL",
+		Lore:    "A phial holding light caught from the star of Earendil. It wards off darkness and fear.",
+		Gen: Gen{
+			Floors:    []int{5},
+			GroupSize: 1,
+			Unique:    true,
+		},
+		Traits: &Traits{
+			Equipment: NewEquipment(Equipment{
+				Melee:   0,
+				Evasion: 1,
+				Weight:  1,
+				Slot:    SlotRelic,
+				Effects: NewEffects(map[Effect]int{ResistBlind: 2, ResistFear: 1, ResistDrain: 1}),
+			}),
+		},
+	},
 	&Spec{
 		Family:  FamItem,
 		Genus:   GenConsumable,
@@ -105,3 +178,13 @@ var Items = []*Spec{
 		},
 	},
 }
+
+// Finds the spec in Items for the given species. Panics if there isn't one.
+func itemspec(s Species) *Spec {
+	for _, spec := range Items {
+		if spec.Species == s {
+			return spec
+		}
+	}
+	panic(fmt.Sprintf("No item spec for species %v", s))
+}
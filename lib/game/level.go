@@ -14,12 +14,37 @@ type Feature struct {
 	Type   FeatureType
 	Solid  bool
 	Opaque bool
+	// What this does if it's a trap.
+	Trap *Trap
+	// How many turns it takes to move onto this. Zero is the same as one.
+	MoveCost int
+	// What this does to whoever moves onto it.
+	Hazard Hazard
+	// What this turns into once someone has made their way onto it, if it
+	// doesn't last.
+	Cleared *Feature
+	// Set on features that are hidden until someone finds them: what they look
+	// like until then, and what they turn into once they're found.
+	Looks, Found *Feature
 }
 
 func (f *Feature) String() string {
 	return string(f.Type)
 }
 
+// Is this hidden until someone finds it?
+func (f *Feature) Hidden() bool {
+	return f.Found != nil
+}
+
+// What this looks like to anyone who hasn't found it.
+func (f *Feature) Appearance() *Feature {
+	if f.Looks != nil {
+		return f.Looks
+	}
+	return f
+}
+
 type Tile struct {
 	Feature *Feature
 	Actor   *Obj
@@ -41,6 +66,9 @@ type Level struct {
 	Bounds    math.Rectangle
 	game      *Game
 	scheduler *Scheduler
+	flows     map[flowKey]*FlowMap
+	// What the generator that made this level recorded about it.
+	stats GenStats
 }
 
 // Create a level that uses the given game to create objects, generated by the
@@ -65,14 +93,23 @@ func NewLevel(width, height int, game *Game, gen func(*Level) *Level) *Level {
 		Bounds:    math.Rect(math.Origin, math.Pt(width, height)),
 		game:      game,
 		scheduler: NewScheduler(),
+		flows:     map[flowKey]*FlowMap{},
 	}
 	level = gen(level)
 
 	// Init all the actors brains, now that they have a place on the map.
+	// Monsters that live here know where all of the secret doors are.
+	secrets := level.secretdoors()
 	level.scheduler.EachActor(func(o *Obj) {
-		if o.AI != nil {
-			o.AI.Init()
+		if o.AI == nil {
+			return
+		}
+		if o.Senser != nil {
+			for _, door := range secrets {
+				o.Senser.Learn(door)
+			}
 		}
+		o.AI.Init()
 	})
 	return level
 }
@@ -99,21 +136,27 @@ func (l *Level) HasPoint(p math.Point) bool {
 
 // Place `o` on the tile at `p`. Returns false if this is impossible (e.g.
 // trying to put something on a solid square.)
-// This will remove `o` from any tile on any map it was previously on.
+// This will remove `o` from any tile on any map it was previously on. A unique
+// only counts as generated once it has been placed.
 func (l *Level) Place(o *Obj, p math.Point) bool {
 	t := l.At(p)
 	if t.Feature.Solid {
 		return false
 	}
 
+	placed := false
 	switch o.Spec.Family {
 	case FamActor:
-		return l.placeActor(o, t)
+		placed = l.placeActor(o, t)
 	case FamItem:
-		return l.placeItem(o, t)
+		placed = l.placeItem(o, t)
 	default:
 		panic(fmt.Sprintf("Tried to place object of family %v", o.Spec.Family))
 	}
+	if placed {
+		l.game.Uniques.markGenerated(o.Spec)
+	}
+	return placed
 }
 
 // Removes 'o' from the level.
@@ -139,6 +182,11 @@ func (l *Level) Evolve() {
 			ai.Act()
 		}
 		if actor.IsPlayer() {
+			// The player notices things right next to them without having
+			// to look.
+			if actor.Sheet.CanAct() && actor.Senser != nil {
+				actor.Senser.Search(NoticeRadius, 0)
+			}
 			break
 		}
 	}
@@ -209,64 +257,22 @@ func (l *Level) UpdateScent(scent Field) {
 // Return type of FindPath. This is what you follow to travel the found route.
 type Path []math.Point
 
-// The distance map in Dijkstra's algorithm.
-type dists map[math.Point]int
-
-// Return basically INFINITY if we haven't seen p before.
-func (d dists) get(p math.Point) int {
-	dist, ok := d[p]
-	if ok {
-		return dist
-	}
-	return num.MaxInt32
-}
-
-// An unvisited position on the map. We hold a reference to the distances dict
-// for distance lookup instead of trying to maintain it all in one place.
-type unvisited struct {
-	p    math.Point
-	dist dists
-}
-
-// Look up the currently calculated distance for this point.
-func (u *unvisited) d() int {
-	return u.dist.get(u.p)
-}
-
-// The PQ for dijkstra pathfinding.
-type dijkstraQ []*unvisited
-
-// Implementing heap.Interface
-func (d dijkstraQ) Len() int {
-	return len(d)
-}
-
-func (d dijkstraQ) Less(i, j int) bool {
-	return d[i].d() < d[j].d()
-}
-
-func (d dijkstraQ) Swap(i, j int) {
-	d[i], d[j] = d[j], d[i]
-}
-
-func (d *dijkstraQ) Push(x interface{}) {
-	*d = append(*d, x.(*unvisited))
-}
-
-func (d *dijkstraQ) Pop() interface{} {
-	old := *d
-	n := len(old)
-	x := old[n-1]
-	*d = old[0 : n-1]
-	return x
-}
-
 // Finds a reasonably direct path between start and dest in this level. If no
-// path could be found, 'path' will be zero and 'ok' will be false. This uses
-// Dijkstra's algorithm.
+// path could be found, 'path' will be zero and 'ok' will be false. This is
+// AStar over every tile that isn't a wall.
 func (l *Level) FindPath(start, end math.Point, cost func(*Level, math.Point) int) (path Path, ok bool) {
-	path = make(Path, 0, 100)
-	if !start.In(l) || !end.In(l) || l.At(start).Feature.Solid || l.At(end).Feature.Solid {
+	return l.AStar(start, end, cost, patheligible)
+}
+
+// Finds the cheapest path between start and end in this level using A*,
+// stepping only onto tiles that 'passable' allows. Stepping onto a tile costs
+// whatever 'cost' says, which must be at least 1 or the path might not be the
+// cheapest. Of all the cheapest paths, this prefers the one that stays closest
+// to a straight line from start to end. If start is end, 'path' is empty and
+// 'ok' is true; if there's no way through, 'ok' is false.
+func (l *Level) AStar(start, end math.Point, cost func(*Level, math.Point) int, passable func(*Tile) bool) (path Path, ok bool) {
+	path = make(Path, 0)
+	if !start.In(l) || !end.In(l) || l.At(start).Feature.Solid || !passable(l.At(end)) {
 		return path, false
 	}
 
@@ -274,76 +280,197 @@ func (l *Level) FindPath(start, end math.Point, cost func(*Level, math.Point) in
 		return path, true
 	}
 
-	// PQ
-	dist := dists{start: 0}
-	prev := map[math.Point]math.Point{}
-	todo := &dijkstraQ{&unvisited{p: start, dist: dist}}
-	heap.Init(todo)
+	width := l.Bounds.Width()
+	index := func(p math.Point) int {
+		return p.Y*width + p.X
+	}
+	dist := make([]int, width*l.Bounds.Height())
+	for i := range dist {
+		dist[i] = num.MaxInt32
+	}
+	prev := make([]math.Point, len(dist))
+
+	// How far a point strays from the straight line between start and end.
+	line := start.Sub(end)
+	stray := func(p math.Point) int {
+		d := p.Sub(end)
+		return math.Abs(d.X*line.Y - d.Y*line.X)
+	}
+
+	dist[index(start)] = 0
+	todo := &astarQ{astarEntry{pos: start, h: math.ChebyDist(start, end)}}
+	found := false
 
 	for todo.Len() != 0 {
-		cur := heap.Pop(todo).(*unvisited).p
-		curdist := dist[cur]
-		if cur == end {
+		cur := heap.Pop(todo).(astarEntry)
+		if cur.g > dist[index(cur.pos)] {
+			continue
+		}
+		if cur.pos == end {
+			found = true
 			break
 		}
 
-		// Get neighbouring points.
-		neighbors := l.Around(cur)
-
-		// Figure out which neighbours we should even bother checking (e.g.
-		// walls are a bad idea at the moment. We don't have Kemenrauko.
-		for _, n := range neighbors {
-			if !patheligible(n) {
+		for _, n := range l.Around(cur.pos) {
+			if !passable(n) {
 				continue
 			}
 			npos := n.Pos
-			d := dist.get(npos)
-
-			altdist := curdist + cost(l, npos)
-			if altdist < d {
-				dist[npos] = altdist
-				prev[npos] = cur
-				heap.Push(todo, &unvisited{p: npos, dist: dist})
+			if altdist := cur.g + cost(l, npos); altdist < dist[index(npos)] {
+				dist[index(npos)] = altdist
+				prev[index(npos)] = cur.pos
+				heap.Push(todo, astarEntry{
+					pos:   npos,
+					g:     altdist,
+					h:     math.ChebyDist(npos, end),
+					stray: stray(npos),
+				})
 			}
 		}
 	}
 
+	if !found {
+		return path, false
+	}
+
 	// Trace path, which will give us the route in reverse.
-	pathcur := end
-	for {
-		next, ok := prev[pathcur]
-		if !ok {
-			break
-		}
-		path = append(path, pathcur)
-		pathcur = next
+	for cur := end; cur != start; cur = prev[index(cur)] {
+		path = append(path, cur)
 	}
 
 	// Put the path in order.
 	for i, l := 0, len(path); i < l/2; i++ {
 		path[i], path[l-i-1] = path[l-i-1], path[i]
 	}
+	return path, true
+}
 
-	// We didn't find any path. Let the caller know via 'ok', since this
-	// distinguishes the situation where you tried to pathfind to yourself.
-	if len(path) == 0 {
-		return path, false
+// Fixes 'path' when some of it can't be walked any more, e.g. because someone
+// is standing in the way. Instead of finding a whole new path, this finds a way
+// from 'from' around the blocked part and back onto the rest of the path. If
+// the path can't be rejoined, this searches for the end of it instead. If
+// nothing is blocked, 'path' comes back as it is.
+func (l *Level) RepairPath(from math.Point, path Path, cost func(*Level, math.Point) int, passable func(*Tile) bool) (Path, bool) {
+	blocked := -1
+	for i, pt := range path {
+		if !passable(l.At(pt)) {
+			blocked = i
+			break
+		}
 	}
-	return path, true
+	if blocked == -1 {
+		return path, true
+	}
+
+	for i := blocked + 1; i < len(path); i++ {
+		if !passable(l.At(path[i])) {
+			continue
+		}
+		if detour, ok := l.AStar(from, path[i], cost, passable); ok {
+			return append(detour, path[i+1:]...), true
+		}
+		break
+	}
+	return l.AStar(from, path[len(path)-1], cost, passable)
+}
+
+// An entry in the A* PQ. 'g' is the cost to get here from the start, and 'h'
+// is our guess at the cost from here to the end.
+type astarEntry struct {
+	pos   math.Point
+	g     int
+	h     int
+	stray int
+}
+
+// The PQ for A* pathfinding. Entries that look like they'll make equally cheap
+// paths are tried closest-to-the-end first, and then closest to the straight
+// line between start and end, so that we don't wander all over open rooms.
+type astarQ []astarEntry
+
+// Implementing heap.Interface
+func (q astarQ) Len() int {
+	return len(q)
+}
+
+func (q astarQ) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if fa, fb := a.g+a.h, b.g+b.h; fa != fb {
+		return fa < fb
+	}
+	if a.h != b.h {
+		return a.h < b.h
+	}
+	return a.stray < b.stray
+}
+
+func (q astarQ) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *astarQ) Push(x interface{}) {
+	*q = append(*q, x.(astarEntry))
+}
+
+func (q *astarQ) Pop() interface{} {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[0 : n-1]
+	return x
 }
 
-// Returns the "cost" of moving onto 'loc' in level l.
+// Returns the "cost" of moving onto 'loc' in level l. Traps that have been
+// found cost so much that they're only crossed if there's no other way.
 func PathCost(l *Level, loc math.Point) int {
-	switch l.At(loc).Feature {
-	case FeatClosedDoor:
+	switch f := l.At(loc).Feature; {
+	case f == FeatClosedDoor, f == FeatSecretDoor:
 		return 2
+	case f.Trap != nil && !f.Hidden():
+		return trapPathCost
+	case f.MoveCost > 1:
+		return f.MoveCost
 	default:
 		return 1
 	}
 }
 
 func patheligible(t *Tile) bool {
-	return t.Feature != FeatWall
+	return t.Feature != FeatWall && t.Feature != FeatSecretDoor && !t.Feature.Dangerous()
+}
+
+// Finds a path like FindPath does, from wherever 'obj' is, but also through
+// any secret doors that 'obj' knows about.
+func (l *Level) FindPathFor(obj *Obj, end math.Point, cost func(*Level, math.Point) int) (path Path, ok bool) {
+	return l.AStar(obj.Pos(), end, cost, pathableby(obj))
+}
+
+// Like patheligible, but secret doors that 'obj' knows about can be used too,
+// and deep water can't be if 'obj' would sink in it.
+func pathableby(obj *Obj) func(*Tile) bool {
+	return func(t *Tile) bool {
+		switch {
+		case t.Feature == FeatSecretDoor:
+			return obj.Senser != nil && obj.Senser.Knows(t)
+		case t.Feature.Hazard == HazardDeepWater:
+			return !obj.Spec.Sinks
+		default:
+			return patheligible(t)
+		}
+	}
+}
+
+// Every secret door on this level that nobody has found yet.
+func (l *Level) secretdoors() []*Tile {
+	doors := make([]*Tile, 0)
+	for _, row := range l.Map {
+		for _, tile := range row {
+			if tile.Feature == FeatSecretDoor {
+				doors = append(doors, tile)
+			}
+		}
+	}
+	return doors
 }
 
 func (l *Level) placeActor(obj *Obj, tile *Tile) bool {
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+var (
+	ErrLevelNoPlayer     = errors.New("LevelNoPlayer")
+	ErrLevelOpenEdge     = errors.New("LevelOpenEdge")
+	ErrLevelUnreachable  = errors.New("LevelUnreachable")
+	ErrLevelBadDoor      = errors.New("LevelBadDoor")
+	ErrLevelInWall       = errors.New("LevelInWall")
+	ErrLevelNoUpStairs   = errors.New("LevelNoUpStairs")
+	ErrLevelNoDownStairs = errors.New("LevelNoDownStairs")
+)
+
+// Something wrong with a particular tile on a level.
+type LevelError struct {
+	Err error
+	Pos math.Point
+}
+
+func (e *LevelError) Error() string {
+	return fmt.Sprintf("%v at %v", e.Err, e.Pos)
+}
+
+func (e *LevelError) Unwrap() error {
+	return e.Err
+}
+
+// Checks that a freshly generated level is fit to play on, and returns
+// everything that's wrong with it. A good level has walls all the way around
+// the edge, the player on it, and a way from the player to every tile that
+// isn't wall, lava or chasm without crossing any lava or chasms. Its doors are
+// all set into walls with a way through them, nothing is stuck inside a wall,
+// and there are stairs up and down wherever there should be.
+func (l *Level) Check() []error {
+	errs := make([]error, 0)
+	bad := func(err error, pos math.Point) {
+		errs = append(errs, &LevelError{Err: err, Pos: pos})
+	}
+
+	player := l.game.Player
+	if player == nil || player.Level != l || player.Tile == nil {
+		return append(errs, ErrLevelNoPlayer)
+	}
+
+	for _, tile := range unreachable(l) {
+		bad(ErrLevelUnreachable, tile.Pos)
+	}
+
+	width, height := l.Bounds.Width(), l.Bounds.Height()
+	ups, downs := 0, 0
+	for _, row := range l.Map {
+		for _, tile := range row {
+			pos, feature := tile.Pos, tile.Feature
+			edge := pos.X == 0 || pos.Y == 0 || pos.X == width-1 || pos.Y == height-1
+
+			if edge && feature != FeatWall {
+				bad(ErrLevelOpenEdge, pos)
+			}
+			isdoor := feature == FeatClosedDoor || feature == FeatOpenDoor || feature == FeatSecretDoor
+			if isdoor && !sanedoor(l, pos) {
+				bad(ErrLevelBadDoor, pos)
+			}
+			if feature.Solid && (tile.Actor != nil || !tile.Items.Empty()) {
+				bad(ErrLevelInWall, pos)
+			}
+
+			switch feature {
+			case FeatStairsUp:
+				ups++
+			case FeatStairsDown:
+				downs++
+			}
+		}
+	}
+
+	// These are the same as the rules in placestairs.
+	if floor := l.game.Progress.Floor; floor != MaxFloor && ups == 0 {
+		errs = append(errs, ErrLevelNoUpStairs)
+	}
+	if floor := l.game.Progress.Floor; floor != 1 && downs == 0 {
+		errs = append(errs, ErrLevelNoDownStairs)
+	}
+	return errs
+}
@@ -0,0 +1,8 @@
+//go:build debug
+// +build debug
+
+package game
+
+// In debug builds, NewDungeon checks every level it makes and panics if
+// there's anything wrong with it.
+const checkLevels = true
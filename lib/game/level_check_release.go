@@ -0,0 +1,7 @@
+//go:build !debug
+// +build !debug
+
+package game
+
+// Set in debug builds to check every level NewDungeon makes.
+const checkLevels = false
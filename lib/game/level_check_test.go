@@ -0,0 +1,118 @@
+package game
+
+import (
+	"errors"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+// Does 'errs' have 'want' in it at 'pos'?
+func hasLevelError(errs []error, want error, pos math.Point) bool {
+	for _, err := range errs {
+		var lerr *LevelError
+		if errors.As(err, &lerr) && lerr.Err == want && lerr.Pos == pos {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckFindsProblems(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#@  + # #
+#     ###
+#+##    #
+#### ####`)
+	l := g.Level
+	l.At(math.Pt(0, 0)).Items.Add(g.NewObj(lTestItem))
+
+	errs := l.Check()
+
+	tests := []struct {
+		err error
+		pos math.Point
+	}{
+		{ErrLevelBadDoor, math.Pt(4, 1)},
+		{ErrLevelBadDoor, math.Pt(1, 3)},
+		{ErrLevelUnreachable, math.Pt(7, 1)},
+		{ErrLevelInWall, math.Pt(0, 0)},
+		{ErrLevelOpenEdge, math.Pt(4, 4)},
+	}
+	for _, test := range tests {
+		if !hasLevelError(errs, test.err, test.pos) {
+			t.Errorf(`Check() didn't find %v at %v; got %v`, test.err, test.pos, errs)
+		}
+	}
+	if len(errs) != len(tests)+1 {
+		t.Errorf(`Check() found %d problems; want %d: %v`, len(errs), len(tests)+1, errs)
+	}
+	// Floor 1 needs a way up.
+	found := false
+	for _, err := range errs {
+		found = found || err == ErrLevelNoUpStairs
+	}
+	if !found {
+		t.Errorf(`Check() didn't notice there were no stairs up`)
+	}
+}
+
+func TestCheckPassesGoodLevel(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#@  # #
+#   + #
+#   # #
+#######`)
+	l := g.Level
+	l.At(math.Pt(5, 2)).Feature = FeatStairsUp
+
+	if errs := l.Check(); len(errs) != 0 {
+		t.Errorf(`Check() on a good level found %v`, errs)
+	}
+}
+
+func TestSeedGivesSameLevel(t *testing.T) {
+	defer RestoreRandom()
+	gen := func() *Level {
+		SeedRandom(42)
+		g := newTestGame()
+		return NewLevel(80, 80, g, LynnRoomsLevel)
+	}
+
+	a, b := gen(), gen()
+	for y, row := range a.Map {
+		for x, tile := range row {
+			if other := b.Map[y][x]; tile.Feature != other.Feature {
+				t.Fatalf(`Levels from the same seed differ at %v`, tile.Pos)
+			}
+		}
+	}
+}
+
+// Generates lots of levels with every generator, on every floor, and checks
+// that they're all fit to play on. Any failure can be reproduced with
+// SeedRandom and the seed it reports.
+func TestGeneratedLevelsPass(t *testing.T) {
+	n := 2000
+	if testing.Short() {
+		n = 50
+	}
+	defer RestoreRandom()
+
+	for seed := 0; seed < n; seed++ {
+		SeedRandom(int64(seed))
+		gen := LevelGens[seed%len(LevelGens)]
+		floor := 1 + (seed/len(LevelGens))%MaxFloor
+		// Arrive from above, below, or at the start of the game.
+		prev := math.Max(1, math.Min(MaxFloor, floor+seed%3-1))
+
+		g := newTestGame()
+		g.Progress.Floor, g.Progress.PrevFloor = floor, prev
+		g.Level = NewLevel(80, 80, g, gen.Gen)
+
+		if errs := g.Level.Check(); len(errs) != 0 {
+			t.Errorf(`Seed %d, floor %d, %s: %d problems, e.g. %v`, seed, floor, gen.Name, len(errs), errs[0])
+		}
+	}
+}
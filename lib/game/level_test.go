@@ -261,6 +261,148 @@ func TestPathfinding(t *testing.T) {
 	}
 }
 
+// How much it costs to walk 'path'.
+func pathcost(l *Level, path Path, cost func(*Level, math.Point) int) int {
+	total := 0
+	for _, pt := range path {
+		total += cost(l, pt)
+	}
+	return total
+}
+
+func TestAStarFindsCheapestPaths(t *testing.T) {
+	// Lumpy costs make for lots of paths that look cheap but aren't.
+	lumpy := func(l *Level, loc math.Point) int {
+		return PathCost(l, loc) + (loc.X*7+loc.Y*13)%5
+	}
+	costs := []func(*Level, math.Point) int{PathCost, lumpy}
+
+	for level := 0; level < 5; level++ {
+		g := newTestGame()
+		l := NewDungeon(g)
+		g.Level = l
+
+		for trip := 0; trip < 10; trip++ {
+			start, end := l.RandomClearTile().Pos, l.RandomClearTile().Pos
+
+			for ci, cost := range costs {
+				// Compare against a plain Dijkstra flow from the start.
+				want := l.FlowMap([]math.Point{start}, cost, patheligible).At(end)
+				path, ok := l.AStar(start, end, cost, patheligible)
+
+				if want == FlowUnreachable {
+					if ok {
+						t.Errorf(`AStar(%v, %v) with cost %d found a path; want none`, start, end, ci)
+					}
+					continue
+				}
+				if !ok {
+					t.Errorf(`AStar(%v, %v) with cost %d found no path`, start, end, ci)
+					continue
+				}
+				if got := pathcost(l, path, cost); got != want {
+					t.Errorf(`AStar(%v, %v) with cost %d cost %d; want %d`, start, end, ci, got, want)
+				}
+				for i, prev := 0, start; i < len(path); prev, i = path[i], i+1 {
+					if math.ChebyDist(prev, path[i]) != 1 || !patheligible(l.At(path[i])) {
+						t.Errorf(`AStar(%v, %v) made a bad step %v -> %v`, start, end, prev, path[i])
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestAStarPrefersStraightPaths(t *testing.T) {
+	g := newRunTestGame(`
+############
+#          #
+#          #
+#          #
+############`)
+	l := g.Level
+
+	path, ok := l.AStar(math.Pt(1, 2), math.Pt(10, 2), PathCost, patheligible)
+
+	if !ok || len(path) != 9 {
+		t.Fatalf(`AStar across a room was %v; want 9 steps`, path)
+	}
+	for _, pt := range path {
+		if pt.Y != 2 {
+			t.Errorf(`AStar across a room was %v; want a straight line`, path)
+			break
+		}
+	}
+}
+
+func TestAStarPassability(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#  +  #
+## # ##
+##   ##
+#######`)
+	l := g.Level
+	start, end := math.Pt(1, 1), math.Pt(5, 1)
+	nodoors := func(t *Tile) bool {
+		return patheligible(t) && t.Feature != FeatClosedDoor
+	}
+	// Make the door the cheaper way, so the only reason to avoid it is that
+	// we can't go through it.
+	nolong := func(l *Level, loc math.Point) int {
+		if loc.Y == 3 {
+			return 10
+		}
+		return 1
+	}
+
+	if path, _ := l.AStar(start, end, nolong, patheligible); len(path) != 4 || path[1] != math.Pt(3, 1) {
+		t.Errorf(`AStar through a door was %v; want it through (3, 1)`, path)
+	}
+
+	path, ok := l.AStar(start, end, nolong, nodoors)
+	if !ok {
+		t.Fatal(`AStar without doors found no path`)
+	}
+	for _, pt := range path {
+		if pt == math.Pt(3, 1) {
+			t.Errorf(`AStar without doors was %v; went through the door`, path)
+		}
+	}
+}
+
+func TestRepairPathGoesAroundBlockage(t *testing.T) {
+	g := newRunTestGame(`
+##########
+#        #
+#        #
+##########`)
+	l := g.Level
+	start, end := math.Pt(1, 1), math.Pt(8, 1)
+	path, _ := l.AStar(start, end, PathCost, patheligible)
+	l.Place(g.NewObj(lTestActor), math.Pt(4, 1))
+
+	repaired, ok := l.RepairPath(start, path, PathCost, unoccupied)
+
+	if !ok {
+		t.Fatal(`RepairPath found no way around`)
+	}
+	if last := repaired[len(repaired)-1]; last != end {
+		t.Errorf(`Repaired path ended at %v; want %v`, last, end)
+	}
+	for i, prev := 0, start; i < len(repaired); prev, i = repaired[i], i+1 {
+		if pt := repaired[i]; math.ChebyDist(prev, pt) != 1 || l.At(pt).Actor != nil {
+			t.Errorf(`Repaired path %v has a bad step %v -> %v`, repaired, prev, pt)
+			break
+		}
+	}
+	// The tail past the blockage should be left alone.
+	if tail := repaired[len(repaired)-3:]; tail[0] != math.Pt(6, 1) {
+		t.Errorf(`Repaired path %v didn't rejoin the old one`, repaired)
+	}
+}
+
 func TestUpdateVisTeachesPlayer(t *testing.T) {
 	g := newTestGame()
 	dest := math.Pt(1, 1)
@@ -286,3 +428,31 @@ func TestUpdateVisTeachesPlayer(t *testing.T) {
 		}
 	}
 }
+
+func TestMonstersKnowSecretDoors(t *testing.T) {
+	g := newTestGame()
+	l := NewLevel(5, 3, g, func(l *Level) *Level {
+		StringLevel(`
+#####
+#@S #
+#####`)(l)
+		l.Place(g.NewObj(Monsters[0]), math.Pt(3, 1))
+		return l
+	})
+	g.Level = l
+	door, orc := l.At(math.Pt(2, 1)), l.At(math.Pt(3, 1)).Actor
+
+	if !orc.Senser.Knows(door) {
+		t.Error(`Monster doesn't know about the secret door on its own level`)
+	}
+	if g.Player.Senser.Knows(door) {
+		t.Error(`Player knows about a secret door they haven't found`)
+	}
+
+	if path, ok := l.FindPathFor(orc, math.Pt(1, 1), PathCost); !ok || len(path) != 2 {
+		t.Errorf(`FindPathFor(orc) was %v, %v; want a path through the secret door`, path, ok)
+	}
+	if path, ok := l.FindPath(orc.Pos(), math.Pt(1, 1), PathCost); ok {
+		t.Errorf(`FindPath went through a secret door: %v`, path)
+	}
+}
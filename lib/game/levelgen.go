@@ -1,6 +1,7 @@
 package game
 
 import (
+	"fmt"
 	"github.com/MichaelDiBernardo/srl/lib/math"
 	"log"
 )
@@ -33,19 +34,10 @@ func StringLevel(pic string) func(l *Level) *Level {
 				y++
 				x = 0
 				continue
-			case '#':
-				m[y][x].Feature = FeatWall
-			case ' ':
-				m[y][x].Feature = FeatFloor
-			case '+':
-				m[y][x].Feature = FeatClosedDoor
-			case '\'':
-				m[y][x].Feature = FeatOpenDoor
 			case '@':
 				placepoint = math.Pt(x, y)
-			default:
-				m[y][x].Feature = FeatFloor
 			}
+			m[y][x].Feature = picfeature(s)
 			x++
 		}
 		// Place at the end so that the player doesn't get LOS of a partially
@@ -55,6 +47,30 @@ func StringLevel(pic string) func(l *Level) *Level {
 	}
 }
 
+// The features that pictures of levels are drawn with.
+var picFeatures = map[rune]*Feature{
+	'#':  FeatWall,
+	' ':  FeatFloor,
+	'.':  FeatFloor,
+	'+':  FeatClosedDoor,
+	'\'': FeatOpenDoor,
+	'S':  FeatSecretDoor,
+	'~':  FeatShallowWater,
+	'W':  FeatDeepWater,
+	'%':  FeatRubble,
+	':':  FeatChasm,
+	'L':  FeatLava,
+}
+
+// The feature that 'ch' stands for in a picture of a level. Anything that
+// isn't in picFeatures is something standing on the floor.
+func picfeature(ch rune) *Feature {
+	if f, ok := picFeatures[ch]; ok {
+		return f
+	}
+	return FeatFloor
+}
+
 // This implements lynn's algorithm for laying out angband-ish rooms
 // without a lot of fuss.
 //
@@ -116,9 +132,30 @@ func LynnRoomsLevel(l *Level) *Level {
 		ri++
 		nrooms++
 	}
-	path := dig(joints[0], joints[nrooms-1])
-	drawpath(l, path, rooms)
-	// Don't need to add to paths anymore since we're done placing rooms.
+
+	if nrooms == 0 {
+		// We couldn't fit a single room in. Rather than leave the player
+		// nowhere to stand, make the whole level one big room.
+		log.Print("\tNo rooms fit; making one big one.")
+		whole := math.Rect(math.Pt(1, 1), math.Pt(l.Bounds.Width()-1, l.Bounds.Height()-1))
+		rooms = append(rooms, whole)
+		joints = append(joints, whole.Center())
+		fillmap(m, whole, FeatFloor)
+		nrooms++
+	} else {
+		path := dig(joints[0], joints[nrooms-1])
+		drawpath(l, path, rooms)
+		paths = append(paths, path...)
+	}
+
+	// Vaults go wherever there's room left over.
+	vaults := placevaults(l, rooms, joints, paths)
+	// Later corridors can leave doors from earlier ones somewhere that makes
+	// no sense.
+	tidydoors(l)
+
+	l.stats.Rooms, l.stats.Vaults = nrooms, len(vaults)
+	l.stats.Corridor = countcorridor(l, append(rooms, vaults...))
 
 	startroom := rooms[RandInt(0, nrooms)]
 	l.Place(l.game.Player, startroom.Center())
@@ -126,6 +163,8 @@ func LynnRoomsLevel(l *Level) *Level {
 	placemonsters(l, startroom, rooms)
 	placeitems(l, rooms)
 	placestairs(l, rooms)
+	placetraps(l, rooms)
+	placeterrain(l, rooms)
 
 	log.Printf("Made %d/%d rooms.", nrooms, maxrooms)
 	return l
@@ -235,8 +274,11 @@ func makejoint(room math.Rectangle) math.Point {
 	)
 }
 
+// One in this many of the doors that corridors get are secret.
+const secretDoorChance = 10
+
 // Draws the path from startroom to endroom. Also places closed doors at egress
-// of each room that is intersected along the way.
+// of each room that is intersected along the way; a few of them are secret.
 func drawpath(l *Level, path []math.Point, rooms []math.Rectangle) {
 	// Predicate that tells us if we should place a door.
 	placedoor := func(i int, pt math.Point) bool {
@@ -254,14 +296,67 @@ func drawpath(l *Level, path []math.Point, rooms []math.Rectangle) {
 		return false
 	}
 	for i, pt := range path {
-		if tile := l.At(pt); placedoor(i, pt) {
-			tile.Feature = FeatClosedDoor
-		} else {
+		tile := l.At(pt)
+		switch {
+		case !placedoor(i, pt):
 			tile.Feature = FeatFloor
+		case OneIn(secretDoorChance):
+			tile.Feature = FeatSecretDoor
+		default:
+			tile.Feature = FeatClosedDoor
 		}
 	}
 }
 
+// Counts the tiles outside of 'areas' that aren't wall, which is how much
+// corridor has been dug between them.
+func countcorridor(l *Level, areas []math.Rectangle) int {
+	n := 0
+	for _, row := range l.Map {
+	tiles:
+		for _, tile := range row {
+			if tile.Feature == FeatWall {
+				continue
+			}
+			for _, area := range areas {
+				if area.HasPoint(tile.Pos) {
+					continue tiles
+				}
+			}
+			n++
+		}
+	}
+	return n
+}
+
+// Turns any door that isn't set into a wall with a way through it into floor.
+// This happens when one corridor runs right alongside another one's door.
+func tidydoors(l *Level) {
+	for _, row := range l.Map {
+		for _, tile := range row {
+			isdoor := tile.Feature == FeatClosedDoor || tile.Feature == FeatOpenDoor || tile.Feature == FeatSecretDoor
+			if isdoor && !sanedoor(l, tile.Pos) {
+				tile.Feature = FeatFloor
+			}
+		}
+	}
+}
+
+// Is the door at 'pos' set into a wall, with a way through on either side?
+func sanedoor(l *Level, pos math.Point) bool {
+	wall := func(dx, dy int) bool {
+		pt := pos.Add(math.Pt(dx, dy))
+		return !pt.In(l) || l.At(pt).Feature == FeatWall
+	}
+	open := func(dx, dy int) bool {
+		pt := pos.Add(math.Pt(dx, dy))
+		return pt.In(l) && l.At(pt).Feature != FeatWall
+	}
+	across := wall(0, -1) && wall(0, 1) && open(-1, 0) && open(1, 0)
+	down := wall(-1, 0) && wall(1, 0) && open(0, -1) && open(0, 1)
+	return across || down
+}
+
 // Generates and places monsters in any room except the starting room.
 func placemonsters(l *Level, startroom math.Rectangle, rooms []math.Rectangle) {
 	g := l.game
@@ -329,16 +424,39 @@ func placestairs(l *Level, rooms []math.Rectangle) {
 		return false
 	}
 
+	// If we got unlucky and couldn't find anywhere for stairs we need, look
+	// through every room in order until we find a spot.
+	placeanywhere := func(feat *Feature) bool {
+		for _, room := range rooms {
+			for y := room.Min.Y; y < room.Max.Y; y++ {
+				for x := room.Min.X; x < room.Max.X; x++ {
+					tile := l.Map[y][x]
+					if tile.Feature == FeatFloor && tile.Items.Empty() && tile.Actor == nil {
+						tile.Feature = feat
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+
 	for i := 0; i <= up; i++ {
 		if place(FeatStairsUp) {
 			nup++
 		}
 	}
+	if up >= 0 && nup == 0 && placeanywhere(FeatStairsUp) {
+		nup++
+	}
 	for i := 0; i <= down; i++ {
 		if place(FeatStairsDown) {
 			ndown++
 		}
 	}
+	if down >= 0 && ndown == 0 && placeanywhere(FeatStairsDown) {
+		ndown++
+	}
 	log.Printf("Placed stairs -- %d up, %d down", nup, ndown)
 
 	// Place the connecting stair.
@@ -380,7 +498,64 @@ func drange(x, y int, incl bool) (start, end, incr int) {
 	return start, end, incr
 }
 
+// A way of generating levels.
+type LevelGen struct {
+	Name string
+	Gen  func(*Level) *Level
+	// How likely this is to be picked on the given floor, compared to the
+	// other generators.
+	Weight func(floor int) int
+}
+
+// All of the ways NewDungeon can generate a level. The lower floors are mostly
+// rooms, and caves take over as you climb.
+var LevelGens = []*LevelGen{
+	{
+		Name: "rooms",
+		Gen:  LynnRoomsLevel,
+		Weight: func(floor int) int {
+			return math.Max(MaxFloor-floor+1, 1)
+		},
+	},
+	{
+		Name: "caves",
+		Gen:  CaveLevel,
+		Weight: func(floor int) int {
+			return math.Max(floor-1, 0)
+		},
+	},
+}
+
+// Randomly picks one of LevelGens to generate 'floor' with.
+func ChooseLevelGen(floor int) *LevelGen {
+	choices := make([]Weighter, 0, len(LevelGens))
+	for _, gen := range LevelGens {
+		choices = append(choices, levelGenChoice{gen: gen, floor: floor})
+	}
+	_, chosen := WChoose(choices)
+	return chosen.(levelGenChoice).gen
+}
+
+// Lets WChoose pick level generators by how likely they are on a floor.
+type levelGenChoice struct {
+	gen   *LevelGen
+	floor int
+}
+
+func (c levelGenChoice) Weight() int {
+	return c.gen.Weight(c.floor)
+}
+
 // Generates a new dungeon level.
 func NewDungeon(g *Game) *Level {
-	return NewLevel(80, 80, g, LynnRoomsLevel)
+	gen := ChooseLevelGen(g.Progress.Floor)
+	log.Printf("Generating floor %d with %s.", g.Progress.Floor, gen.Name)
+	l := NewLevel(80, 80, g, gen.Gen)
+
+	if checkLevels {
+		if errs := l.Check(); len(errs) != 0 {
+			panic(fmt.Sprintf("Generated a bad level with %s: %v", gen.Name, errs))
+		}
+	}
+	return l
 }
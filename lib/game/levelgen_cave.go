@@ -0,0 +1,248 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"log"
+)
+
+const (
+	// How much of a cave starts out as wall before smoothing, in percent.
+	caveFill = 45
+	// How many smoothing passes break up big open areas, and how many just
+	// tidy up afterwards.
+	caveBreakupPasses = 4
+	caveTidyPasses    = 3
+	// Regions of floor smaller than this get filled in instead of joined up.
+	caveMinRegion = 20
+	// If less than this percent of the level ends up as floor, we start over.
+	caveMinFloor = 30
+	// How big the sectors that stand in for rooms are.
+	caveSectorSize = 10
+)
+
+// Generates organic-looking caves using a cellular automaton.
+//
+// We start by filling the level with random walls, and then smooth it out a
+// few times: each tile becomes a wall if most of the tiles around it are walls,
+// and floor otherwise. The first few passes also put walls in the middle of big
+// open areas, so that we don't end up with one huge cavern.
+//
+// Then we flood-fill to find the separate regions of floor. Tiny ones get
+// filled in, and the rest are dug through to the biggest one. Finally, the
+// level is cut up into sectors, which stand in for rooms when we place the
+// player, monsters, items and stairs.
+func CaveLevel(l *Level) *Level {
+	for tries := 0; tries < 20; tries++ {
+		scattercave(l)
+		for i := 0; i < caveBreakupPasses; i++ {
+			smoothcave(l, true)
+		}
+		for i := 0; i < caveTidyPasses; i++ {
+			smoothcave(l, false)
+		}
+		l.stats.Corridor = joincave(l)
+
+		if floor := countfloor(l); floor*100 >= l.Bounds.Width()*l.Bounds.Height()*caveMinFloor {
+			break
+		}
+		log.Print("\tCave came out too small; trying again.")
+	}
+
+	sectors := cavesectors(l)
+	l.stats.Rooms = len(sectors)
+	startroom := sectors[RandInt(0, len(sectors))]
+	// Sectors are at least a quarter floor, so this won't take long.
+	for !l.Place(l.game.Player, randpoint(startroom)) {
+	}
+
+	placemonsters(l, startroom, sectors)
+	placeitems(l, sectors)
+	placestairs(l, sectors)
+	placetraps(l, sectors)
+	placeterrain(l, sectors)
+
+	log.Printf("Made cave with %d sectors.", len(sectors))
+	return l
+}
+
+// Fills the level with random walls, with a solid wall all the way around the
+// edge.
+func scattercave(l *Level) {
+	width, height := l.Bounds.Width(), l.Bounds.Height()
+	for y, row := range l.Map {
+		for x, tile := range row {
+			edge := x == 0 || y == 0 || x == width-1 || y == height-1
+			if edge || RandInt(0, 100) < caveFill {
+				tile.Feature = FeatWall
+			} else {
+				tile.Feature = FeatFloor
+			}
+		}
+	}
+}
+
+// Runs one pass of the cellular automaton over the level. A tile becomes wall
+// if at least 5 of the 9 tiles in and around it are walls. If 'breakup' is
+// set, it also becomes wall if there are hardly any walls within 2 tiles of it.
+func smoothcave(l *Level, breakup bool) {
+	width, height := l.Bounds.Width(), l.Bounds.Height()
+	walls := make([][]bool, height)
+	for y := range walls {
+		walls[y] = make([]bool, width)
+		for x := range walls[y] {
+			pt := math.Pt(x, y)
+			if x == 0 || y == 0 || x == width-1 || y == height-1 {
+				walls[y][x] = true
+				continue
+			}
+			near, far := countwalls(l, pt, 1), countwalls(l, pt, 2)
+			walls[y][x] = near >= 5 || (breakup && far <= 2)
+		}
+	}
+
+	for y, row := range walls {
+		for x, wall := range row {
+			if wall {
+				l.Map[y][x].Feature = FeatWall
+			} else {
+				l.Map[y][x].Feature = FeatFloor
+			}
+		}
+	}
+}
+
+// Counts the walls within 'radius' of 'pt', including 'pt' itself. Anything off
+// the edge of the map counts as wall.
+func countwalls(l *Level, pt math.Point, radius int) int {
+	n := 0
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if p := pt.Add(math.Pt(dx, dy)); !p.In(l) || l.At(p).Feature == FeatWall {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Finds every separate region of floor on the level.
+func caveregions(l *Level) [][]math.Point {
+	regions := make([][]math.Point, 0)
+	seen := map[math.Point]bool{}
+
+	for _, row := range l.Map {
+		for _, tile := range row {
+			if tile.Feature.Solid || seen[tile.Pos] {
+				continue
+			}
+			region := []math.Point{tile.Pos}
+			seen[tile.Pos] = true
+			for i := 0; i < len(region); i++ {
+				for _, n := range l.Around(region[i]) {
+					if !n.Feature.Solid && !seen[n.Pos] {
+						seen[n.Pos] = true
+						region = append(region, n.Pos)
+					}
+				}
+			}
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
+
+// Makes sure every bit of floor on the level can be reached from every other.
+// Tiny regions are filled in, and the rest get a corridor dug from them to the
+// nearest part of the biggest region. Returns how many tiles of wall were dug
+// out to join them.
+func joincave(l *Level) int {
+	regions := caveregions(l)
+	if len(regions) == 0 {
+		return 0
+	}
+
+	biggest := 0
+	for i, region := range regions {
+		if len(region) > len(regions[biggest]) {
+			biggest = i
+		}
+	}
+	joined, dug := regions[biggest], 0
+
+	// Fill in the tiny regions before digging anything, so that we don't wall
+	// up a corridor that went through one of them.
+	for i, region := range regions {
+		if i != biggest && len(region) < caveMinRegion {
+			for _, pt := range region {
+				l.At(pt).Feature = FeatWall
+			}
+		}
+	}
+
+	for i, region := range regions {
+		if i == biggest || len(region) < caveMinRegion {
+			continue
+		}
+
+		from := region[RandInt(0, len(region))]
+		to := joined[0]
+		for _, pt := range joined {
+			if math.ChebyDist(from, pt) < math.ChebyDist(from, to) {
+				to = pt
+			}
+		}
+
+		path := dig(from, to)
+		for _, pt := range path {
+			if tile := l.At(pt); tile.Feature == FeatWall {
+				tile.Feature = FeatFloor
+				dug++
+			}
+		}
+		joined = append(joined, path...)
+		joined = append(joined, region...)
+	}
+	return dug
+}
+
+// Counts the tiles on the level that aren't solid.
+func countfloor(l *Level) int {
+	n := 0
+	for _, row := range l.Map {
+		for _, tile := range row {
+			if !tile.Feature.Solid {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Cuts the level up into sectors that have enough floor in them to be worth
+// putting things in. If none do, the whole level is one sector.
+func cavesectors(l *Level) []math.Rectangle {
+	sectors := make([]math.Rectangle, 0)
+	for y := 0; y < l.Bounds.Height(); y += caveSectorSize {
+		for x := 0; x < l.Bounds.Width(); x += caveSectorSize {
+			min := math.Pt(x, y)
+			sector := math.Rect(min, min.Add(math.Pt(caveSectorSize, caveSectorSize))).Intersect(l.Bounds)
+
+			floor := 0
+			for sy := sector.Min.Y; sy < sector.Max.Y; sy++ {
+				for sx := sector.Min.X; sx < sector.Max.X; sx++ {
+					if !l.Map[sy][sx].Feature.Solid {
+						floor++
+					}
+				}
+			}
+			if floor*4 >= sector.Width()*sector.Height() {
+				sectors = append(sectors, sector)
+			}
+		}
+	}
+
+	if len(sectors) == 0 {
+		sectors = append(sectors, l.Bounds)
+	}
+	return sectors
+}
@@ -0,0 +1,68 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+func TestCaveLevelIsConnected(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		g := newTestGame()
+		g.Progress.Floor = 3
+		l := NewLevel(80, 80, g, CaveLevel)
+		g.Level = l
+
+		reach := l.FlowMap([]math.Point{g.Player.Pos()}, PathCost, walkable)
+		stairs := 0
+		for _, row := range l.Map {
+			for _, tile := range row {
+				pos := tile.Pos
+				edge := pos.X == 0 || pos.Y == 0 || pos.X == 79 || pos.Y == 79
+				if edge && tile.Feature != FeatWall {
+					t.Errorf(`Cave %d has a hole in its edge at %v`, i, pos)
+				}
+				if walkable(tile) && reach.At(pos) == FlowUnreachable {
+					t.Errorf(`Cave %d: %v can't be reached from the player`, i, pos)
+				}
+				if tile.Feature == FeatStairsUp || tile.Feature == FeatStairsDown {
+					stairs++
+				}
+			}
+		}
+		if stairs == 0 {
+			t.Errorf(`Cave %d has no stairs`, i)
+		}
+	}
+}
+
+func TestFirstFloorIsAlwaysRooms(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		if gen := ChooseLevelGen(1); gen.Name != "rooms" {
+			t.Errorf(`ChooseLevelGen(1) picked %s; want rooms`, gen.Name)
+		}
+	}
+}
+
+func TestCavesAreMoreLikelyDeeper(t *testing.T) {
+	var rooms, caves *LevelGen
+	for _, gen := range LevelGens {
+		switch gen.Name {
+		case "rooms":
+			rooms = gen
+		case "caves":
+			caves = gen
+		}
+	}
+
+	for floor := 2; floor <= MaxFloor; floor++ {
+		if caves.Weight(floor) <= caves.Weight(floor-1) {
+			t.Errorf(`Caves were no more likely on floor %d than %d`, floor, floor-1)
+		}
+		if rooms.Weight(floor) > rooms.Weight(floor-1) {
+			t.Errorf(`Rooms were more likely on floor %d than %d`, floor, floor-1)
+		}
+	}
+	if caves.Weight(MaxFloor) <= rooms.Weight(MaxFloor) {
+		t.Errorf(`Caves weren't the most likely on the top floor`)
+	}
+}
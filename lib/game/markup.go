@@ -0,0 +1,113 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Messages can be marked up with semantic tags that suggest to the client how
+// the tagged text should be styled, e.g.
+//
+//     "<monster>ORC</monster> hits YOU (<dmg>5</dmg>)."
+//
+// Tags can't be nested. Anything that looks like a tag but isn't one of the
+// tags below is left in the text as-is.
+
+// How a piece of message text should be styled.
+type Style uint
+
+const (
+	StylePlain Style = iota
+	StyleMonster
+	StyleDamage
+	StyleGood
+	StyleBad
+	StyleItem
+	StyleCrit
+)
+
+// The tag used for each style in markup.
+var styletags = map[Style]string{
+	StyleMonster: "monster",
+	StyleDamage:  "dmg",
+	StyleGood:    "good",
+	StyleBad:     "bad",
+	StyleItem:    "item",
+	StyleCrit:    "crit",
+}
+
+// A run of message text that is all styled the same way.
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// Wraps 'text' in the tag for 'style'.
+func mark(style Style, text string) string {
+	tag, ok := styletags[style]
+	if !ok {
+		return text
+	}
+	return fmt.Sprintf("<%s>%s</%s>", tag, text, tag)
+}
+
+// Splits marked-up text into styled spans. Adjacent plain text is merged into
+// a single span, and empty spans are left out.
+func ParseMarkup(text string) []Span {
+	spans := []Span{}
+	plain := ""
+
+	for len(text) > 0 {
+		style, inner, rest, ok := nexttag(text)
+		if !ok {
+			plain += text[:1]
+			text = text[1:]
+			continue
+		}
+		text = rest
+		if inner == "" {
+			continue
+		}
+		if plain != "" {
+			spans = append(spans, Span{Text: plain})
+			plain = ""
+		}
+		spans = append(spans, Span{Text: inner, Style: style})
+	}
+
+	if plain != "" {
+		spans = append(spans, Span{Text: plain})
+	}
+	return spans
+}
+
+// Strips all markup from 'text', for logs and clients that can't show styles.
+func PlainText(text string) string {
+	plain := ""
+	for _, span := range ParseMarkup(text) {
+		plain += span.Text
+	}
+	return plain
+}
+
+// If 'text' starts with a complete tagged span, returns its style, the text
+// inside the tags, and whatever follows the closing tag.
+func nexttag(text string) (style Style, inner, rest string, ok bool) {
+	if !strings.HasPrefix(text, "<") {
+		return StylePlain, "", text, false
+	}
+	for style, tag := range styletags {
+		open, close := "<"+tag+">", "</"+tag+">"
+		if !strings.HasPrefix(text, open) {
+			continue
+		}
+		end := strings.Index(text[len(open):], close)
+		if end < 0 {
+			return StylePlain, "", text, false
+		}
+		inner = text[len(open) : len(open)+end]
+		rest = text[len(open)+end+len(close):]
+		return style, inner, rest, true
+	}
+	return StylePlain, "", text, false
+}
@@ -0,0 +1,61 @@
+package game
+
+import (
+	"testing"
+)
+
+type markupTest struct {
+	text  string
+	spans []Span
+}
+
+var markupTests = []markupTest{
+	{"", []Span{}},
+	{"plain", []Span{{Text: "plain"}}},
+	{"<monster>ORC</monster>", []Span{{Text: "ORC", Style: StyleMonster}}},
+	{
+		"<monster>ORC</monster> hits YOU (<dmg>5</dmg>).",
+		[]Span{
+			{Text: "ORC", Style: StyleMonster},
+			{Text: " hits YOU ("},
+			{Text: "5", Style: StyleDamage},
+			{Text: ")."},
+		},
+	},
+	// Empty spans are dropped.
+	{"a<good></good>b", []Span{{Text: "ab"}}},
+	// Unknown and unclosed tags are left alone.
+	{"<nope>x</nope>", []Span{{Text: "<nope>x</nope>"}}},
+	{"<bad>x", []Span{{Text: "<bad>x"}}},
+	{"1 < 2", []Span{{Text: "1 < 2"}}},
+}
+
+func TestParseMarkup(t *testing.T) {
+	for i, test := range markupTests {
+		spans := ParseMarkup(test.text)
+		if len(spans) != len(test.spans) {
+			t.Errorf(`Test %d: ParseMarkup(%q) was %+v, want %+v`, i, test.text, spans, test.spans)
+			continue
+		}
+		for j, span := range spans {
+			if span != test.spans[j] {
+				t.Errorf(`Test %d: span %d was %+v, want %+v`, i, j, span, test.spans[j])
+			}
+		}
+	}
+}
+
+func TestMarkRoundTrips(t *testing.T) {
+	text := mark(StyleItem, "SWORD of Fire")
+	spans := ParseMarkup(text)
+	if len(spans) != 1 || spans[0].Style != StyleItem || spans[0].Text != "SWORD of Fire" {
+		t.Errorf(`ParseMarkup(mark(...)) was %+v`, spans)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	ev := MessageEvent{Text: "<monster>ORC</monster> hits YOU (<dmg>5</dmg>). <crit>1x critical!</crit>"}
+	if p, w := ev.Plain(), "ORC hits YOU (5). 1x critical!"; p != w {
+		t.Errorf(`ev.Plain() was %q, want %q`, p, w)
+	}
+}
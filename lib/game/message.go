@@ -4,6 +4,20 @@ import (
 	"strings"
 )
 
+// An actor's name for use in messages. Anyone but the player is marked up as a
+// monster.
+func actorname(o *Obj) string {
+	if o.IsPlayer() {
+		return o.Spec.Name
+	}
+	return mark(StyleMonster, o.Spec.Name)
+}
+
+// An item's description for use in messages.
+func itemname(o *Obj) string {
+	return mark(StyleItem, o.Describe())
+}
+
 // Return a possessive for the given noun.
 func poss(noun string) string {
 	if strings.HasSuffix(noun, "s") {
@@ -0,0 +1,138 @@
+package game
+
+// Every derived number on a sheet -- stats, skills, melee, evasion, protection
+// and speed -- is computed as a base value plus a stack of modifiers. Each
+// modifier is tagged with the source that contributed it, so that the final
+// number can always be explained to the player.
+
+// The broad kind of thing that contributed a modifier.
+type ModKind uint
+
+const (
+	// Something the actor just has, e.g. a monster's natural armor.
+	ModBase ModKind = iota
+	// A skill's bonus from the stat that governs it.
+	ModStat
+	ModEquipment
+	ModEffect
+	ModRace
+	ModAbility
+	// Temporary conditions like stun, blindness and paralysis.
+	ModStatus
+)
+
+// Where a modifier came from, e.g. {ModEffect, "DRAIN"} or {ModEquipment,
+// "SWORD"}. Modifiers from the same source to the same number are combined.
+type ModSource struct {
+	Kind ModKind
+	Name string
+}
+
+// Sources that get used from more than one place.
+var (
+	SourceBase     = ModSource{Kind: ModBase, Name: "BASE"}
+	SourceStr      = ModSource{Kind: ModStat, Name: "STR"}
+	SourceAgi      = ModSource{Kind: ModStat, Name: "AGI"}
+	SourceMnd      = ModSource{Kind: ModStat, Name: "MND"}
+	SourceStun     = ModSource{Kind: ModStatus, Name: "STUN"}
+	SourceConfused = ModSource{Kind: ModStatus, Name: "CONFUSED"}
+	SourceBlind    = ModSource{Kind: ModStatus, Name: "BLIND"}
+	SourceHelpless = ModSource{Kind: ModStatus, Name: "HELPLESS"}
+	SourceSlow     = ModSource{Kind: ModStatus, Name: "SLOW"}
+	SourcePetrify  = ModSource{Kind: ModStatus, Name: "PETRIFIED"}
+	SourceStim     = ModSource{Kind: ModEffect, Name: "STIM"}
+	SourceHyper    = ModSource{Kind: ModEffect, Name: "HYPER"}
+	SourceDrain    = ModSource{Kind: ModEffect, Name: "DRAIN"}
+)
+
+// A single contribution to a derived number.
+type Modifier struct {
+	Source ModSource
+	Amount int
+}
+
+// A set of dice contributed to a roll by a single source.
+type DiceMod struct {
+	Source ModSource
+	Dice   Dice
+}
+
+// Explains a derived number as its base value plus every modifier that was
+// applied to it, in the order they were applied.
+type Breakdown struct {
+	Base int
+	Mods []Modifier
+}
+
+// The final value of the number being explained.
+func (b Breakdown) Total() int {
+	total := b.Base
+	for _, mod := range b.Mods {
+		total += mod.Amount
+	}
+	return total
+}
+
+// Add a modifier from 'src'. Zero modifiers are left out, since they don't
+// explain anything.
+func (b *Breakdown) add(src ModSource, amt int) {
+	if amt == 0 {
+		return
+	}
+	for i, mod := range b.Mods {
+		if mod.Source == src {
+			b.Mods[i].Amount += amt
+			return
+		}
+	}
+	b.Mods = append(b.Mods, Modifier{Source: src, Amount: amt})
+}
+
+// Add every modifier in 'mods'.
+func (b *Breakdown) addall(mods modlist) {
+	for _, mod := range mods {
+		b.add(mod.Source, mod.Amount)
+	}
+}
+
+// Apply a penalty that transforms the running total instead of adding to it,
+// e.g. halving melee when blind. The difference is recorded as a modifier from
+// 'src' so that the breakdown still adds up.
+func (b *Breakdown) penalize(src ModSource, penalty func(int) int) {
+	total := b.Total()
+	b.add(src, penalty(total)-total)
+}
+
+// The modifiers that have been applied to a single number on a sheet. Lists
+// are never changed in place, so they can be safely shared when sheets are
+// copied.
+type modlist []Modifier
+
+// The sum of all the modifiers in this list.
+func (l modlist) total() int {
+	total := 0
+	for _, mod := range l {
+		total += mod.Amount
+	}
+	return total
+}
+
+// Returns a new list with the modifier from 'src' changed by 'diff'. Sources
+// whose modifiers drop to 0 are removed.
+func (l modlist) change(src ModSource, diff int) modlist {
+	changed := make(modlist, 0, len(l)+1)
+	found := false
+	for _, mod := range l {
+		if mod.Source == src {
+			mod.Amount += diff
+			found = true
+		}
+		if mod.Amount != 0 {
+			changed = append(changed, mod)
+		}
+	}
+	if !found && diff != 0 {
+		changed = append(changed, Modifier{Source: src, Amount: diff})
+	}
+	return changed
+}
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestModlistChange(t *testing.T) {
+	l := modlist{}
+	l = l.change(SourceStun, -2)
+	l = l.change(SourceStim, 2)
+	l = l.change(SourceStun, -2)
+
+	if n := len(l); n != 2 {
+		t.Fatalf(`len(l) was %d, want 2`, n)
+	}
+	if total := l.total(); total != -2 {
+		t.Errorf(`l.total() was %d, want -2`, total)
+	}
+
+	l = l.change(SourceStim, -2)
+	if n := len(l); n != 1 {
+		t.Errorf(`len(l) was %d after zeroing a source, want 1`, n)
+	}
+}
+
+func TestModlistChangeDoesntAlias(t *testing.T) {
+	orig := modlist{}.change(SourceStun, -2)
+	changed := orig.change(SourceStun, -2)
+
+	if a := orig[0].Amount; a != -2 {
+		t.Errorf(`Original list was changed to %d, want -2`, a)
+	}
+	if a := changed[0].Amount; a != -4 {
+		t.Errorf(`Changed list was %d, want -4`, a)
+	}
+}
+
+func TestBreakdownTotal(t *testing.T) {
+	b := Breakdown{Base: 3}
+	b.add(SourceAgi, 2)
+	b.add(SourceStun, 0)
+	b.add(SourceAgi, 1)
+
+	if n := len(b.Mods); n != 1 {
+		t.Errorf(`len(b.Mods) was %d, want 1`, n)
+	}
+	if total := b.Total(); total != 6 {
+		t.Errorf(`b.Total() was %d, want 6`, total)
+	}
+}
+
+func TestBreakdownPenalize(t *testing.T) {
+	b := Breakdown{Base: 10}
+	b.penalize(SourceBlind, func(s int) int { return s / 2 })
+
+	if total := b.Total(); total != 5 {
+		t.Errorf(`b.Total() was %d, want 5`, total)
+	}
+	if mod := b.Mods[0]; mod.Source != SourceBlind || mod.Amount != -5 {
+		t.Errorf(`Penalty was %+v, want -5 from BLIND`, mod)
+	}
+}
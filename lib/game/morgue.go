@@ -0,0 +1,260 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// How many of the last messages go into a morgue file.
+const MorgueMessages = 30
+
+// How a game ended.
+type Ending struct {
+	// Did the player conquer the TOWER?
+	Won bool
+	// What killed the player, e.g. "ORC" or "poison". Empty if they won or
+	// if we don't know.
+	Cause string
+}
+
+// Describes the ending, e.g. "Killed by ORC".
+func (e *Ending) Describe() string {
+	switch {
+	case e.Won:
+		return "Conquered the TOWER"
+	case e.Cause == "":
+		return "Killed by unknown causes"
+	default:
+		return "Killed by " + e.Cause
+	}
+}
+
+// Writes a morgue file for this game into 'dir', creating the directory if it
+// doesn't exist. Returns the path of the new file.
+func (g *Game) SaveMorgue(dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	stamp := time.Now().Format("20060102-150405")
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.txt", filesafe(g.Player.Spec.Name), stamp))
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := g.WriteMorgue(f); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// Writes a plain-text account of this game to 'w': the character, how far
+// they got and how it ended, what they killed, the last few messages, and a
+// map of the last floor as the player knew it.
+func (g *Game) WriteMorgue(w io.Writer) error {
+	m := &morgue{w: w}
+	p := g.Player
+	sheet := p.Sheet
+	ending := g.Ending
+	if ending == nil {
+		ending = &Ending{}
+	}
+
+	m.printf("%s the %s\n", p.Spec.Name, p.Spec.Species.Describe())
+	m.printf("%s on floor %d after %d turns.\n", ending.Describe(), g.Progress.Floor, g.Progress.Turns)
+	m.printf("Reached floor %d with %d XP (%d total).\n", g.Progress.MaxFloor, p.Learner.XP(), p.Learner.TotalXP())
+
+	m.section("CHARACTER")
+	for stat := Str; stat < NumStats; stat++ {
+		m.printf("%-6s%3d\n", stat.Describe(), sheet.Stat(stat))
+	}
+	m.printf("\n")
+	for sk := Melee; sk < NumSkills; sk++ {
+		m.printf("%-6s%3d\n", sk.Describe(), sheet.Skill(sk))
+	}
+	m.printf("\n")
+	m.printf("HP    %d:%d\n", sheet.HP(), sheet.MaxHP())
+	m.printf("MP    %d:%d\n", sheet.MP(), sheet.MaxMP())
+	m.printf("FIGHT %s\n", sheet.Attack().Describe())
+	m.printf("DEF   %s\n", sheet.Defense().Describe())
+
+	m.section("EQUIPMENT")
+	worn := p.Equipper.Body().worn()
+	for _, item := range worn {
+		m.printf("%s\n", item.Describe())
+	}
+	if len(worn) == 0 {
+		m.printf("(nothing)\n")
+	}
+
+	m.section("INVENTORY")
+	inv := p.Packer.Inventory()
+	inv.EachItem(func(item *Obj) {
+		m.printf("%s\n", item.Describe())
+	})
+	if inv.Empty() {
+		m.printf("(nothing)\n")
+	}
+
+	m.section("KILLS")
+	m.kills(p.Learner.Kills())
+
+	m.section("LAST MESSAGES")
+	first := g.History.Len() - MorgueMessages
+	if first < 0 {
+		first = 0
+	}
+	for i := first; i < g.History.Len(); i++ {
+		entry := g.History.At(i)
+		m.printf("%7d %s\n", entry.Turn, PlainText(entry.Describe()))
+	}
+
+	m.section("MAP")
+	for _, row := range morguemap(g.Level) {
+		m.printf("%s\n", row)
+	}
+
+	return m.err
+}
+
+// Writes formatted text, remembering the first error so that callers don't
+// have to check every write.
+type morgue struct {
+	w   io.Writer
+	err error
+}
+
+func (m *morgue) printf(format string, args ...interface{}) {
+	if m.err != nil {
+		return
+	}
+	_, m.err = fmt.Fprintf(m.w, format, args...)
+}
+
+func (m *morgue) section(title string) {
+	m.printf("\n%s\n%s\n", title, strings.Repeat("-", len(title)))
+}
+
+// Lists kills by monster name, most killed first.
+func (m *morgue) kills(kills map[Species]int) {
+	names := map[Species]string{}
+	for _, spec := range Monsters {
+		names[spec.Species] = spec.Name
+	}
+
+	species := make([]Species, 0, len(kills))
+	total := 0
+	for s, n := range kills {
+		species = append(species, s)
+		total += n
+	}
+	sort.Slice(species, func(i, j int) bool {
+		si, sj := species[i], species[j]
+		if kills[si] != kills[sj] {
+			return kills[si] > kills[sj]
+		}
+		return names[si] < names[sj]
+	})
+
+	for _, s := range species {
+		m.printf("%4d %s\n", kills[s], names[s])
+	}
+	m.printf("%4d total\n", total)
+}
+
+// Glyphs for features in a morgue map.
+var morgueFeatures = map[*Feature]rune{
+	FeatWall:       '#',
+	FeatFloor:      '.',
+	FeatClosedDoor: '+',
+	FeatOpenDoor:   '\'',
+	FeatStairsUp:   '>',
+	FeatStairsDown: '<',
+	FeatSecretDoor: '#',
+
+	FeatShallowWater: '~',
+	FeatDeepWater:    '~',
+	FeatRubble:       '%',
+	FeatChasm:        ':',
+	FeatLava:         '~',
+
+	FeatPit:          '^',
+	FeatTrapDoor:     '^',
+	FeatSiren:        '^',
+	FeatTeleportRune: '^',
+	FeatDartTrap:     '^',
+	FeatWeb:          '^',
+}
+
+// Draws the parts of 'l' that the player has seen. Actors are only drawn if
+// they were in view. Blank rows above and below what was seen are left out.
+func morguemap(l *Level) []string {
+	rows := []string{}
+	for y := l.Bounds.Min.Y; y < l.Bounds.Max.Y; y++ {
+		row := make([]rune, 0, l.Bounds.Width())
+		for x := l.Bounds.Min.X; x < l.Bounds.Max.X; x++ {
+			row = append(row, morgueglyph(l.Map[y][x]))
+		}
+		rows = append(rows, strings.TrimRight(string(row), " "))
+	}
+
+	for len(rows) > 0 && rows[0] == "" {
+		rows = rows[1:]
+	}
+	for len(rows) > 0 && rows[len(rows)-1] == "" {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+func morgueglyph(t *Tile) rune {
+	if !t.Seen {
+		return ' '
+	}
+	return tileglyph(t, t.Feature.Appearance(), t.Visible, true)
+}
+
+// Draws the actor on 't' if 'actors' is set, or else its top item if 'items'
+// is set, or else 'f', which is what its feature should be drawn as.
+func tileglyph(t *Tile, f *Feature, actors, items bool) rune {
+	if a := t.Actor; a != nil && actors {
+		if a.IsPlayer() {
+			return '@'
+		}
+		return unicode.ToLower([]rune(a.Spec.Name)[0])
+	}
+	if item := t.Items.Top(); item != nil && items {
+		switch {
+		case item.Equipment == nil:
+			return '!'
+		case item.Equipment.Slot == SlotHand:
+			return '|'
+		case item.Equipment.Slot == SlotRelic:
+			return '~'
+		default:
+			return '['
+		}
+	}
+	if ch, ok := morgueFeatures[f]; ok {
+		return ch
+	}
+	return '?'
+}
+
+// Replaces anything in 'name' that might not be allowed in a filename.
+func filesafe(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,182 @@
+package game
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+func TestEndingDescribe(t *testing.T) {
+	tests := []struct {
+		ending Ending
+		want   string
+	}{
+		{Ending{Won: true}, "Conquered the TOWER"},
+		{Ending{Cause: "ORC"}, "Killed by ORC"},
+		{Ending{}, "Killed by unknown causes"},
+	}
+
+	for _, test := range tests {
+		if got := test.ending.Describe(); got != test.want {
+			t.Errorf(`%+v.Describe() was "%s"; want "%s"`, test.ending, got, test.want)
+		}
+	}
+}
+
+func TestKillingPlayerBlamesLastCause(t *testing.T) {
+	g := newTestGame()
+	g.blame(g.Player, "ORC")
+	g.Kill(g.Player)
+
+	if g.Ending == nil {
+		t.Fatal(`game.Kill(player) left Ending nil`)
+	}
+	if got := g.Ending.Cause; got != "ORC" {
+		t.Errorf(`Ending.Cause was "%s"; want "ORC"`, got)
+	}
+	if g.Ending.Won {
+		t.Error(`Ending.Won was true after player died`)
+	}
+}
+
+func TestBlameIgnoresMonsters(t *testing.T) {
+	g := newTestGame()
+	mon := g.NewObj(Monsters[0])
+	g.blame(g.Player, "poison")
+	g.blame(mon, "ORC")
+
+	if g.cause != "poison" {
+		t.Errorf(`Blaming monster changed cause to "%s"; want "poison"`, g.cause)
+	}
+}
+
+func TestFatalBlowBlamesAttacker(t *testing.T) {
+	g := newTestGame()
+	mon := g.NewObj(makeTestHitterSpec(NewEffects(map[Effect]int{})))
+	g.Player.Sheet.setHP(1)
+
+	// Hit with no crits, and do 3 + 2 damage through no prot.
+	FixRandomDie([]int{10, 1, 3, 2, 0})
+	defer RestoreRandom()
+	mon.Fighter.Hit(g.Player.Fighter)
+
+	if g.Ending == nil {
+		t.Fatal(`Player did not die`)
+	}
+	if got := g.Ending.Cause; got != mon.Spec.Name {
+		t.Errorf(`Ending.Cause was "%s"; want "%s"`, got, mon.Spec.Name)
+	}
+}
+
+func TestClimbingPastTopFloorWins(t *testing.T) {
+	g := newTestGame()
+	g.Progress.Floor = MaxFloor
+	level := g.Level
+	g.ChangeFloor(1)
+
+	if g.Ending == nil || !g.Ending.Won {
+		t.Fatalf(`Climbing past top floor gave Ending %+v; want won`, g.Ending)
+	}
+	if m := g.mode; m != ModeGameOver {
+		t.Errorf(`Climbing past top floor changed mode to %v; want %v`, m, ModeGameOver)
+	}
+	if g.Level != level {
+		t.Error(`Climbing past top floor generated a new level`)
+	}
+	if f := g.Progress.Floor; f != MaxFloor {
+		t.Errorf(`Climbing past top floor changed floor to %d; want %d`, f, MaxFloor)
+	}
+}
+
+func TestWriteMorgue(t *testing.T) {
+	g := newTestGame()
+	g.Player.Spec = &Spec{Name: "DEBO", Species: SpecNoldor, Family: FamActor, Genus: GenPlayer}
+	g.Player.Packer.Inventory().Add(g.NewObj(Items[0]))
+	g.Player.Learner.(*ActorLearner).killed[SpecOrc] = 3
+	g.Events.Message("Something <monster>happened</monster>.")
+	g.blame(g.Player, "ORC")
+	g.Kill(g.Player)
+
+	var buf bytes.Buffer
+	if err := g.WriteMorgue(&buf); err != nil {
+		t.Fatalf(`WriteMorgue returned error %v`, err)
+	}
+	morgue := buf.String()
+
+	wants := []string{
+		"DEBO the Noldor",
+		"Killed by ORC on floor 1 after 0 turns.",
+		"STR",
+		"FIGHT",
+		Items[0].Name,
+		"   3 ORC",
+		"Something happened.",
+		"The quest for the TOWER ends...",
+		"#.@#\n",
+	}
+	for _, want := range wants {
+		if !strings.Contains(morgue, want) {
+			t.Errorf(`Morgue did not contain "%s":\n%s`, want, morgue)
+		}
+	}
+	if strings.Contains(morgue, "<monster>") {
+		t.Errorf(`Morgue contained markup:\n%s`, morgue)
+	}
+}
+
+func TestMorgueMapOnlyShowsSeenTiles(t *testing.T) {
+	g := newTestGame()
+	for _, row := range g.Level.Map {
+		for _, tile := range row {
+			tile.Seen, tile.Visible = false, false
+		}
+	}
+	g.Level.At(math.Pt(1, 1)).Seen = true
+	g.Level.At(math.Pt(3, 1)).Seen = true
+	g.Level.At(math.Pt(2, 2)).Seen = true
+
+	rows := morguemap(g.Level)
+	// The player's tile was seen but isn't in view, so they aren't drawn.
+	want := []string{" . #", "  ."}
+
+	if len(rows) != len(want) {
+		t.Fatalf(`morguemap gave %q; want %q`, rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf(`morguemap row %d was %q; want %q`, i, rows[i], want[i])
+		}
+	}
+}
+
+func TestSaveMorgue(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srlmorgue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	g := newTestGame()
+	g.Player.Spec = &Spec{Name: "DE/BO", Family: FamActor, Genus: GenPlayer}
+	g.Kill(g.Player)
+
+	path, err := g.SaveMorgue(filepath.Join(dir, "morgue"))
+	if err != nil {
+		t.Fatalf(`SaveMorgue returned error %v`, err)
+	}
+	if base := filepath.Base(path); !strings.HasPrefix(base, "DE_BO-") {
+		t.Errorf(`SaveMorgue wrote to %s; want a file starting with "DE_BO-"`, base)
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf(`Could not read morgue file: %v`, err)
+	}
+	if !strings.Contains(string(contents), "DE/BO") {
+		t.Errorf(`Morgue file did not contain player name:\n%s`, contents)
+	}
+}
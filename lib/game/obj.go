@@ -36,9 +36,11 @@ func (t *Trait) Obj() *Obj {
 // ingame.
 // 'Floors' is a list of the native floors of this object.
 // 'GroupSize' means "pack size" for monsters and "stack size" for consumables.
+// 'Unique' means that at most one of these will ever be created in a game.
 type Gen struct {
 	Floors    []int
 	GroupSize int
+	Unique    bool
 }
 
 // Should this entry be "findable" in the given range of floors?
@@ -72,6 +74,10 @@ type Spec struct {
 	Name    string
 	Traits  *Traits
 	Gen     Gen
+	// Flavour text. Mostly used for uniques.
+	Lore string
+	// Actors that sink like a stone, and so can't go into deep water.
+	Sinks bool
 }
 
 var nextobjid = 1
@@ -99,6 +105,8 @@ type Obj struct {
 	Ticker   Ticker
 	Dropper  Dropper
 	Learner  Learner
+	Caster   Caster
+	Singer   Singer
 
 	// Item traits. Since these don't ever conceivably need alternate
 	// implementations, they are not interface types.
@@ -128,6 +136,8 @@ type Traits struct {
 	Ticker   func(*Obj) Ticker
 	Dropper  func(*Obj) Dropper
 	Learner  func(*Obj) Learner
+	Caster   func(*Obj) Caster
+	Singer   func(*Obj) Singer
 
 	Equipment  func(*Obj) *Equipment
 	Consumable func(*Obj) *Consumable
@@ -177,6 +187,12 @@ func newObj(spec *Spec) *Obj {
 	if traits.Learner != nil {
 		newobj.Learner = traits.Learner(newobj)
 	}
+	if traits.Caster != nil {
+		newobj.Caster = traits.Caster(newobj)
+	}
+	if traits.Singer != nil {
+		newobj.Singer = traits.Singer(newobj)
+	}
 
 	if traits.Equipment != nil {
 		newobj.Equipment = traits.Equipment(newobj)
@@ -0,0 +1,265 @@
+package game
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"sort"
+)
+
+// How many pixels across each tile is in a preview image.
+const PreviewTileSize = 8
+
+// What a level generator made. Generators fill in the first few; the rest are
+// counted from the finished level.
+type GenStats struct {
+	// How many rooms were placed. Caves count their sectors.
+	Rooms int
+	// How many tiles of corridor were dug to join everything up.
+	Corridor int
+	// How many vaults were carved.
+	Vaults int
+	// How many traps were set.
+	Traps int
+	// How many secret doors there are.
+	SecretDoors int
+	// How many tiles of water, rubble, chasm and lava were laid down.
+	Terrain int
+	// How many items there are lying around.
+	Items int
+	// How many of each monster there are, by name.
+	Monsters map[string]int
+}
+
+// Everything we know about how this level was generated.
+func (l *Level) Stats() GenStats {
+	stats := l.stats
+	stats.Monsters = map[string]int{}
+	for _, row := range l.Map {
+		for _, tile := range row {
+			stats.Items += tile.Items.Len()
+			if tile.Feature == FeatSecretDoor {
+				stats.SecretDoors++
+			}
+			if a := tile.Actor; a != nil && !a.IsPlayer() {
+				stats.Monsters[a.Spec.Name]++
+			}
+		}
+	}
+	return stats
+}
+
+// How many monsters there are in total.
+func (s GenStats) MonsterCount() int {
+	n := 0
+	for _, count := range s.Monsters {
+		n += count
+	}
+	return n
+}
+
+// Finds the generator in LevelGens called 'name'.
+func FindLevelGen(name string) (*LevelGen, bool) {
+	for _, gen := range LevelGens {
+		if gen.Name == name {
+			return gen, true
+		}
+	}
+	return nil, false
+}
+
+// Generates 'floor' on its own, outside of any game, from 'seed'. The same seed
+// always gives the same level. If 'gen' is nil, a generator is picked the same
+// way that NewDungeon does. Returns the level and the generator that made it.
+func PreviewLevel(seed int64, floor int, gen *LevelGen) (*Level, *LevelGen) {
+	SeedRandom(seed)
+	defer RestoreRandom()
+
+	g := NewGame()
+	g.Player = g.NewObj(PlayerSpec)
+	g.Progress.Floor, g.Progress.PrevFloor = floor, floor
+	if gen == nil {
+		gen = ChooseLevelGen(floor)
+	}
+	g.Level = NewLevel(80, 80, g, gen.Gen)
+	return g.Level, gen
+}
+
+// Draws all of 'l' the way a morgue map would, except that secret doors show
+// up as 'S' and hidden traps are drawn like ones that have been found. If
+// 'overlay' is set, monsters and items are drawn too.
+func PreviewMap(l *Level, overlay bool) []string {
+	rows := make([]string, 0, l.Bounds.Height())
+	for _, row := range l.Map {
+		line := make([]rune, 0, len(row))
+		for _, tile := range row {
+			if tile.Feature == FeatSecretDoor {
+				line = append(line, 'S')
+			} else {
+				line = append(line, tileglyph(tile, previewfeature(tile.Feature), overlay, overlay))
+			}
+		}
+		rows = append(rows, string(line))
+	}
+	return rows
+}
+
+// What to draw for 'f' in a preview, where everything is shown as it really
+// is.
+func previewfeature(f *Feature) *Feature {
+	if f.Trap != nil && f.Hidden() {
+		return f.Found
+	}
+	return f
+}
+
+// Colours for features in preview images. Anything not in here is drawn
+// magenta so that it stands out.
+var previewColors = map[*Feature]color.RGBA{
+	FeatWall:       {0x40, 0x40, 0x40, 0xff},
+	FeatFloor:      {0xa0, 0xa0, 0xa0, 0xff},
+	FeatClosedDoor: {0x8b, 0x5a, 0x2b, 0xff},
+	FeatOpenDoor:   {0xc8, 0x96, 0x5a, 0xff},
+	FeatSecretDoor: {0x3c, 0x6e, 0x8c, 0xff},
+	FeatStairsUp:   {0x30, 0xc0, 0x30, 0xff},
+	FeatStairsDown: {0x30, 0x60, 0xe0, 0xff},
+
+	FeatShallowWater: {0x60, 0xb0, 0xe0, 0xff},
+	FeatDeepWater:    {0x10, 0x30, 0xa0, 0xff},
+	FeatRubble:       {0x70, 0x60, 0x50, 0xff},
+	FeatChasm:        {0x00, 0x00, 0x00, 0xff},
+	FeatLava:         {0xe0, 0x40, 0x00, 0xff},
+
+	FeatPit:          {0x60, 0x30, 0x10, 0xff},
+	FeatTrapDoor:     {0x90, 0x40, 0x10, 0xff},
+	FeatSiren:        {0xff, 0x80, 0x00, 0xff},
+	FeatTeleportRune: {0x90, 0x30, 0xd0, 0xff},
+	FeatDartTrap:     {0x20, 0x90, 0x20, 0xff},
+	FeatWeb:          {0xe0, 0xe0, 0xe0, 0xff},
+}
+
+var (
+	previewUnknown = color.RGBA{0xff, 0x00, 0xff, 0xff}
+	previewPlayer  = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	previewMonster = color.RGBA{0xe0, 0x20, 0x20, 0xff}
+	previewItem    = color.RGBA{0xf0, 0xd0, 0x20, 0xff}
+)
+
+// Draws 'l' as a picture, with each tile a PreviewTileSize square. If 'overlay'
+// is set, monsters and items are drawn as a smaller square in the middle of
+// their tile.
+func PreviewImage(l *Level, overlay bool) *image.RGBA {
+	size := PreviewTileSize
+	img := image.NewRGBA(image.Rect(0, 0, l.Bounds.Width()*size, l.Bounds.Height()*size))
+
+	fill := func(x0, y0, x1, y1 int, c color.RGBA) {
+		for y := y0; y < y1; y++ {
+			for x := x0; x < x1; x++ {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+
+	for _, row := range l.Map {
+		for _, tile := range row {
+			x, y := tile.Pos.X*size, tile.Pos.Y*size
+			c, ok := previewColors[previewfeature(tile.Feature)]
+			if !ok {
+				c = previewUnknown
+			}
+			fill(x, y, x+size, y+size, c)
+
+			if !overlay {
+				continue
+			}
+			var mark color.RGBA
+			switch {
+			case tile.Actor != nil && tile.Actor.IsPlayer():
+				mark = previewPlayer
+			case tile.Actor != nil:
+				mark = previewMonster
+			case !tile.Items.Empty():
+				mark = previewItem
+			default:
+				continue
+			}
+			inset := size / 4
+			fill(x+inset, y+inset, x+size-inset, y+size-inset, mark)
+		}
+	}
+	return img
+}
+
+// Writes PreviewImage(l, overlay) to 'w' as a PNG.
+func WritePreviewPNG(w io.Writer, l *Level, overlay bool) error {
+	return png.Encode(w, PreviewImage(l, overlay))
+}
+
+// The smallest, biggest and total of some number over many levels.
+type StatRange struct {
+	Min, Max, Total int
+}
+
+func (r *StatRange) add(n, count int) {
+	if count == 0 || n < r.Min {
+		r.Min = n
+	}
+	if count == 0 || n > r.Max {
+		r.Max = n
+	}
+	r.Total += n
+}
+
+// Sums up the stats of many levels made by the same generator, so that
+// changes to generators can be compared.
+type GenSurvey struct {
+	Levels                                                                int
+	Rooms, Corridor, Vaults, Traps, SecretDoors, Terrain, Items, Monsters StatRange
+	// How many of each monster there were across all of the levels.
+	Species map[string]int
+}
+
+func NewGenSurvey() *GenSurvey {
+	return &GenSurvey{Species: map[string]int{}}
+}
+
+// Adds one more level's stats to the survey.
+func (s *GenSurvey) Add(stats GenStats) {
+	s.Rooms.add(stats.Rooms, s.Levels)
+	s.Corridor.add(stats.Corridor, s.Levels)
+	s.Vaults.add(stats.Vaults, s.Levels)
+	s.Traps.add(stats.Traps, s.Levels)
+	s.SecretDoors.add(stats.SecretDoors, s.Levels)
+	s.Terrain.add(stats.Terrain, s.Levels)
+	s.Items.add(stats.Items, s.Levels)
+	s.Monsters.add(stats.MonsterCount(), s.Levels)
+	for name, n := range stats.Monsters {
+		s.Species[name] += n
+	}
+	s.Levels++
+}
+
+// The average of 'r' over the levels in the survey.
+func (s *GenSurvey) Mean(r StatRange) float64 {
+	if s.Levels == 0 {
+		return 0
+	}
+	return float64(r.Total) / float64(s.Levels)
+}
+
+// The monsters that were seen in the survey, most common first.
+func (s *GenSurvey) CommonSpecies() []string {
+	names := make([]string, 0, len(s.Species))
+	for name := range s.Species {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ni, nj := names[i], names[j]
+		if s.Species[ni] != s.Species[nj] {
+			return s.Species[ni] > s.Species[nj]
+		}
+		return ni < nj
+	})
+	return names
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"bytes"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"image/png"
+	"testing"
+)
+
+func previewTestLevel() *Level {
+	g := newRunTestGame(`
+#######
+#@  S #
+#######`)
+	l := g.Level
+	l.Place(g.NewObj(lTestActor), math.Pt(2, 1))
+	l.Place(g.NewObj(lTestItem), math.Pt(3, 1))
+	l.Place(g.NewObj(lTestItem2), math.Pt(5, 1))
+	return l
+}
+
+func TestPreviewMap(t *testing.T) {
+	l := previewTestLevel()
+
+	tests := []struct {
+		overlay bool
+		want    string
+	}{
+		{false, "#...S.#"},
+		{true, "#@h!S!#"},
+	}
+	for _, test := range tests {
+		if row := PreviewMap(l, test.overlay)[1]; row != test.want {
+			t.Errorf(`PreviewMap(l, %v)[1] was %q; want %q`, test.overlay, row, test.want)
+		}
+	}
+}
+
+func TestPreviewImage(t *testing.T) {
+	l := previewTestLevel()
+	var buf bytes.Buffer
+	if err := WritePreviewPNG(&buf, l, true); err != nil {
+		t.Fatalf(`WritePreviewPNG failed: %v`, err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf(`WritePreviewPNG didn't write a PNG: %v`, err)
+	}
+
+	size := img.Bounds().Size()
+	if want := math.Pt(7*PreviewTileSize, 3*PreviewTileSize); size.X != want.X || size.Y != want.Y {
+		t.Errorf(`Preview image was %v; want %v`, size, want)
+	}
+
+	tests := []struct {
+		tile math.Point
+		want interface{}
+	}{
+		{math.Pt(0, 0), previewColors[FeatWall]},
+		{math.Pt(4, 1), previewColors[FeatSecretDoor]},
+		{math.Pt(1, 1), previewPlayer},
+		{math.Pt(2, 1), previewMonster},
+		{math.Pt(3, 1), previewItem},
+	}
+	for _, test := range tests {
+		mid := PreviewTileSize / 2
+		c := img.At(test.tile.X*PreviewTileSize+mid, test.tile.Y*PreviewTileSize+mid)
+		if r, g, b, _ := c.RGBA(); c != test.want {
+			t.Errorf(`Preview of %v was (%d, %d, %d); want %v`, test.tile, r>>8, g>>8, b>>8, test.want)
+		}
+	}
+}
+
+func TestLevelStats(t *testing.T) {
+	l := previewTestLevel()
+	stats := l.Stats()
+
+	if stats.Items != 2 {
+		t.Errorf(`Stats().Items was %d; want 2`, stats.Items)
+	}
+	if n := stats.Monsters[lTestActor.Name]; n != 1 || stats.MonsterCount() != 1 {
+		t.Errorf(`Stats().Monsters was %v; want one %s`, stats.Monsters, lTestActor.Name)
+	}
+}
+
+func TestPreviewLevelIsRepeatable(t *testing.T) {
+	rooms, ok := FindLevelGen("rooms")
+	if !ok {
+		t.Fatal(`FindLevelGen couldn't find "rooms"`)
+	}
+
+	a, gen := PreviewLevel(7, 2, rooms)
+	b, _ := PreviewLevel(7, 2, rooms)
+	if gen != rooms {
+		t.Errorf(`PreviewLevel used %s; want rooms`, gen.Name)
+	}
+
+	amap, bmap := PreviewMap(a, true), PreviewMap(b, true)
+	for y := range amap {
+		if amap[y] != bmap[y] {
+			t.Fatalf(`Previews from the same seed differ on row %d:\n%s\n%s`, y, amap[y], bmap[y])
+		}
+	}
+
+	stats := a.Stats()
+	if stats.Rooms == 0 || stats.Corridor == 0 || stats.MonsterCount() == 0 {
+		t.Errorf(`Stats() of a rooms level was %+v; want rooms, corridors and monsters`, stats)
+	}
+}
+
+func TestGenSurvey(t *testing.T) {
+	s := NewGenSurvey()
+	s.Add(GenStats{Rooms: 4, Corridor: 100, Monsters: map[string]int{"ORC": 2}})
+	s.Add(GenStats{Rooms: 8, Corridor: 50, Monsters: map[string]int{"ORC": 1, "DRAGON": 3}})
+
+	if s.Rooms.Min != 4 || s.Rooms.Max != 8 || s.Mean(s.Rooms) != 6 {
+		t.Errorf(`Survey rooms were %+v; want 4 to 8, mean 6`, s.Rooms)
+	}
+	if s.Corridor.Min != 50 || s.Corridor.Max != 100 {
+		t.Errorf(`Survey corridor was %+v; want 50 to 100`, s.Corridor)
+	}
+	if s.Monsters.Min != 2 || s.Monsters.Max != 4 {
+		t.Errorf(`Survey monsters were %+v; want 2 to 4`, s.Monsters)
+	}
+	if common := s.CommonSpecies(); len(common) != 2 || common[0] != "DRAGON" {
+		t.Errorf(`CommonSpecies() was %v; want DRAGON first`, common)
+	}
+}
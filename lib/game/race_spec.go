@@ -0,0 +1,60 @@
+package game
+
+// The races and houses a player can choose from during character creation.
+var Races = []*Race{
+	{
+		Name:    "NOLDOR",
+		Species: SpecNoldor,
+		Desc:    "Deep-lore elves of the West; strong in mind and song.",
+		Stats:   statlist{Str: 1, Agi: 2, Vit: 1, Mnd: 2},
+		Skills:  skilllist{Magic: 2, Song: 1, Sense: 1},
+		Effects: NewEffects(map[Effect]int{ResistConfuse: 1}),
+		Kit: []KitItem{
+			{Spec: itemspec(SpecSword), Count: 1},
+			{Spec: itemspec(SpecLeatherArmor), Count: 1},
+			{Spec: itemspec(SpecCure), Count: 2},
+			{Spec: itemspec(SpecRestore), Count: 1},
+		},
+	},
+	{
+		Name:    "SINDAR",
+		Species: SpecSindar,
+		Desc:    "Grey-elves of the forests; quick, quiet and keen-eyed.",
+		Stats:   statlist{Str: 0, Agi: 3, Vit: 0, Mnd: 1},
+		Skills:  skilllist{Stealth: 2, Shooting: 1, Song: 2},
+		Effects: NewEffects(map[Effect]int{ResistBlind: 1}),
+		Kit: []KitItem{
+			{Spec: itemspec(SpecSword), Count: 1},
+			{Spec: itemspec(SpecLeatherArmor), Count: 1},
+			{Spec: itemspec(SpecCure), Count: 2},
+			{Spec: itemspec(SpecStim), Count: 2},
+		},
+	},
+	{
+		Name:    "NAUGRIM",
+		Species: SpecNaugrim,
+		Desc:    "Dwarves of the mountains; stout, stubborn and hard to kill.",
+		Stats:   statlist{Str: 2, Agi: -1, Vit: 3, Mnd: 0},
+		Skills:  skilllist{Chi: 2, Sense: 1},
+		Effects: NewEffects(map[Effect]int{ResistPoison: 1, ResistBlind: 1}),
+		Kit: []KitItem{
+			{Spec: itemspec(SpecSword), Count: 1},
+			{Spec: itemspec(SpecLeatherArmor), Count: 1},
+			{Spec: itemspec(SpecCure), Count: 3},
+		},
+	},
+	{
+		Name:    "EDAIN",
+		Species: SpecEdain,
+		Desc:    "Men of the three houses; brave, and good at most things.",
+		Stats:   statlist{Str: 1, Agi: 1, Vit: 1, Mnd: 0},
+		Skills:  skilllist{Melee: 1, Evasion: 1},
+		Effects: NewEffects(map[Effect]int{ResistFear: 1}),
+		Kit: []KitItem{
+			{Spec: itemspec(SpecSword), Count: 1},
+			{Spec: itemspec(SpecLeatherArmor), Count: 1},
+			{Spec: itemspec(SpecCure), Count: 2},
+			{Spec: itemspec(SpecHyper), Count: 1},
+		},
+	},
+}
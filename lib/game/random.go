@@ -43,6 +43,15 @@ func FixRandomSource(ints []int) func(int) int {
 	return intsource
 }
 
+// Seeds the random generator, so that everything random happens the same way
+// every time for the same 'seed'. Undo this with RestoreRandom.
+func SeedRandom(seed int64) {
+	if oldintsource == nil {
+		oldintsource = intsource
+	}
+	intsource = rand.New(rand.NewSource(seed)).Intn
+}
+
 // An intsource that subtracts 1 to each element in the list. This makes it
 // compatible with rigging dierolls directly, since DieRoll has to add one to
 // each int to represent a roll from 1 to n (instead of 0 to n-1).
@@ -0,0 +1,293 @@
+package game
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+// The cost of travelling over a tile the player has never seen. It's high so
+// that travel sticks to the parts of the map the player knows.
+const travelUnseenCost = 100
+
+// The ways the player can move without sending a command for every step.
+type autoKind int
+
+const (
+	autoRun autoKind = iota
+	autoTravel
+	autoExplore
+)
+
+// A run, travel or exploration that keeps the player moving without the client
+// having to send a command for every step. Game.Handle keeps asking it for the
+// next step until it runs out or something disturbs the player.
+type autoMove struct {
+	kind autoKind
+	// The direction of a run.
+	dir math.Point
+	// Set once a run has decided whether it's following a corridor or going
+	// straight across a room.
+	started  bool
+	corridor bool
+	// Whether the tiles to either side of the last step were open. A run
+	// across a room stops when these change.
+	sides [2]bool
+	// The rest of the route when travelling.
+	path Path
+	// Monsters the player could see after the last step.
+	seen map[*Obj]bool
+	// Whether exploring picks up items.
+	pickup bool
+	// Tiles that we've already been to. Runs stop if they come back around to
+	// one, and exploring won't head for them again.
+	visited map[math.Point]bool
+	// How many items were on each tile the last time exploring tried to pick
+	// one up there. If that doesn't change, the pickup failed.
+	tried map[math.Point]int
+}
+
+// The next command to send to the hud controller, or false if the player
+// should stop here.
+func (a *autoMove) next(g *Game) (Command, bool) {
+	p := g.Player
+	if a.spotted(p) || p.Sheet.Confused() {
+		return nil, false
+	}
+	switch a.kind {
+	case autoTravel:
+		return a.travel(p)
+	case autoExplore:
+		return a.explore(g)
+	}
+	return a.run(p)
+}
+
+// Has a monster come into view since the last step?
+func (a *autoMove) spotted(p *Obj) bool {
+	seen := visibleMonsters(p)
+	spotted := false
+	for mon := range seen {
+		if !a.seen[mon] {
+			spotted = true
+		}
+	}
+	a.seen = seen
+	return spotted
+}
+
+// Takes the next step along a run, unless we've reached something interesting.
+func (a *autoMove) run(p *Obj) (Command, bool) {
+	l, pos := p.Level, p.Pos()
+
+	if here := l.At(pos); here.Feature.Appearance() != FeatFloor || !here.Items.Empty() || a.visited[pos] {
+		return nil, false
+	}
+	a.visited[pos] = true
+
+	// Find out where we could go from here, ignoring the way we came. Doors
+	// and stairs beside the run stop it so the player can have a look.
+	open := make([]math.Point, 0, 8)
+	for _, t := range l.Around(pos) {
+		d := t.Pos.Sub(pos)
+		if behind(d, a.dir) {
+			continue
+		}
+		if f := t.Feature.Appearance(); f != FeatFloor && f != FeatWall {
+			return nil, false
+		}
+		if !t.Feature.Solid {
+			open = append(open, d)
+		}
+	}
+
+	if !a.started {
+		a.started = true
+		_, a.corridor = corridorDir(open)
+	}
+
+	if a.corridor {
+		dir, ok := corridorDir(open)
+		if !ok {
+			return nil, false
+		}
+		a.dir = dir
+	} else if sides := runSides(l, pos, a.dir); sides != a.sides {
+		// Across a room, we go straight until we pass an opening in the walls
+		// beside us.
+		return nil, false
+	}
+
+	ahead := pos.Add(a.dir)
+	if !ahead.In(l) || l.At(ahead).Feature.Solid || l.At(ahead).Actor != nil {
+		return nil, false
+	}
+	return MoveCommand{Dir: a.dir}, true
+}
+
+// Takes the next step along a travel route.
+func (a *autoMove) travel(p *Obj) (Command, bool) {
+	pos := p.Pos()
+	for len(a.path) > 0 && a.path[0] == pos {
+		a.path = a.path[1:]
+	}
+	if len(a.path) == 0 {
+		return nil, false
+	}
+
+	// If we've been knocked off the route or someone's in the way, give the
+	// player a chance to decide what to do.
+	next := a.path[0]
+	if math.ChebyDist(pos, next) != 1 || p.Level.At(next).Actor != nil {
+		return nil, false
+	}
+	return MoveCommand{Dir: next.Sub(pos)}, true
+}
+
+// Which way a corridor goes, given the open tiles around the player. If the
+// corridor splits or ends here, this returns false. At a bend there may be an
+// orthogonal and a diagonal way forward that lead to the same place; we take
+// the orthogonal one.
+func corridorDir(open []math.Point) (math.Point, bool) {
+	switch len(open) {
+	case 1:
+		return open[0], true
+	case 2:
+		a, b := open[0], open[1]
+		if diagonal(a) {
+			a, b = b, a
+		}
+		if !diagonal(a) && diagonal(b) && math.ChebyDist(a, b) == 1 {
+			return a, true
+		}
+	}
+	return math.Origin, false
+}
+
+// Is 'd' a diagonal direction?
+func diagonal(d math.Point) bool {
+	return d.X != 0 && d.Y != 0
+}
+
+// Is 'd' back the way we came, given that we're moving in 'dir'?
+func behind(d, dir math.Point) bool {
+	return d.X*dir.X+d.Y*dir.Y < 0
+}
+
+// Whether the tiles to the left and right of 'pos' are open when moving in
+// 'dir'.
+func runSides(l *Level, pos, dir math.Point) [2]bool {
+	sides := [2]bool{}
+	for i, side := range []math.Point{math.Pt(-dir.Y, dir.X), math.Pt(dir.Y, -dir.X)} {
+		pt := pos.Add(side)
+		sides[i] = pt.In(l) && !l.At(pt).Feature.Solid
+	}
+	return sides
+}
+
+// The monsters that 'p' can see right now.
+func visibleMonsters(p *Obj) map[*Obj]bool {
+	seen := map[*Obj]bool{}
+	for _, pt := range p.Senser.FOV() {
+		if mon := p.Level.At(pt).Actor; mon != nil && !mon.IsPlayer() {
+			seen[mon] = true
+		}
+	}
+	return seen
+}
+
+// Starts the player running in 'dir'. The first step is just a move; if the
+// player actually gets anywhere, Handle keeps them going. Returns true if a
+// turn passes.
+func (g *Game) run(dir math.Point) bool {
+	p := g.Player
+	if !g.canAutoMove() {
+		return false
+	}
+
+	start := p.Pos()
+	a := &autoMove{
+		kind:    autoRun,
+		dir:     dir,
+		sides:   runSides(p.Level, start, dir),
+		seen:    visibleMonsters(p),
+		visited: map[math.Point]bool{start: true},
+	}
+	evolve, _ := p.Mover.Move(dir)
+	if p.Pos() != start {
+		g.auto = a
+	}
+	return evolve
+}
+
+// Starts the player travelling to 'dest', which must be somewhere they've
+// seen. No time passes until Handle takes the first step.
+func (g *Game) travel(dest math.Point) {
+	p, l := g.Player, g.Level
+	if !g.canAutoMove() {
+		return
+	}
+	if !dest.In(l) || !l.At(dest).Seen {
+		g.Events.Message(fmt.Sprintf("%v doesn't know the way there.", p.Spec.Name))
+		return
+	}
+	if dest == p.Pos() {
+		return
+	}
+
+	path, ok := l.FindPath(p.Pos(), dest, travelcost)
+	if !ok {
+		g.Events.Message(fmt.Sprintf("%v can't find a way there.", p.Spec.Name))
+		return
+	}
+	g.auto = &autoMove{kind: autoTravel, path: path, seen: visibleMonsters(p)}
+}
+
+// Starts the player travelling to the nearest stairs they know about, other
+// than the ones they're standing on.
+func (g *Game) travelStairs() {
+	p, l := g.Player, g.Level
+	if !g.canAutoMove() {
+		return
+	}
+
+	stairs := make([]math.Point, 0)
+	for _, row := range l.Map {
+		for _, tile := range row {
+			isstairs := tile.Feature == FeatStairsUp || tile.Feature == FeatStairsDown
+			if isstairs && tile.Seen && tile.Pos != p.Pos() {
+				stairs = append(stairs, tile.Pos)
+			}
+		}
+	}
+
+	// One flow map finds the way to whichever stairs are closest.
+	path, ok := l.FlowMap(stairs, travelcost, patheligible).PathFrom(l, p.Pos())
+	if !ok || len(path) == 0 {
+		g.Events.Message(fmt.Sprintf("%v doesn't know of any stairs.", p.Spec.Name))
+		return
+	}
+	g.auto = &autoMove{kind: autoTravel, path: path, seen: visibleMonsters(p)}
+}
+
+// Can the player run or travel right now?
+func (g *Game) canAutoMove() bool {
+	if p := g.Player; p.Sheet.Confused() {
+		g.Events.Message(fmt.Sprintf("%v is too confused.", p.Spec.Name))
+		return false
+	}
+	return true
+}
+
+// Stops the player running, travelling or exploring. This is called whenever
+// something happens that the player would want to react to, like getting hurt.
+func (g *Game) Disturb() {
+	g.auto = nil
+}
+
+// Returns the cost of travelling onto 'loc' in level l.
+func travelcost(l *Level, loc math.Point) int {
+	if !l.At(loc).Seen {
+		return travelUnseenCost
+	}
+	return PathCost(l, loc)
+}
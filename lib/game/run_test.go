@@ -0,0 +1,224 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"strings"
+	"testing"
+)
+
+var runTestMonster = &Spec{
+	Family:  FamActor,
+	Genus:   GenMonster,
+	Species: "TestSpecies",
+	Name:    "Hi",
+	Traits: &Traits{
+		Sheet:  NewPlayerSheet,
+		Ticker: NewActorTicker,
+	},
+}
+
+// Creates a game on a level drawn by 'pic', which must be exactly as big as
+// the level.
+func newRunTestGame(pic string) *Game {
+	rows := strings.Split(strings.TrimPrefix(pic, "\n"), "\n")
+	g := newTestGame()
+	g.Level = NewLevel(len(rows[0]), len(rows), g, StringLevel(pic))
+	return g
+}
+
+func TestRunFollowsCorridor(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#@     ##
+###### ##
+###### ##
+#########`)
+
+	g.Handle(RunCommand{Dir: math.Pt(1, 0)})
+
+	if pos, want := g.Player.Pos(), math.Pt(6, 3); pos != want {
+		t.Errorf(`Run ended at %v; want %v`, pos, want)
+	}
+	if turns := g.Progress.Turns; turns != 7 {
+		t.Errorf(`Run took %d turns; want 7`, turns)
+	}
+}
+
+func TestRunStops(t *testing.T) {
+	tests := []struct {
+		pic  string
+		dir  math.Point
+		want math.Point
+	}{
+		// At a junction.
+		{
+			pic: `
+#######
+#@    #
+### ###
+#######`,
+			dir:  math.Pt(1, 0),
+			want: math.Pt(3, 1),
+		},
+		// Beside a door.
+		{
+			pic: `
+#######
+#@   +#
+#######`,
+			dir:  math.Pt(1, 0),
+			want: math.Pt(4, 1),
+		},
+		// At an opening in the wall of a room.
+		{
+			pic: `
+### ###
+#@    #
+#     #
+#######`,
+			dir:  math.Pt(1, 0),
+			want: math.Pt(3, 1),
+		},
+		// At the far wall of a room.
+		{
+			pic: `
+#######
+#     #
+#@    #
+#     #
+#######`,
+			dir:  math.Pt(1, 0),
+			want: math.Pt(5, 2),
+		},
+	}
+
+	for i, test := range tests {
+		g := newRunTestGame(test.pic)
+		g.Handle(RunCommand{Dir: test.dir})
+
+		if pos := g.Player.Pos(); pos != test.want {
+			t.Errorf(`Run test %d ended at %v; want %v`, i, pos, test.want)
+		}
+	}
+}
+
+func TestRunStopsOnItems(t *testing.T) {
+	g := newRunTestGame(`
+########
+#@     #
+########`)
+	g.Level.Place(g.NewObj(lTestItem), math.Pt(3, 1))
+
+	g.Handle(RunCommand{Dir: math.Pt(1, 0)})
+
+	if pos, want := g.Player.Pos(), math.Pt(3, 1); pos != want {
+		t.Errorf(`Run ended at %v; want %v`, pos, want)
+	}
+}
+
+func TestRunStopsWhenMonsterComesIntoView(t *testing.T) {
+	g := newRunTestGame(`
+####################
+#@                 #
+####################`)
+	g.Level.Place(g.NewObj(runTestMonster), math.Pt(18, 1))
+
+	g.Handle(RunCommand{Dir: math.Pt(1, 0)})
+
+	if !g.Player.Senser.CanSee(g.Level.At(math.Pt(18, 1)).Actor) {
+		t.Fatal(`Run ended before the monster came into view`)
+	}
+	if pos := g.Player.Pos(); pos.X >= 17 {
+		t.Errorf(`Run ended at %v; should have stopped when the monster came into view`, pos)
+	}
+}
+
+func TestRunStopsWhenHurt(t *testing.T) {
+	g := newRunTestGame(`
+##########
+#@       #
+##########`)
+	g.Player.Ticker.AddEffect(EffectPoison, 100)
+
+	g.Handle(RunCommand{Dir: math.Pt(1, 0)})
+
+	if pos, want := g.Player.Pos(), math.Pt(2, 1); pos != want {
+		t.Errorf(`Poisoned run ended at %v; want %v`, pos, want)
+	}
+}
+
+func TestTravel(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#@    #
+##### #
+#     #
+#######`)
+	seeAll(g.Level)
+	dest := math.Pt(1, 3)
+
+	g.Handle(TravelCommand{Dest: dest})
+
+	if pos := g.Player.Pos(); pos != dest {
+		t.Errorf(`Travel ended at %v; want %v`, pos, dest)
+	}
+	if turns := g.Progress.Turns; turns != 8 {
+		t.Errorf(`Travel took %d turns; want 8`, turns)
+	}
+}
+
+func TestTravelNeedsSeenTile(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#@    #
+##### #
+#     #
+#######`)
+	g.Level.At(math.Pt(1, 3)).Seen = false
+
+	g.Handle(TravelCommand{Dest: math.Pt(1, 3)})
+
+	if pos, want := g.Player.Pos(), math.Pt(1, 1); pos != want {
+		t.Errorf(`Travel to unseen tile moved player to %v; want %v`, pos, want)
+	}
+}
+
+func TestTravelStairsGoesToNearest(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#   @   #
+#########`)
+	seeAll(g.Level)
+	g.Level.At(math.Pt(1, 1)).Feature = FeatStairsUp
+	g.Level.At(math.Pt(6, 1)).Feature = FeatStairsDown
+
+	g.Handle(TravelStairsCommand{})
+
+	if pos, want := g.Player.Pos(), math.Pt(6, 1); pos != want {
+		t.Errorf(`Travel to stairs ended at %v; want %v`, pos, want)
+	}
+}
+
+// Marks every tile on 'l' as seen by the player.
+func seeAll(l *Level) {
+	for _, row := range l.Map {
+		for _, tile := range row {
+			tile.Seen = true
+		}
+	}
+}
+
+func TestRunStopsGoingInCircles(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#@    #
+# ### #
+#     #
+#######`)
+
+	g.Handle(RunCommand{Dir: math.Pt(1, 0)})
+
+	if turns := g.Progress.Turns; turns > 12 {
+		t.Errorf(`Run around a loop took %d turns; want at most 12`, turns)
+	}
+}
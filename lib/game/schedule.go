@@ -104,6 +104,18 @@ func (s *Scheduler) Next() *Obj {
 	return actor
 }
 
+// Makes 'actor' wait 'delay' longer than usual before their next turn, e.g.
+// because they just waded through something.
+func (s *Scheduler) Delay(actor *Obj, delay int) {
+	for i, e := range *(s.pq) {
+		if e.actor == actor {
+			e.delay += delay
+			heap.Fix(s.pq, i)
+			return
+		}
+	}
+}
+
 // Removes an actor from the scheduler.
 func (s *Scheduler) Remove(actor *Obj) {
 	index := -1
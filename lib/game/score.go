@@ -0,0 +1,167 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// What each achievement is worth, on top of the player's total XP.
+const (
+	ScorePerFloor = 100
+	ScorePerKill  = 10
+	ScoreForWin   = 1000
+)
+
+// A record of a single finished game.
+type ScoreEntry struct {
+	Name  string
+	Score int
+	// The deepest floor reached.
+	Floor int
+	Turns int
+	Won   bool
+	// What killed the player, if they didn't win.
+	Cause string
+	Date  time.Time
+}
+
+// Creates an entry for 'g', which should be over.
+func newScoreEntry(g *Game, date time.Time) ScoreEntry {
+	ending := g.Ending
+	if ending == nil {
+		ending = &Ending{}
+	}
+	return ScoreEntry{
+		Name:  g.Player.Spec.Name,
+		Score: g.Score(),
+		Floor: g.Progress.MaxFloor,
+		Turns: g.Progress.Turns,
+		Won:   ending.Won,
+		Cause: ending.Cause,
+		Date:  date,
+	}
+}
+
+// How the game ended and how far the player got, e.g. "Killed by ORC on 3F".
+func (e ScoreEntry) Summary() string {
+	ending := &Ending{Won: e.Won, Cause: e.Cause}
+	return fmt.Sprintf("%s on %dF", ending.Describe(), e.Floor)
+}
+
+// A single line for a score table, e.g.
+// "    450 DEBO             Killed by ORC on 3F            2017-01-02".
+func (e ScoreEntry) Describe() string {
+	summary := e.Summary()
+	if len(summary) > 30 {
+		summary = summary[:30]
+	}
+	return fmt.Sprintf("%7d %-16s %-30s %s", e.Score, e.Name, summary, e.Date.Format("2006-01-02"))
+}
+
+// The player's score so far: their total XP, plus bonuses for each floor
+// reached, each monster killed, and winning.
+func (g *Game) Score() int {
+	p := g.Player
+	score := p.Learner.TotalXP() + ScorePerFloor*g.Progress.MaxFloor
+	for _, n := range p.Learner.Kills() {
+		score += ScorePerKill * n
+	}
+	if g.Ending != nil && g.Ending.Won {
+		score += ScoreForWin
+	}
+	return score
+}
+
+// Every game that has been played, in the order they were played.
+type Scores struct {
+	Runs []ScoreEntry
+}
+
+// Loads the scores saved at 'path'. If nothing has been saved there yet, the
+// scores will be empty.
+func LoadScores(path string) (*Scores, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Scores{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scores := &Scores{}
+	if err := json.Unmarshal(data, scores); err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// Saves the scores to 'path'. They are written to a temporary file which is
+// then renamed over 'path', so a crash midway can't lose the old scores.
+func (s *Scores) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, ".scores")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Every run, best first. Runs with the same score are listed in the order they
+// were played.
+func (s *Scores) Ranked() []ScoreEntry {
+	ranked := make([]ScoreEntry, len(s.Runs))
+	copy(ranked, s.Runs)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Score > ranked[j].Score
+	})
+	return ranked
+}
+
+// Records a run, and returns where it placed in Ranked().
+func (s *Scores) add(e ScoreEntry) int {
+	placing := 0
+	for _, run := range s.Runs {
+		if run.Score >= e.Score {
+			placing++
+		}
+	}
+	s.Runs = append(s.Runs, e)
+	return placing
+}
+
+// Adds this game to the scores saved at 'path'. Once this succeeds, Scores and
+// Placing are set so that clients can show how this game did.
+func (g *Game) RecordScore(path string) error {
+	scores, err := LoadScores(path)
+	if err != nil {
+		return err
+	}
+	placing := scores.add(newScoreEntry(g, time.Now()))
+	if err := scores.Save(path); err != nil {
+		return err
+	}
+	g.Scores, g.Placing = scores, placing
+	return nil
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	g := newTestGame()
+	learner := g.Player.Learner.(*ActorLearner)
+	learner.totalxp = 50
+	learner.killed[SpecOrc] = 3
+	learner.killed[SpecAnt] = 1
+	g.Progress.MaxFloor = 2
+
+	want := 50 + 2*ScorePerFloor + 4*ScorePerKill
+	if score := g.Score(); score != want {
+		t.Errorf(`Score() was %d; want %d`, score, want)
+	}
+
+	g.Ending = &Ending{Won: true}
+	if score := g.Score(); score != want+ScoreForWin {
+		t.Errorf(`Score() after winning was %d; want %d`, score, want+ScoreForWin)
+	}
+}
+
+func TestScoreEntryDescribe(t *testing.T) {
+	e := ScoreEntry{
+		Name:  "DEBO",
+		Score: 450,
+		Floor: 3,
+		Cause: "ORC",
+		Date:  time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+	want := "    450 DEBO             Killed by ORC on 3F            2017-01-02"
+	if got := e.Describe(); got != want {
+		t.Errorf(`Describe() was "%s"; want "%s"`, got, want)
+	}
+}
+
+func TestScoresAddPlacing(t *testing.T) {
+	s := &Scores{}
+	tests := []struct {
+		score int
+		want  int
+	}{
+		{100, 0},
+		{300, 0},
+		{200, 1},
+		// Ties go to whoever got there first.
+		{200, 2},
+		{50, 4},
+	}
+
+	for i, test := range tests {
+		placing := s.add(ScoreEntry{Name: string('A' + rune(i)), Score: test.score})
+		if placing != test.want {
+			t.Errorf(`Adding score %d placed %d; want %d`, test.score, placing, test.want)
+		}
+		if ranked := s.Ranked(); ranked[placing].Name != string('A'+rune(i)) {
+			t.Errorf(`Ranked()[%d] was %v; want run %d`, placing, ranked[placing], i)
+		}
+	}
+}
+
+func TestLoadScoresMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srlscores")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	scores, err := LoadScores(filepath.Join(dir, "scores.json"))
+	if err != nil {
+		t.Fatalf(`LoadScores on missing file returned error %v`, err)
+	}
+	if n := len(scores.Runs); n != 0 {
+		t.Errorf(`LoadScores on missing file gave %d runs; want 0`, n)
+	}
+}
+
+func TestRecordScore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srlscores")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "srl", "scores.json")
+
+	for i := 0; i < 2; i++ {
+		g := newTestGame()
+		g.Player.Learner.(*ActorLearner).totalxp = 100 * i
+		g.blame(g.Player, "ORC")
+		g.Kill(g.Player)
+
+		if err := g.RecordScore(path); err != nil {
+			t.Fatalf(`RecordScore returned error %v`, err)
+		}
+		if g.Scores == nil || len(g.Scores.Runs) != i+1 {
+			t.Fatalf(`RecordScore gave scores %v; want %d runs`, g.Scores, i+1)
+		}
+		if g.Placing != 0 {
+			t.Errorf(`Game %d placed %d; want 0`, i, g.Placing)
+		}
+	}
+
+	scores, err := LoadScores(path)
+	if err != nil {
+		t.Fatalf(`LoadScores returned error %v`, err)
+	}
+	if n := len(scores.Runs); n != 2 {
+		t.Fatalf(`Loaded %d runs; want 2`, n)
+	}
+	if cause := scores.Runs[0].Cause; cause != "ORC" {
+		t.Errorf(`Loaded cause "%s"; want "ORC"`, cause)
+	}
+
+	files, _ := ioutil.ReadDir(filepath.Dir(path))
+	if len(files) != 1 {
+		t.Errorf(`Score dir has %d files; want only the score file`, len(files))
+	}
+}
+
+func TestRecordScoreKeepsCorruptFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "srlscores")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "scores.json")
+	ioutil.WriteFile(path, []byte("not json"), 0644)
+
+	g := newTestGame()
+	g.Kill(g.Player)
+	if err := g.RecordScore(path); err == nil {
+		t.Error(`RecordScore on corrupt file returned no error`)
+	}
+	if data, _ := ioutil.ReadFile(path); string(data) != "not json" {
+		t.Errorf(`RecordScore overwrote corrupt file with %s`, data)
+	}
+}
@@ -0,0 +1,28 @@
+package game
+
+// Songs that the player can learn by raising their Song skill.
+var PlayerSongs = []*Melody{
+	{
+		Name:   "STAYING",
+		Level:  1,
+		Cost:   2,
+		Effect: EffectSongStaying,
+		Boosts: Chi,
+		Boost:  func(power int) int { return 2 + power/3 },
+	},
+	{
+		Name:   "SHARPNESS",
+		Level:  4,
+		Cost:   3,
+		Effect: EffectSongSharpness,
+		Boosts: Melee,
+		Boost:  func(power int) int { return 1 + power/4 },
+	},
+	{
+		Name:   "SILENCE",
+		Level:  8,
+		Cost:   4,
+		Effect: EffectSongSilence,
+		Each:   silencenearby,
+	},
+}
@@ -0,0 +1,80 @@
+package game
+
+// Spells that the player can learn by raising their Magic skill.
+var PlayerSpells = []*Spell{
+	{
+		Name:     "MAGIC BOLT",
+		Level:    1,
+		Cost:     2,
+		Targeted: true,
+		Effect:   spellbolt(EffectNone, 2, 4),
+	},
+	{
+		Name:   "HEAL",
+		Level:  3,
+		Cost:   4,
+		Effect: spellheal(10),
+	},
+	{
+		Name:   "BLINK",
+		Level:  5,
+		Cost:   3,
+		Effect: spellblink(5),
+	},
+	{
+		Name:     "SLOW",
+		Level:    6,
+		Cost:     4,
+		Targeted: true,
+		Effect:   spellstatus(EffectSlow, 5, 4),
+	},
+	{
+		Name:     "CONFUSE",
+		Level:    8,
+		Cost:     5,
+		Targeted: true,
+		Effect:   spellstatus(EffectConfuse, 5, 4),
+	},
+	{
+		Name:     "FROST BOLT",
+		Level:    10,
+		Cost:     6,
+		Targeted: true,
+		Effect:   spellbolt(BrandIce, 4, 6),
+	},
+	{
+		Name:     "FIREBALL",
+		Level:    12,
+		Cost:     9,
+		Targeted: true,
+		Effect:   spellball(BrandFire, 3, 6),
+	},
+	{
+		Name:     "HOLD",
+		Level:    15,
+		Cost:     9,
+		Targeted: true,
+		Effect:   spellstatus(EffectPara, 4, 4),
+	},
+}
+
+// Spells cast by monsters. Monsters don't need any Magic skill to cast these.
+var (
+	SpellMonsterBolt = &Spell{
+		Name:     "MAGIC BOLT",
+		Cost:     2,
+		Targeted: true,
+		Effect:   spellbolt(EffectNone, 2, 4),
+	}
+	SpellScare = &Spell{
+		Name:     "SCARE",
+		Cost:     3,
+		Targeted: true,
+		Effect:   spellstatus(EffectFear, 5, 4),
+	}
+	SpellMonsterHeal = &Spell{
+		Name:   "HEAL",
+		Cost:   4,
+		Effect: spellheal(10),
+	}
+)
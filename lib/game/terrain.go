@@ -0,0 +1,215 @@
+package game
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+const (
+	// One in this many times, swimming in deep water sweeps something out of
+	// the swimmer's pack.
+	deepWaterDropChance = 2
+	// How many patches of terrain a level can get, at most.
+	terrainPatchesMax = 4
+	// How many tiles a patch of terrain spreads over, at most.
+	terrainPatchSize = 12
+)
+
+// What a feature does to whoever moves onto it.
+type Hazard int
+
+const (
+	HazardNone Hazard = iota
+	// Puts out fire brands.
+	HazardShallowWater
+	// Puts out fire brands, and sweeps things out of packs. Actors that sink
+	// can't go in it at all.
+	HazardDeepWater
+	// Drops whoever falls in to the floor below.
+	HazardChasm
+	// Burns anyone who doesn't resist fire.
+	HazardLava
+)
+
+// Is this somewhere that nobody should step without meaning to? Paths never go
+// onto these.
+func (f *Feature) Dangerous() bool {
+	return f.Hazard == HazardChasm || f.Hazard == HazardLava
+}
+
+// Does standing on this put out fire brands?
+func (f *Feature) Douses() bool {
+	return f.Hazard == HazardShallowWater || f.Hazard == HazardDeepWater
+}
+
+// The terrain that levels can have, and the lowest floor each can be on. Deep
+// water isn't here, since it only turns up in the middle of big enough pools.
+var Terrains = []struct {
+	Feature  *Feature
+	MinFloor int
+}{
+	{FeatShallowWater, 1},
+	{FeatRubble, 1},
+	{FeatChasm, 2},
+	{FeatLava, 3},
+}
+
+// Does whatever the hazard on 'tile' does to 'victim', who has just moved onto
+// it.
+func endure(victim *Obj, tile *Tile) {
+	g := victim.Game
+	seen := victim.IsPlayer() || tile.Visible
+	say := func(format string) {
+		if seen {
+			g.Events.Message(fmt.Sprintf(format, actorname(victim)))
+		}
+	}
+
+	switch tile.Feature.Hazard {
+	case HazardDeepWater:
+		if victim.Packer == nil || !OneIn(deepWaterDropChance) {
+			return
+		}
+		inv := victim.Packer.Inventory()
+		if inv.Empty() {
+			return
+		}
+		item := inv.Take(RandInt(0, inv.Len()))
+		if seen {
+			g.Events.Message(fmt.Sprintf("%s's %s is swept away!", actorname(victim), item.Describe()))
+		}
+		sweepaway(victim.Level, item)
+	case HazardChasm:
+		say("%s falls into the CHASM!")
+		fall(victim)
+	case HazardLava:
+		effects := victim.Sheet.Defense().Effects
+		if effects.Resists(BrandFire) > 0 {
+			say("%s wades through the LAVA unharmed.")
+			return
+		}
+		say("%s is burned by the LAVA!")
+		g.blame(victim, "LAVA")
+		victim.Sheet.Hurt(effects.ResistDmg(BrandFire, DieRoll(4, 6)))
+	}
+}
+
+// Drops 'obj' to the floor below. Monsters that fall are never seen again.
+func fall(obj *Obj) {
+	if obj.IsPlayer() {
+		obj.Game.ChangeFloor(-1)
+	} else {
+		obj.Level.Remove(obj)
+	}
+}
+
+// Washes 'item' up somewhere on 'l' that it can be picked up from. If there's
+// nowhere like that, it's lost.
+func sweepaway(l *Level, item *Obj) {
+	for tries := 0; tries < 100; tries++ {
+		tile := l.RandomClearTile()
+		if tile != nil && tile.Feature.Hazard == HazardNone && l.Place(item, tile.Pos) {
+			return
+		}
+	}
+}
+
+// Spreads a few patches of terrain over the floor of 'rooms'. Water pools that
+// are big enough get deep water in the middle. Patches that would cut off any
+// part of the level from the player are taken back out.
+func placeterrain(l *Level, rooms []math.Rectangle) {
+	floor := l.game.Progress.Floor
+	kinds := make([]*Feature, 0, len(Terrains))
+	for _, kind := range Terrains {
+		if kind.MinFloor <= floor {
+			kinds = append(kinds, kind.Feature)
+		}
+	}
+	if len(kinds) == 0 || len(rooms) == 0 {
+		return
+	}
+
+	n := RandInt(0, terrainPatchesMax+1)
+	for i := 0; i < n; i++ {
+		room := rooms[RandInt(0, len(rooms))]
+		kind := kinds[RandInt(0, len(kinds))]
+		patch := growpatch(l, randpoint(room), RandInt(3, terrainPatchSize+1))
+		for _, tile := range patch {
+			tile.Feature = kind
+		}
+		if kind == FeatShallowWater {
+			deepen(l, patch)
+		}
+
+		if len(unreachable(l)) > 0 {
+			for _, tile := range patch {
+				tile.Feature = FeatFloor
+			}
+			continue
+		}
+		l.stats.Terrain += len(patch)
+	}
+}
+
+// Grows a patch of up to 'size' tiles out from 'start', over floor that has
+// nothing on it.
+func growpatch(l *Level, start math.Point, size int) []*Tile {
+	open := func(t *Tile) bool {
+		return t.Feature == FeatFloor && t.Actor == nil && t.Items.Empty()
+	}
+	if tile := l.At(start); !open(tile) {
+		return nil
+	}
+
+	patch, in := []*Tile{l.At(start)}, map[*Tile]bool{l.At(start): true}
+	for tries := 0; tries < size*4 && len(patch) < size; tries++ {
+		around := l.Around(patch[RandInt(0, len(patch))].Pos)
+		next := around[RandInt(0, len(around))]
+		if !in[next] && open(next) {
+			patch = append(patch, next)
+			in[next] = true
+		}
+	}
+	return patch
+}
+
+// Turns the water in 'patch' that's surrounded by water into deep water.
+func deepen(l *Level, patch []*Tile) {
+	deep := make([]*Tile, 0)
+	for _, tile := range patch {
+		surrounded := true
+		for _, n := range l.Around(tile.Pos) {
+			if !n.Feature.Douses() {
+				surrounded = false
+				break
+			}
+		}
+		if surrounded {
+			deep = append(deep, tile)
+		}
+	}
+	for _, tile := range deep {
+		tile.Feature = FeatDeepWater
+	}
+}
+
+// Can someone get to 't' without having to go through anything dangerous?
+// Secret doors count as a way through, since they can be found.
+func walkable(t *Tile) bool {
+	return t.Feature != FeatWall && !t.Feature.Dangerous()
+}
+
+// All of the tiles on 'l' that could be walked on, but can't be walked to from
+// where the player is.
+func unreachable(l *Level) []*Tile {
+	reach := l.FlowMap([]math.Point{l.game.Player.Pos()}, PathCost, walkable)
+	cut := make([]*Tile, 0)
+	for _, row := range l.Map {
+		for _, tile := range row {
+			if walkable(tile) && reach.At(tile.Pos) == FlowUnreachable {
+				cut = append(cut, tile)
+			}
+		}
+	}
+	return cut
+}
@@ -0,0 +1,237 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+// A monster that can walk around, and takes no damage from fire if 'fireproof'.
+func terrainTestMonster(fireproof bool) *Spec {
+	resists := map[Effect]int{}
+	if fireproof {
+		resists[ResistFire] = 1
+	}
+	return &Spec{
+		Family:  FamActor,
+		Genus:   GenMonster,
+		Species: SpecOrc,
+		Name:    "ORC",
+		Traits: &Traits{
+			Mover:  NewActorMover,
+			Packer: NewActorPacker,
+			Sheet: NewMonsterSheet(&MonsterSheet{
+				maxhp:   30,
+				speed:   2,
+				defense: Defense{Effects: NewEffects(resists)},
+			}),
+		},
+	}
+}
+
+// How long 'obj' has to wait for its next turn.
+func scheduledDelay(obj *Obj) int {
+	for _, e := range *obj.Level.scheduler.pq {
+		if e.actor == obj {
+			return e.delay
+		}
+	}
+	return -1
+}
+
+func TestWadingTakesLonger(t *testing.T) {
+	tests := []struct {
+		feature *Feature
+		turns   int
+	}{
+		{FeatFloor, 0},
+		{FeatShallowWater, 1},
+		{FeatDeepWater, 1},
+		{FeatRubble, 2},
+	}
+	for _, test := range tests {
+		g := newRunTestGame(`
+#####
+#@  #
+#####`)
+		g.Level.At(math.Pt(2, 1)).Feature = test.feature
+		before := scheduledDelay(g.Player)
+
+		// Never get swept away in deep water.
+		FixRandomSource([]int{1})
+		g.Player.Mover.Move(math.Pt(1, 0))
+		RestoreRandom()
+
+		want := before + test.turns*GetDelay(g.Player.Sheet.Speed())
+		if after := scheduledDelay(g.Player); after != want {
+			t.Errorf(`Moving onto %v left delay at %d; want %d`, test.feature, after, want)
+		}
+	}
+}
+
+func TestRubbleIsClearedOnceCrossed(t *testing.T) {
+	g := newRunTestGame(`
+#####
+#@  #
+#####`)
+	rubble := g.Level.At(math.Pt(2, 1))
+	rubble.Feature = FeatRubble
+
+	g.Player.Mover.Move(math.Pt(1, 0))
+	if f := rubble.Feature; f != FeatFloor {
+		t.Errorf(`Rubble was %v after crossing it; want %v`, f, FeatFloor)
+	}
+
+	// Stepping back onto it now takes no longer than walking.
+	g.Player.Mover.Move(math.Pt(1, 0))
+	before := scheduledDelay(g.Player)
+	g.Player.Mover.Move(math.Pt(-1, 0))
+	if after := scheduledDelay(g.Player); after != before {
+		t.Errorf(`Moving onto cleared rubble left delay at %d; want %d`, after, before)
+	}
+}
+
+func TestSinkersStayOutOfDeepWater(t *testing.T) {
+	g := newRunTestGame(`
+######
+#@ W #
+######`)
+	water := math.Pt(3, 1)
+	sinker := terrainTestMonster(false)
+	sinker.Sinks = true
+	orc := g.NewObj(sinker)
+	g.Level.Place(orc, math.Pt(4, 1))
+
+	if _, err := orc.Mover.Move(math.Pt(-1, 0)); err != ErrMoveBlocked {
+		t.Errorf(`Sinker's move into deep water was %v; want %v`, err, ErrMoveBlocked)
+	}
+	if pathableby(orc)(g.Level.At(water)) {
+		t.Error(`Sinker can path through deep water`)
+	}
+	if !pathableby(g.Player)(g.Level.At(water)) {
+		t.Error(`Player can't path through deep water`)
+	}
+}
+
+func TestDeepWaterSweepsAwayItems(t *testing.T) {
+	g := newRunTestGame(`
+######
+#@W  #
+######`)
+	item := g.NewObj(lTestItem)
+	g.Player.Packer.Inventory().Add(item)
+
+	// Get swept away, lose the only item, and wash it up at (4, 1).
+	FixRandomSource([]int{0, 0, 4, 1})
+	defer RestoreRandom()
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	if !g.Player.Packer.Inventory().Empty() {
+		t.Error(`Player still has their item after swimming`)
+	}
+	if top := g.Level.At(math.Pt(4, 1)).Items.Top(); top != item {
+		t.Errorf(`Swept away item washed up as %v; want %v`, top, item)
+	}
+}
+
+func TestLavaBurns(t *testing.T) {
+	tests := []struct {
+		fireproof bool
+		hurt      int
+	}{
+		{false, 10},
+		{true, 0},
+	}
+	for _, test := range tests {
+		g := newRunTestGame(`
+####
+#@ #
+#  #
+####`)
+		g.Level.At(math.Pt(2, 2)).Feature = FeatLava
+		mon := g.NewObj(terrainTestMonster(test.fireproof))
+		g.Level.Place(mon, math.Pt(2, 1))
+		hp := mon.Sheet.HP()
+
+		FixRandomDie([]int{1, 2, 3, 4})
+		mon.Mover.Move(math.Pt(0, 1))
+		RestoreRandom()
+
+		if got, want := mon.Sheet.HP(), hp-test.hurt; got != want {
+			t.Errorf(`Fireproof %v: HP was %d after lava; want %d`, test.fireproof, got, want)
+		}
+	}
+}
+
+func TestChasmDropsPlayer(t *testing.T) {
+	g := newRunTestGame(`
+#####
+#@: #
+#####`)
+	g.Progress.Floor, g.Progress.MaxFloor = 3, 3
+	old := g.Level
+	mon := g.NewObj(terrainTestMonster(false))
+	old.Place(mon, math.Pt(3, 1))
+
+	mon.Mover.Move(math.Pt(-1, 0))
+	if mon.Level != nil {
+		t.Error(`Monster that fell into a chasm is still on the level`)
+	}
+
+	SeedRandom(1)
+	defer RestoreRandom()
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	if g.Progress.Floor != 2 {
+		t.Errorf(`Floor was %d after falling into a chasm; want 2`, g.Progress.Floor)
+	}
+	if g.Level == old {
+		t.Error(`Player wasn't put on a new level after falling into a chasm`)
+	}
+}
+
+func TestPathsAvoidDangerousTerrain(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#     #
+# ### #
+#     #
+#######`)
+	l := g.Level
+	start, end := math.Pt(1, 1), math.Pt(5, 1)
+
+	for _, f := range []*Feature{FeatLava, FeatChasm} {
+		l.At(math.Pt(3, 1)).Feature = f
+		path, _ := l.FindPath(start, end, PathCost)
+		if len(path) != 6 {
+			t.Errorf(`Path past %v was %v; want it to go around`, f, path)
+		}
+	}
+}
+
+func TestPlaceTerrain(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		g := newTestGame()
+		g.Progress.Floor = 4
+		l := NewLevel(30, 30, g, SquareLevel)
+		g.Level = l
+		placeterrain(l, []math.Rectangle{math.Rect(math.Pt(1, 1), math.Pt(29, 29))})
+
+		n := 0
+		for _, row := range l.Map {
+			for _, tile := range row {
+				if f := tile.Feature; f != FeatFloor && f != FeatWall {
+					n++
+				}
+			}
+		}
+		if n != l.Stats().Terrain {
+			t.Errorf(`Level %d has %d tiles of terrain, but recorded %d`, i, n, l.Stats().Terrain)
+		}
+		if cut := unreachable(l); len(cut) > 0 {
+			t.Errorf(`Level %d has terrain cutting off %v`, i, cut[0].Pos)
+		}
+		if f := g.Player.Tile.Feature; f != FeatFloor {
+			t.Errorf(`Level %d put %v under the player`, i, f)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"fmt"
+	"github.com/MichaelDiBernardo/srl/lib/math"
+)
+
+const (
+	// What it costs to path over a trap that's been found, compared to 1 for
+	// an ordinary step. It's high enough that anyone will go a long way around
+	// rather than step on it.
+	trapPathCost = 50
+	// How far away monsters can hear a SIREN from.
+	sirenRadius = 20
+	// If a disarm fails by at least this much, the trap goes off.
+	disarmBlunder = 5
+	// How many traps a level gets: at least this many, plus up to two more for
+	// every floor climbed.
+	trapsMin = 2
+	// One in this many traps can be seen from the start.
+	trapVisibleChance = 4
+)
+
+// Something nasty that happens to whoever steps on it. Each trap has a feature
+// for when it's hidden and one for when it's been found; see Traps.
+type Trap struct {
+	Name string
+	// The lowest floor this trap can appear on.
+	MinFloor int
+	// How hard this is to find, avoid and disarm. Traps get one harder for
+	// every floor.
+	Difficulty int
+	// Magic traps are resisted with a saving throw. Everything else has to be
+	// dodged.
+	Magic bool
+}
+
+// How hard this trap is on the floor that 'g' is on.
+func (t *Trap) difficulty(g *Game) int {
+	return t.Difficulty + g.Progress.Floor
+}
+
+// Does 'victim' get away without the trap doing anything to them?
+func (t *Trap) avoided(victim *Obj) bool {
+	def := victim.Sheet.Defense()
+	if t.Magic {
+		return !savingthrow(victim, def.Effects, EffectNone)
+	}
+	won, _ := skillcheck(def.Evasion, t.difficulty(victim.Game), 0, victim, nil)
+	return won
+}
+
+// Sets off the trap on 'tile' on 'victim', who has usually just stepped on it.
+// If the player is the victim or can see it happen, the trap is found.
+func springtrap(victim *Obj, tile *Tile) {
+	trap, g := tile.Feature.Trap, victim.Game
+	seen := victim.IsPlayer() || tile.Visible
+
+	if victim.IsPlayer() {
+		g.Disturb()
+	}
+	if seen {
+		reveal(tile)
+		g.Events.Message(fmt.Sprintf("%s sets off a %s!", actorname(victim), trap.Name))
+	}
+	if trap.avoided(victim) {
+		if seen {
+			g.Events.Message(fmt.Sprintf("%s avoids it.", actorname(victim)))
+		}
+		return
+	}
+
+	say := func(format string) {
+		if seen {
+			g.Events.Message(fmt.Sprintf(format, actorname(victim)))
+		}
+	}
+	switch trap {
+	case TrapPit:
+		say("%s falls into the PIT.")
+		g.blame(victim, "a PIT")
+		victim.Sheet.Hurt(DieRoll(2, 6))
+	case TrapTrapDoor:
+		say("%s falls through the TRAP DOOR!")
+		fall(victim)
+	case TrapSiren:
+		// Everyone hears this, whether they saw it or not.
+		g.Events.Message("The SIREN wails!")
+		victim.Level.scheduler.EachActor(func(o *Obj) {
+			if o.AI != nil && math.ChebyDist(o.Pos(), tile.Pos) <= sirenRadius {
+				o.AI.Alert()
+			}
+		})
+	case TrapTeleport:
+		say("%s is teleported away!")
+		teleport(victim)
+	case TrapDart:
+		say("%s is hit by a poisoned dart.")
+		if victim.Sheet.Defense().Effects.Resists(EffectPoison) <= 0 {
+			victim.Ticker.AddEffect(EffectPoison, DieRoll(2, 4))
+		}
+		g.blame(victim, "a DART TRAP")
+		victim.Sheet.Hurt(DieRoll(1, 4))
+	case TrapWeb:
+		say("%s is caught in the WEB, and tears it apart getting out.")
+		victim.Ticker.AddEffect(EffectSlow, DieRoll(2, 4))
+		tile.Feature = FeatFloor
+	}
+}
+
+// Turns whatever is hidden on 'tile' into what it really is.
+func reveal(tile *Tile) {
+	if f := tile.Feature; f.Hidden() {
+		tile.Feature = f.Found
+	}
+}
+
+// Moves 'obj' to somewhere random on its level that nobody is standing on.
+func teleport(obj *Obj) {
+	l := obj.Level
+	for tries := 0; tries < 100; tries++ {
+		if tile := l.RandomClearTile(); tile != nil && l.Place(obj, tile.Pos) {
+			return
+		}
+	}
+}
+
+// Scatters traps around the floor of 'rooms'. Most of them are hidden.
+func placetraps(l *Level, rooms []math.Rectangle) {
+	floor := l.game.Progress.Floor
+	kinds := make([]int, 0, len(Traps))
+	for i, kind := range Traps {
+		if kind.Trap.MinFloor <= floor {
+			kinds = append(kinds, i)
+		}
+	}
+	if len(kinds) == 0 || len(rooms) == 0 {
+		return
+	}
+
+	n, placed := RandInt(trapsMin, trapsMin+2*floor), 0
+	for i := 0; i < n; i++ {
+		room := rooms[RandInt(0, len(rooms))]
+		for tries := 0; tries < 10; tries++ {
+			tile := l.At(randpoint(room))
+			if tile.Feature != FeatFloor || tile.Actor != nil || !tile.Items.Empty() {
+				continue
+			}
+			kind := Traps[kinds[RandInt(0, len(kinds))]]
+			if OneIn(trapVisibleChance) {
+				tile.Feature = kind.Found
+			} else {
+				tile.Feature = kind.Hidden
+			}
+			placed++
+			break
+		}
+	}
+	l.stats.Traps = placed
+}
+
+// Tries to disarm a trap that's been found next to the actor, in direction
+// 'dir'. Failing badly sets it off. Returns true if a turn should pass.
+func (p *ActorMover) Disarm(dir math.Point) bool {
+	obj, g := p.obj, p.obj.Game
+	pos := obj.Pos().Add(dir)
+
+	var tile *Tile
+	if math.ChebyDist(math.Origin, dir) == 1 && pos.In(obj.Level) {
+		tile = obj.Level.At(pos)
+	}
+	if tile == nil || tile.Feature.Trap == nil || tile.Feature.Hidden() {
+		g.Events.Message(fmt.Sprintf("%s doesn't know of a trap there.", actorname(obj)))
+		return false
+	}
+
+	trap := tile.Feature.Trap
+	won, by := skillcheck(obj.Sheet.Skill(Sense), trap.difficulty(g), 0, obj, nil)
+	switch {
+	case won:
+		tile.Feature = FeatFloor
+		g.Events.Message(fmt.Sprintf("%s disarms the %s.", actorname(obj), trap.Name))
+	case -by >= disarmBlunder:
+		springtrap(obj, tile)
+	default:
+		g.Events.Message(fmt.Sprintf("%s fails to disarm the %s.", actorname(obj), trap.Name))
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package game
+
+var (
+	TrapPit = &Trap{
+		Name:       "PIT",
+		MinFloor:   1,
+		Difficulty: 3,
+	}
+	// Drops whoever falls through it to the floor below.
+	TrapTrapDoor = &Trap{
+		Name:       "TRAP DOOR",
+		MinFloor:   2,
+		Difficulty: 4,
+	}
+	// Brings every monster nearby running.
+	TrapSiren = &Trap{
+		Name:       "SIREN",
+		MinFloor:   1,
+		Difficulty: 4,
+		Magic:      true,
+	}
+	TrapTeleport = &Trap{
+		Name:       "TELEPORT RUNE",
+		MinFloor:   2,
+		Difficulty: 5,
+		Magic:      true,
+	}
+	TrapDart = &Trap{
+		Name:       "DART TRAP",
+		MinFloor:   1,
+		Difficulty: 4,
+	}
+	// Slows down whoever gets caught in it, and is torn apart when they break
+	// free.
+	TrapWeb = &Trap{
+		Name:       "WEB",
+		MinFloor:   1,
+		Difficulty: 2,
+	}
+)
+
+// All of the traps that levels can have, and the features for them before and
+// after they're found.
+var Traps = []struct {
+	Trap          *Trap
+	Found, Hidden *Feature
+}{
+	{TrapPit, FeatPit, FeatHiddenPit},
+	{TrapTrapDoor, FeatTrapDoor, FeatHiddenTrapDoor},
+	{TrapSiren, FeatSiren, FeatHiddenSiren},
+	{TrapTeleport, FeatTeleportRune, FeatHiddenTeleportRune},
+	{TrapDart, FeatDartTrap, FeatHiddenDartTrap},
+	{TrapWeb, FeatWeb, FeatHiddenWeb},
+}
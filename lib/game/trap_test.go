@@ -0,0 +1,223 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"testing"
+)
+
+func trapTestGame(trap *Feature) *Game {
+	g := newRunTestGame(`
+#######
+#@    #
+#######`)
+	g.Level.At(math.Pt(2, 1)).Feature = trap
+	return g
+}
+
+func TestSteppingOnHiddenPit(t *testing.T) {
+	g := trapTestGame(FeatHiddenPit)
+	hp := g.Player.Sheet.HP()
+
+	// Fail the dodge, then fall for 3 + 4.
+	FixRandomDie([]int{1, 10, 3, 4})
+	defer RestoreRandom()
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	if f := g.Level.At(math.Pt(2, 1)).Feature; f != FeatPit {
+		t.Errorf(`Pit was %v after player fell in; want %v`, f, FeatPit)
+	}
+	if got, want := g.Player.Sheet.HP(), hp-7; got != want {
+		t.Errorf(`Player HP was %d after falling in a pit; want %d`, got, want)
+	}
+}
+
+func TestDodgingPit(t *testing.T) {
+	g := trapTestGame(FeatHiddenPit)
+	hp := g.Player.Sheet.HP()
+
+	FixRandomDie([]int{10, 1})
+	defer RestoreRandom()
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	if f := g.Level.At(math.Pt(2, 1)).Feature; f != FeatPit {
+		t.Errorf(`Pit was %v after player dodged it; want %v`, f, FeatPit)
+	}
+	if got := g.Player.Sheet.HP(); got != hp {
+		t.Errorf(`Player HP was %d after dodging a pit; want %d`, got, hp)
+	}
+}
+
+func TestWebSlowsAndTears(t *testing.T) {
+	g := trapTestGame(FeatWeb)
+
+	FixRandomDie([]int{1, 10, 2, 2})
+	defer RestoreRandom()
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	if !g.Player.Sheet.Slow() {
+		t.Error(`Player wasn't slowed by a web`)
+	}
+	if f := g.Level.At(math.Pt(2, 1)).Feature; f != FeatFloor {
+		t.Errorf(`Web was %v after player broke free; want %v`, f, FeatFloor)
+	}
+}
+
+func TestTrapDoorDropsPlayer(t *testing.T) {
+	g := trapTestGame(FeatTrapDoor)
+	g.Progress.Floor, g.Progress.MaxFloor = 3, 3
+	old := g.Level
+
+	// Rig the dodge to fail, and leave the new level up to chance.
+	SeedRandom(1)
+	defer RestoreRandom()
+	seeded, rolls := intsource, []int{0, 9}
+	intsource = func(n int) int {
+		if len(rolls) == 0 {
+			return seeded(n)
+		}
+		roll := rolls[0]
+		rolls = rolls[1:]
+		return roll
+	}
+	g.Player.Mover.Move(math.Pt(1, 0))
+
+	if g.Progress.Floor != 2 {
+		t.Errorf(`Floor was %d after falling through a trap door; want 2`, g.Progress.Floor)
+	}
+	if g.Level == old || g.Player.Level != g.Level {
+		t.Error(`Player wasn't put on a new level after falling through a trap door`)
+	}
+}
+
+func TestSearchFindsNearbyTraps(t *testing.T) {
+	g := newRunTestGame(`
+########
+#@     #
+########`)
+	l := g.Level
+	for _, row := range l.Map {
+		for _, tile := range row {
+			tile.Visible = true
+		}
+	}
+	l.At(math.Pt(3, 1)).Feature = FeatHiddenDartTrap
+	l.At(math.Pt(6, 1)).Feature = FeatHiddenDartTrap
+
+	FixRandomDie([]int{10, 1})
+	defer RestoreRandom()
+	found := g.Player.Senser.Search(SearchRadius, SearchBonus)
+
+	if !found {
+		t.Error(`Search() found nothing`)
+	}
+	if f := l.At(math.Pt(3, 1)).Feature; f != FeatDartTrap {
+		t.Errorf(`Nearby trap was %v after search; want %v`, f, FeatDartTrap)
+	}
+	if f := l.At(math.Pt(6, 1)).Feature; f != FeatHiddenDartTrap {
+		t.Errorf(`Faraway trap was %v after search; want it still hidden`, f)
+	}
+}
+
+func TestDisarm(t *testing.T) {
+	tests := []struct {
+		feature *Feature
+		rolls   []int
+		want    *Feature
+		turn    bool
+	}{
+		// Only traps that have been found can be disarmed.
+		{FeatHiddenPit, nil, FeatHiddenPit, false},
+		{FeatPit, []int{10, 1}, FeatFloor, true},
+		{FeatPit, []int{5, 4}, FeatPit, true},
+		// Blundering sets it off, and the dodge fails too.
+		{FeatPit, []int{1, 10, 1, 10, 1, 1}, FeatPit, true},
+	}
+	for _, test := range tests {
+		g := trapTestGame(test.feature)
+		hp := g.Player.Sheet.HP()
+
+		FixRandomDie(test.rolls)
+		turn := g.Player.Mover.Disarm(math.Pt(1, 0))
+		RestoreRandom()
+
+		if f := g.Level.At(math.Pt(2, 1)).Feature; f != test.want {
+			t.Errorf(`Disarming %v with rolls %v left %v; want %v`, test.feature, test.rolls, f, test.want)
+		}
+		if turn != test.turn {
+			t.Errorf(`Disarming %v with rolls %v took a turn: %v; want %v`, test.feature, test.rolls, turn, test.turn)
+		}
+		if len(test.rolls) > 4 && g.Player.Sheet.HP() != hp-2 {
+			t.Errorf(`Blundering a disarm didn't set off the pit`)
+		}
+	}
+}
+
+func TestPathsAvoidFoundTraps(t *testing.T) {
+	g := newRunTestGame(`
+#######
+#     #
+# ### #
+#     #
+#######`)
+	l := g.Level
+	start, end := math.Pt(1, 1), math.Pt(5, 1)
+
+	l.At(math.Pt(3, 1)).Feature = FeatHiddenPit
+	if path, _ := l.FindPath(start, end, PathCost); len(path) != 4 {
+		t.Errorf(`Path past a hidden trap was %v; want straight across`, path)
+	}
+
+	l.At(math.Pt(3, 1)).Feature = FeatPit
+	path, _ := l.FindPath(start, end, PathCost)
+	for _, pt := range path {
+		if pt == math.Pt(3, 1) {
+			t.Errorf(`Path %v went over a trap that's been found`, path)
+		}
+	}
+}
+
+func TestRunIgnoresHiddenTraps(t *testing.T) {
+	g := newRunTestGame(`
+#########
+#@      #
+#########`)
+	g.Level.At(math.Pt(4, 1)).Feature = FeatHiddenSiren
+	g.Level.At(math.Pt(5, 1)).Feature = FeatSiren
+	// Deep enough down that the siren can't be noticed on the way past.
+	g.Progress.Floor = 20
+
+	// The run carries on over the hidden siren, since it looks like floor.
+	// Setting it off stops the run there.
+	g.Handle(RunCommand{Dir: math.Pt(1, 0)})
+
+	if pos := g.Player.Pos(); pos != math.Pt(4, 1) {
+		t.Errorf(`Run stopped at %v; want %v`, pos, math.Pt(4, 1))
+	}
+}
+
+func TestPlaceTraps(t *testing.T) {
+	g := newTestGame()
+	g.Progress.Floor = 1
+	l := NewLevel(40, 40, g, SquareLevel)
+	placetraps(l, []math.Rectangle{math.Rect(math.Pt(1, 1), math.Pt(39, 39))})
+
+	n := 0
+	for _, row := range l.Map {
+		for _, tile := range row {
+			trap := tile.Feature.Trap
+			if trap == nil {
+				continue
+			}
+			n++
+			if trap.MinFloor > 1 {
+				t.Errorf(`Placed a %s on floor 1`, trap.Name)
+			}
+			if tile.Actor != nil {
+				t.Errorf(`Placed a %s under %v`, trap.Name, tile.Actor.Spec.Name)
+			}
+		}
+	}
+	if n < trapsMin || n != l.Stats().Traps {
+		t.Errorf(`Placed %d traps, and recorded %d; want at least %d`, n, l.Stats().Traps, trapsMin)
+	}
+}
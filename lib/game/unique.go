@@ -0,0 +1,47 @@
+package game
+
+// Keeps track of which unique monsters and artifacts have been created and
+// destroyed over the course of a game, so that we never make two of them.
+type Uniques struct {
+	generated map[Species]bool
+	killed    map[Species]bool
+}
+
+func newUniques() *Uniques {
+	return &Uniques{
+		generated: map[Species]bool{},
+		killed:    map[Species]bool{},
+	}
+}
+
+// Can a new instance of 'spec' be created in this game? This is always true
+// for things that aren't unique.
+func (u *Uniques) Available(spec *Spec) bool {
+	return !spec.Gen.Unique || !u.generated[spec.Species]
+}
+
+// Has the unique with the given species been created in this game?
+func (u *Uniques) Generated(s Species) bool {
+	return u.generated[s]
+}
+
+// Has the unique monster with the given species been killed in this game?
+func (u *Uniques) Killed(s Species) bool {
+	return u.killed[s]
+}
+
+// Record that an instance of 'spec' has been put into the world. Does nothing
+// if spec isn't unique.
+func (u *Uniques) markGenerated(spec *Spec) {
+	if spec.Gen.Unique {
+		u.generated[spec.Species] = true
+	}
+}
+
+// Record that an instance of 'spec' has been killed. Does nothing if spec
+// isn't unique.
+func (u *Uniques) markKilled(spec *Spec) {
+	if spec.Gen.Unique {
+		u.killed[spec.Species] = true
+	}
+}
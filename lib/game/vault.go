@@ -0,0 +1,234 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"log"
+	"strings"
+)
+
+const (
+	// The most vaults that will be carved into one level.
+	maxVaults = 2
+	// How much deeper than the current floor the deep monsters and treasure in
+	// vaults come from.
+	vaultDepthBonus = 2
+	// How far from the floor they're made for things in vaults can be; this is
+	// the same as for the rest of the level.
+	vaultWiggle = 2
+)
+
+// A hand-drawn room that can be carved into a level. Vaults are drawn like the
+// pictures that StringLevel takes, with a few extra symbols for what goes in
+// them:
+//
+//	#  wall
+//	.  floor (so is ' ')
+//	+  closed door
+//	S  secret door
+//	~  shallow water
+//	W  deep water
+//	%  rubble
+//	:  chasm
+//	L  lava
+//	m  a monster from this floor
+//	M  a monster from deeper down
+//	i  an item from this floor
+//	*  treasure: an item from deeper down
+//
+// A vault should be surrounded by walls, with at least one closed door in
+// them. The level is joined to the vault through one of those doors, and the
+// rest are walled up.
+type Vault struct {
+	Name string
+	// The floors this vault can appear on.
+	MinFloor, MaxFloor int
+	// How rare this is. A level that could have this vault has a 1 in Rarity
+	// chance of getting it.
+	Rarity int
+	Pic    string
+}
+
+// The rows of the vault's picture.
+func (v *Vault) rows() []string {
+	return strings.Split(strings.Trim(v.Pic, "\n"), "\n")
+}
+
+// How much room the vault takes up.
+func (v *Vault) size() math.Point {
+	rows := v.rows()
+	return math.Pt(len(rows[0]), len(rows))
+}
+
+// The doors in the vault's outside wall, relative to its top-left corner.
+func (v *Vault) entrances() []math.Point {
+	size, doors := v.size(), make([]math.Point, 0)
+	for y, row := range v.rows() {
+		for x, ch := range row {
+			edge := x == 0 || y == 0 || x == size.X-1 || y == size.Y-1
+			if edge && ch == '+' {
+				doors = append(doors, math.Pt(x, y))
+			}
+		}
+	}
+	return doors
+}
+
+// Can this vault appear on 'floor'?
+func (v *Vault) Findable(floor int) bool {
+	return v.MinFloor <= floor && floor <= v.MaxFloor
+}
+
+// Gives each vault that could go on this level a chance to be carved into the
+// parts of it that 'rooms' and the corridors in 'paths' haven't used. Each
+// vault gets a corridor from one of its doors to the nearest room joint.
+// Returns the areas of the vaults that were placed.
+func placevaults(l *Level, rooms []math.Rectangle, joints, paths []math.Point) []math.Rectangle {
+	floor, placed := l.game.Progress.Floor, 0
+	vaults := make([]math.Rectangle, 0, maxVaults)
+
+	// Start somewhere random so that the vaults at the front of the list don't
+	// always win.
+	start := RandInt(0, len(Vaults))
+	for i := range Vaults {
+		v := Vaults[(start+i)%len(Vaults)]
+		if placed == maxVaults || !v.Findable(floor) || !OneIn(v.Rarity) {
+			continue
+		}
+		if area, path, ok := carvevault(l, v, rooms, vaults, joints, paths); ok {
+			vaults = append(vaults, area)
+			paths = append(paths, path...)
+			placed++
+			log.Printf("Carved vault %s at %v.", v.Name, area)
+		}
+	}
+	return vaults
+}
+
+// Tries to find somewhere for 'v' that stays clear of 'rooms', 'vaults' and
+// 'paths', and can be joined to one of 'joints' without the corridor going
+// through any vault. If we can, the vault is drawn and filled, and its area
+// and corridor are returned.
+func carvevault(l *Level, v *Vault, rooms, vaults []math.Rectangle, joints, paths []math.Point) (math.Rectangle, []math.Point, bool) {
+	size, doors := v.size(), v.entrances()
+	width, height := l.Bounds.Width(), l.Bounds.Height()
+	if len(doors) == 0 || len(joints) == 0 || size.X > width-5 || size.Y > height-5 {
+		return math.ZeroRect, nil, false
+	}
+
+	for tries := 0; tries < 20; tries++ {
+		// Leave room between the vault and the edge of the level for a
+		// corridor to come out of its doors.
+		min := math.Pt(RandInt(2, width-size.X-2), RandInt(2, height-size.Y-2))
+		area := math.Rect(min, min.Add(size))
+		if !fits(area, append(rooms, vaults...), paths) {
+			continue
+		}
+
+		door := min.Add(doors[RandInt(0, len(doors))])
+		out := door.Add(outward(area, door))
+		joint := joints[0]
+		for _, j := range joints {
+			if math.ChebyDist(out, j) < math.ChebyDist(out, joint) {
+				joint = j
+			}
+		}
+
+		path := dig(out, joint)
+		if crosses(path, append(vaults, area)) {
+			continue
+		}
+
+		drawvault(l, v, min, door)
+		drawpath(l, path, rooms)
+		return area, path, true
+	}
+	return math.ZeroRect, nil, false
+}
+
+// Which way is out of 'area' from 'door', which is in its outside wall?
+func outward(area math.Rectangle, door math.Point) math.Point {
+	switch {
+	case door.X == area.Min.X:
+		return math.Pt(-1, 0)
+	case door.X == area.Max.X-1:
+		return math.Pt(1, 0)
+	case door.Y == area.Min.Y:
+		return math.Pt(0, -1)
+	default:
+		return math.Pt(0, 1)
+	}
+}
+
+// Does any of 'path' go through any of 'areas'?
+func crosses(path []math.Point, areas []math.Rectangle) bool {
+	for _, pt := range path {
+		for _, area := range areas {
+			if area.HasPoint(pt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Draws 'v' with its top-left corner at 'min', and fills it with whatever it
+// calls for. 'entrance' is the door the level is joined to it through; any
+// other doors in its outside wall are walled up.
+func drawvault(l *Level, v *Vault, min, entrance math.Point) {
+	g, floor := l.game, l.game.Progress.Floor
+	deep := math.Min(floor+vaultDepthBonus, MaxFloor)
+
+	// Draw all of the features first so that things get placed on floor.
+	stuff := make([]math.Point, 0)
+	for y, row := range v.rows() {
+		for x, ch := range row {
+			pt := min.Add(math.Pt(x, y))
+			feature := picfeature(ch)
+			if feature == FeatFloor && ch != ' ' && ch != '.' {
+				stuff = append(stuff, math.Pt(x, y))
+			}
+			l.At(pt).Feature = feature
+		}
+	}
+	for _, door := range v.entrances() {
+		if pt := min.Add(door); pt != entrance {
+			l.At(pt).Feature = FeatWall
+		}
+	}
+
+	rows := v.rows()
+	for _, at := range stuff {
+		pt, ch := min.Add(at), rows[at.Y][at.X]
+		var groups [][]*Obj
+		switch ch {
+		case 'm':
+			groups = Generate(1, floor, vaultWiggle, Monsters, g)
+		case 'M':
+			groups = Generate(1, deep, vaultWiggle, Monsters, g)
+		case 'i':
+			groups = Generate(1, floor, vaultWiggle, Items, g)
+		case '*':
+			groups = Generate(1, deep, vaultWiggle, Items, g)
+		default:
+			log.Printf("Vault %s has unknown symbol %q.", v.Name, ch)
+		}
+		for _, group := range groups {
+			placegroup(l, group, pt)
+		}
+	}
+}
+
+// Places 'group' at 'pt', spilling monsters onto the tiles around it if
+// there's more than one.
+func placegroup(l *Level, group []*Obj, pt math.Point) {
+	for _, obj := range group {
+		if l.Place(obj, pt) {
+			continue
+		}
+		for _, tile := range l.Around(pt) {
+			if l.Place(obj, tile.Pos) {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package game
+
+// All of the vaults that levels can have.
+var Vaults = []*Vault{
+	{
+		Name:     "guard post",
+		MinFloor: 1,
+		MaxFloor: 5,
+		Rarity:   3,
+		Pic: `
+#######
+#m   m#
++  i  +
+#m   m#
+#######`,
+	},
+	{
+		Name:     "pillared hall",
+		MinFloor: 1,
+		MaxFloor: 5,
+		Rarity:   4,
+		Pic: `
+###########
+#m   m   m#
+# # # # # #
++    i    +
+# # # # # #
+#m   m   m#
+###########`,
+	},
+	{
+		Name:     "hidden cache",
+		MinFloor: 2,
+		MaxFloor: 5,
+		Rarity:   5,
+		Pic: `
+#########
+#m     m#
+# ##S## #
++ #* *# +
+# ##### #
+#   i   #
+#########`,
+	},
+	{
+		Name:     "flooded shrine",
+		MinFloor: 1,
+		MaxFloor: 5,
+		Rarity:   5,
+		Pic: `
+###########
+#m ~~~~~ m#
+# ~~WWW~~ #
++ ~WW*WW~ +
+# ~~WWW~~ #
+#m ~~~~~ m#
+###########`,
+	},
+	{
+		Name:     "lesser vault",
+		MinFloor: 3,
+		MaxFloor: 5,
+		Rarity:   8,
+		Pic: `
+#############
+#m         m#
+# ####+#### #
+# #*  M  *# #
+# ######### #
+#m    i    m#
+######+######`,
+	},
+}
@@ -0,0 +1,92 @@
+package game
+
+import (
+	"github.com/MichaelDiBernardo/srl/lib/math"
+	"strings"
+	"testing"
+)
+
+func TestVaultsAreWellFormed(t *testing.T) {
+	for _, v := range Vaults {
+		if v.MinFloor > v.MaxFloor || v.Rarity < 1 {
+			t.Errorf(`Vault %s has floors %d-%d and rarity %d`, v.Name, v.MinFloor, v.MaxFloor, v.Rarity)
+		}
+		if len(v.entrances()) == 0 {
+			t.Errorf(`Vault %s has no way in`, v.Name)
+		}
+
+		size := v.size()
+		for y, row := range v.rows() {
+			if len(row) != size.X {
+				t.Errorf(`Vault %s row %d is %d wide; want %d`, v.Name, y, len(row), size.X)
+			}
+			for x, ch := range row {
+				_, feature := picFeatures[ch]
+				if !feature && !strings.ContainsRune("mMi*", ch) {
+					t.Errorf(`Vault %s has unknown symbol %q`, v.Name, ch)
+				}
+				edge := x == 0 || y == 0 || x == size.X-1 || y == size.Y-1
+				if edge && ch != '#' && ch != '+' {
+					t.Errorf(`Vault %s has %q in its outside wall`, v.Name, ch)
+				}
+			}
+		}
+	}
+}
+
+var vtRoom = math.Rect(math.Pt(2, 2), math.Pt(7, 7))
+
+// A level that's all wall except for one room.
+func vaultTestLevel(l *Level) *Level {
+	fillmap(l.Map, l.Bounds, FeatWall)
+	fillmap(l.Map, vtRoom, FeatFloor)
+	l.Place(l.game.Player, vtRoom.Center())
+	return l
+}
+
+func TestCarveVault(t *testing.T) {
+	g := newTestGame()
+	l := NewLevel(40, 30, g, vaultTestLevel)
+	g.Level = l
+	joint := math.Pt(3, 3)
+	v := &Vault{
+		Name: "test",
+		Pic: `
+#####
++ i +
+#   #
+#####`,
+	}
+
+	area, _, ok := carvevault(l, v, []math.Rectangle{vtRoom}, nil, []math.Point{joint}, nil)
+	if !ok {
+		t.Fatal(`carvevault couldn't place a vault on an empty level`)
+	}
+	if area.Intersect(vtRoom) != math.ZeroRect {
+		t.Errorf(`Vault at %v overlaps the room at %v`, area, vtRoom)
+	}
+
+	// The corridor might have a secret door in it, which can still be found.
+	reach := l.FlowMap([]math.Point{joint}, PathCost, func(t *Tile) bool {
+		return t.Feature != FeatWall
+	})
+	doors, items := 0, 0
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			tile := l.At(math.Pt(x, y))
+			if tile.Feature != FeatWall && reach.At(tile.Pos) == FlowUnreachable {
+				t.Errorf(`Vault tile %v can't be reached from the room`, tile.Pos)
+			}
+			if tile.Feature == FeatClosedDoor {
+				doors++
+			}
+			items += tile.Items.Len()
+		}
+	}
+	if doors != 1 {
+		t.Errorf(`Vault has %d doors; want the other one walled up`, doors)
+	}
+	if items == 0 {
+		t.Error(`Vault has no item in it`)
+	}
+}
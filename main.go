@@ -1,13 +1,18 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/MichaelDiBernardo/srl/lib/client"
 	"github.com/MichaelDiBernardo/srl/lib/client/console"
+	"github.com/MichaelDiBernardo/srl/lib/config"
 	"github.com/MichaelDiBernardo/srl/lib/game"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 // A single running game. Once we get to serverland, srl will handle multiple
@@ -15,14 +20,30 @@ import (
 type Session struct {
 	client client.Client
 	game   *game.Game
+	config *config.Config
+	// Where to write the morgue file when the game ends, unless the config
+	// names a directory and this didn't come from the command line.
+	morguedir string
+	// Whether morguedir was given on the command line.
+	morgueflag bool
+	// Where high scores are kept.
+	scorefile string
+	// Set once we've recorded the end of the game.
+	recorded bool
+	// The morgue file for this game, once it's been written.
+	morgue string
 }
 
-func NewSession() *Session {
+func NewSession(cfg *config.Config, cfgpath, morguedir string, morgueflag bool, scorefile string) *Session {
 	g := game.NewGame()
 	g.Start()
 	return &Session{
-		client: console.New(),
-		game:   g,
+		client:     console.New(cfg, cfgpath),
+		game:       g,
+		config:     cfg,
+		morguedir:  morguedir,
+		morgueflag: morgueflag,
+		scorefile:  scorefile,
 	}
 }
 
@@ -33,6 +54,10 @@ func (s *Session) Loop() {
 	}
 	defer s.client.Close()
 
+	// Starting the game may already have produced events (e.g. switching to
+	// character creation), so let the client see those first.
+	s.handleEvents()
+
 	for {
 		// Take potentially multiple inputs from the user until they do
 		// something that generates a command.
@@ -52,15 +77,188 @@ func (s *Session) Loop() {
 			return
 		}
 		s.game.Handle(command)
+		s.handleEvents()
+	}
+}
 
-		// TODO: HACK fix the render calls, we shouldn't need one for every single
-		// event handled.
-		for !s.game.Events.Empty() {
-			ev := s.game.Events.Next()
-			s.client.HandleEvent(ev)
-			s.client.Render(s.game)
+// Pass every pending game event to the client.
+func (s *Session) handleEvents() {
+	// TODO: HACK fix the render calls, we shouldn't need one for every single
+	// event handled.
+	for !s.game.Events.Empty() {
+		ev := s.game.Events.Next()
+		s.client.HandleEvent(ev)
+		s.client.Render(s.game)
+	}
+
+	if s.game.Ending != nil && !s.recorded {
+		s.record()
+	}
+}
+
+// Record how this game went in the morgue directory and the high scores.
+func (s *Session) record() {
+	s.recorded = true
+
+	path, err := s.game.SaveMorgue(s.morgueDir())
+	if err != nil {
+		log.Printf("Could not write morgue file: %v", err)
+	}
+	s.morgue = path
+
+	if err := s.game.RecordScore(s.scorefile); err != nil {
+		log.Printf("Could not record score: %v", err)
+	}
+}
+
+// Where to write the morgue file. The config is checked now rather than at
+// startup, since it can change while the game is running.
+func (s *Session) morgueDir() string {
+	if s.config.MorgueDir != "" && !s.morgueflag {
+		return s.config.MorgueDir
+	}
+	return s.morguedir
+}
+
+// Where srl keeps its files: a directory under the user's config dir, or the
+// current directory if there isn't one.
+func configDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "."
+	}
+	return filepath.Join(dir, "srl")
+}
+
+// Print every recorded game, best first.
+func printScores(path string) error {
+	scores, err := game.LoadScores(path)
+	if err != nil {
+		return err
+	}
+
+	ranked := scores.Ranked()
+	if len(ranked) == 0 {
+		fmt.Println("No games have been played yet.")
+		return nil
+	}
+	for i, entry := range ranked {
+		fmt.Printf("%4d. %s\n", i+1, entry.Describe())
+	}
+	return nil
+}
+
+// Generates levels without playing them, so that generators can be tuned.
+// 'args' are whatever came after "gen" on the command line.
+func genLevels(args []string) error {
+	flags := flag.NewFlagSet("gen", flag.ExitOnError)
+	seed := flags.Int64("seed", 1, "seed to generate from; stats start here")
+	floor := flags.Int("floor", 1, "floor to generate")
+	genname := flags.String("gen", "", "generator to use; picked by floor if not given")
+	overlay := flags.Bool("overlay", false, "draw monsters and items too")
+	pngpath := flags.String("png", "", "also write the level to this PNG file")
+	nstats := flags.Int("stats", 0, "instead of drawing a level, report stats over this many seeds")
+	flags.Usage = func() {
+		names := make([]string, 0, len(game.LevelGens))
+		for _, gen := range game.LevelGens {
+			names = append(names, gen.Name)
+		}
+		fmt.Fprintf(os.Stderr, "usage: srl gen [flags]\ngenerators: %s\n", strings.Join(names, ", "))
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if *floor < 1 || *floor > game.MaxFloor {
+		return fmt.Errorf("floor must be from 1 to %d", game.MaxFloor)
+	}
+	var gen *game.LevelGen
+	if *genname != "" {
+		found, ok := game.FindLevelGen(*genname)
+		if !ok {
+			return fmt.Errorf("no generator called %q", *genname)
 		}
+		gen = found
 	}
+
+	// The generators log a lot; none of it is wanted here.
+	log.SetOutput(ioutil.Discard)
+
+	if *nstats > 0 {
+		printGenStats(*seed, *nstats, *floor, gen)
+		return nil
+	}
+
+	l, used := game.PreviewLevel(*seed, *floor, gen)
+	fmt.Printf("Floor %d, seed %d, %s:\n", *floor, *seed, used.Name)
+	for _, row := range game.PreviewMap(l, *overlay) {
+		fmt.Println(row)
+	}
+
+	if *pngpath != "" {
+		f, err := os.Create(*pngpath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := game.WritePreviewPNG(f, l, *overlay); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %s\n", *pngpath)
+	}
+	return nil
+}
+
+// Generates 'n' levels from the seeds starting at 'seed', and prints what
+// each generator made on average.
+func printGenStats(seed int64, n, floor int, gen *game.LevelGen) {
+	surveys := map[string]*game.GenSurvey{}
+	for i := 0; i < n; i++ {
+		l, used := game.PreviewLevel(seed+int64(i), floor, gen)
+		if surveys[used.Name] == nil {
+			surveys[used.Name] = game.NewGenSurvey()
+		}
+		surveys[used.Name].Add(l.Stats())
+	}
+
+	fmt.Printf("Floor %d, seeds %d to %d:\n", floor, seed, seed+int64(n)-1)
+	for _, used := range game.LevelGens {
+		s := surveys[used.Name]
+		if s == nil {
+			continue
+		}
+		fmt.Printf("\n%s (%d levels)\n", used.Name, s.Levels)
+		fmt.Printf("  %-12s %7s %5s %5s\n", "", "mean", "min", "max")
+		ranges := []struct {
+			name string
+			r    game.StatRange
+		}{
+			{"rooms", s.Rooms},
+			{"corridor", s.Corridor},
+			{"vaults", s.Vaults},
+			{"secret doors", s.SecretDoors},
+			{"terrain", s.Terrain},
+			{"traps", s.Traps},
+			{"items", s.Items},
+			{"monsters", s.Monsters},
+		}
+		for _, row := range ranges {
+			fmt.Printf("  %-12s %7.1f %5d %5d\n", row.name, s.Mean(row.r), row.r.Min, row.r.Max)
+		}
+		for _, name := range s.CommonSpecies() {
+			fmt.Printf("    %-20s %7.2f\n", name, float64(s.Species[name])/float64(s.Levels))
+		}
+	}
+}
+
+// Was the flag 'name' given on the command line?
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
 
 var logfile *os.File
@@ -78,9 +276,42 @@ func teardown() {
 }
 
 func main() {
+	cfgpath := flag.String("config", filepath.Join(configDir(), "config"), "config file to use")
+	morguedir := flag.String("morgue", filepath.Join(configDir(), "morgue"), "directory to write morgue files to; overrides the config")
+	scorefile := flag.String("scores", filepath.Join(configDir(), "scores.json"), "file to keep high scores in")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: srl [flags] [scores | gen [gen flags]]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.Arg(0) == "scores" {
+		if err := printScores(*scorefile); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not read scores: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if flag.Arg(0) == "gen" {
+		if err := genLevels(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not generate levels: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cfg, err := config.Load(*cfgpath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load %s: %v\n", *cfgpath, err)
+		os.Exit(1)
+	}
+
 	setup()
 	defer teardown()
 
-	s := NewSession()
+	s := NewSession(cfg, *cfgpath, *morguedir, flagSet("morgue"), *scorefile)
 	s.Loop()
+	if s.morgue != "" {
+		fmt.Printf("Morgue file written to %s\n", s.morgue)
+	}
 }